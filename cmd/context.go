@@ -4,8 +4,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/dateparse"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/chris-regnier/diaryctl/internal/ui"
 	"github.com/spf13/cobra"
@@ -100,18 +104,71 @@ var contextDeleteCmd = &cobra.Command{
 	},
 }
 
+var (
+	contextSetFor   string
+	contextSetUntil string
+)
+
 var contextSetCmd = &cobra.Command{
-	Use:     "set <name>",
-	Short:   "Activate a manual context",
-	Example: `  diaryctl context set sprint:23`,
-	Args:    cobra.ExactArgs(1),
+	Use:   "set <name>",
+	Short: "Activate a manual context",
+	Long: `Activate a manual context.
+
+Use --for or --until to time-box the context. Expired contexts are
+dropped automatically and stop being attached to new entries.
+
+--for accepts Go durations plus day and week units (4h, 90m, 2d, 1w).
+--until accepts a day or period such as tomorrow, friday, next week or
+2026-03-01 (expiring at the end of it), YYYY-MM-DD HH:MM, or HH:MM (next
+occurrence).`,
+	Example: `  diaryctl context set sprint:23
+  diaryctl context set incident:123 --for 4h
+  diaryctl context set release:2.1 --until friday`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		if err := context.SetManualContext(appConfig.DataDir, name); err != nil {
+
+		if contextSetFor != "" && contextSetUntil != "" {
+			fmt.Fprintln(os.Stderr, "Error: --for and --until cannot be used together")
+			os.Exit(1)
+		}
+
+		var expiresAt *time.Time
+		now := time.Now()
+		if contextSetFor != "" {
+			d, err := parseContextDuration(contextSetFor)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+			t := now.Add(d)
+			expiresAt = &t
+		}
+		if contextSetUntil != "" {
+			t, err := parseContextUntil(contextSetUntil, now)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+			expiresAt = &t
+		}
+
+		var err error
+		if expiresAt != nil {
+			err = context.SetManualContextUntil(appConfig.DataDir, name, *expiresAt)
+		} else {
+			err = context.SetManualContext(appConfig.DataDir, name)
+		}
+		if err != nil {
 			fmt.Fprintln(os.Stderr, "Error:", err)
 			os.Exit(2)
 		}
-		fmt.Fprintf(os.Stdout, "Activated context %q.\n", name)
+
+		if expiresAt != nil {
+			fmt.Fprintf(os.Stdout, "Activated context %q until %s.\n", name, expiresAt.Local().Format("2006-01-02 15:04"))
+		} else {
+			fmt.Fprintf(os.Stdout, "Activated context %q.\n", name)
+		}
 		return nil
 	},
 }
@@ -137,18 +194,14 @@ var contextActiveCmd = &cobra.Command{
 	Short:   "Show currently active contexts",
 	Example: `  diaryctl context active`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		manual, err := context.LoadManualContexts(appConfig.DataDir)
+		manual, err := context.LoadActiveManualContexts(appConfig.DataDir)
 		if err != nil {
 			fmt.Fprintln(os.Stderr, "Error:", err)
 			os.Exit(2)
 		}
 
 		var autoContexts []string
-		for _, name := range appConfig.ContextResolvers {
-			r := context.LookupContextResolver(name)
-			if r == nil {
-				continue
-			}
+		for _, r := range buildContextResolvers(appConfig.ContextResolvers) {
 			names, err := r.Resolve()
 			if err != nil {
 				continue
@@ -157,9 +210,21 @@ var contextActiveCmd = &cobra.Command{
 		}
 
 		if jsonOutput {
-			ui.FormatJSON(os.Stdout, map[string][]string{
-				"manual": manual,
-				"auto":   autoContexts,
+			// manual stays a list of names; expiries are reported alongside
+			names := []string{}
+			for _, c := range manual {
+				names = append(names, c.Name)
+			}
+			if manual == nil {
+				manual = []context.ManualContext{}
+			}
+			if autoContexts == nil {
+				autoContexts = []string{}
+			}
+			ui.FormatJSON(os.Stdout, map[string]any{
+				"manual":          names,
+				"manual_contexts": manual,
+				"auto":            autoContexts,
 			})
 		} else {
 			ui.FormatActiveContexts(os.Stdout, manual, autoContexts)
@@ -170,6 +235,8 @@ var contextActiveCmd = &cobra.Command{
 
 func init() {
 	contextDeleteCmd.Flags().BoolVar(&forceDeleteContext, "force", false, "skip confirmation prompt")
+	contextSetCmd.Flags().StringVar(&contextSetFor, "for", "", "deactivate after a duration (e.g. 4h, 2d)")
+	contextSetCmd.Flags().StringVar(&contextSetUntil, "until", "", "deactivate at a time (e.g. friday, 2026-03-01, 17:00)")
 
 	contextCmd.AddCommand(contextListCmd)
 	contextCmd.AddCommand(contextShowCmd)
@@ -180,3 +247,53 @@ func init() {
 
 	rootCmd.AddCommand(contextCmd)
 }
+
+// parseContextDuration parses a Go duration, additionally accepting
+// whole-number day ("2d") and week ("1w") units.
+func parseContextDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if n := len(s); n > 1 && (s[n-1] == 'd' || s[n-1] == 'w') {
+		if count, err := strconv.Atoi(s[:n-1]); err == nil && count > 0 {
+			unit := 24 * time.Hour
+			if s[n-1] == 'w' {
+				unit *= 7
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q (e.g. 4h, 90m, 2d)", s)
+	}
+	return d, nil
+}
+
+// parseContextUntil resolves an --until value to an absolute expiry time.
+// Day and period values (today, friday, next week, dates) expire at the end
+// of their last day; bare weekday and month names mean the next occurrence.
+// Values that resolve to now or earlier are rejected.
+func parseContextUntil(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "eod" {
+		s = "today"
+	}
+	var at time.Time
+	p := dateparse.Parser{Now: func() time.Time { return now }, Forward: true}
+	if r, err := p.Range(s); err == nil {
+		at = r.End.AddDate(0, 0, 1)
+	} else if t, err := time.ParseInLocation("2006-01-02 15:04", s, now.Location()); err == nil {
+		at = t
+	} else if t, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		at = time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+		if !at.After(now) {
+			at = at.AddDate(0, 0, 1)
+		}
+	} else {
+		return time.Time{}, fmt.Errorf("invalid --until value %q (e.g. friday, tomorrow, next week, 2026-03-01, 17:00)", s)
+	}
+
+	if !at.After(now) {
+		return time.Time{}, fmt.Errorf("--until %q resolves to %s, which is already past", s, at.Local().Format("2006-01-02 15:04"))
+	}
+	return at, nil
+}
@@ -3,16 +3,24 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"slices"
+	"time"
 
 	"github.com/chris-regnier/diaryctl/internal/context"
+	gitctx "github.com/chris-regnier/diaryctl/internal/context/git"
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/template"
 )
 
 // buildContentProviders creates ContentProviders from config names, skipping unknown.
 func buildContentProviders(names []string) []context.ContentProvider {
+	cfg := providerConfig()
 	var providers []context.ContentProvider
 	for _, name := range names {
-		p := context.LookupContentProvider(name)
+		p := context.LookupContentProviderWithConfig(name, cfg)
 		if p != nil {
 			providers = append(providers, p)
 		}
@@ -22,9 +30,10 @@ func buildContentProviders(names []string) []context.ContentProvider {
 
 // buildContextResolvers creates ContextResolvers from config names, skipping unknown.
 func buildContextResolvers(names []string) []context.ContextResolver {
+	cfg := providerConfig()
 	var resolvers []context.ContextResolver
 	for _, name := range names {
-		r := context.LookupContextResolver(name)
+		r := context.LookupContextResolverWithConfig(name, cfg)
 		if r != nil {
 			resolvers = append(resolvers, r)
 		}
@@ -36,14 +45,133 @@ func buildContextResolvers(names []string) []context.ContextResolver {
 // loads manual contexts, calls ResolveActiveContexts, and prints warnings.
 // Returns the resolved context refs.
 func resolveContexts() []entry.ContextRef {
+	return resolveContextsWith(nil)
+}
+
+// resolveContextsWith is like resolveContexts but also activates the extra
+// context names given, creating them if needed.
+func resolveContextsWith(extra []string) []entry.ContextRef {
 	resolvers := buildContextResolvers(appConfig.ContextResolvers)
 	manual, err := context.LoadManualContexts(appConfig.DataDir)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to load manual contexts: %v\n", err)
 	}
+	manual = append(manual, extra...)
 	refs, warnings := context.ResolveActiveContexts(resolvers, manual, store)
 	for _, w := range warnings {
 		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
 	}
 	return refs
 }
+
+// providerConfig builds provider settings from the app config.
+// Git activity is collected since the most recent diary entry, falling back to
+// the configured lookback window when there are no entries yet.
+func providerConfig() context.ProviderConfig {
+	if appConfig == nil {
+		return context.ProviderConfig{}
+	}
+	cfg := context.ProviderConfig{}
+	cfg.Calendar.Paths = appConfig.Calendar.Paths
+	cfg.GitActivity = gitActivityOptions()
+	return cfg
+}
+
+// gitActivityOptions builds git-activity settings from the app config.
+func gitActivityOptions() gitctx.ActivityOptions {
+	ga := appConfig.GitActivity
+	opts := gitctx.ActivityOptions{Author: ga.Author, Workspace: ga.Workspace}
+	if !hasContentProvider("git-activity") {
+		return opts
+	}
+
+	if store != nil {
+		if entries, err := store.List(storage.ListOptions{Limit: 1}); err == nil && len(entries) > 0 {
+			opts.Since = entries[0].CreatedAt
+			return opts
+		}
+	}
+	if d, err := time.ParseDuration(ga.Lookback); err == nil {
+		opts.Since = time.Now().Add(-d)
+	}
+	return opts
+}
+
+// hasContentProvider reports whether name is among the configured content providers.
+func hasContentProvider(name string) bool {
+	for _, n := range appConfig.ContextProviders {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// activeContextNames returns the names of the manual and auto-detected
+// contexts without creating them in storage.
+func activeContextNames() []string {
+	names, _ := context.LoadManualContexts(appConfig.DataDir)
+	for _, r := range buildContextResolvers(appConfig.ContextResolvers) {
+		resolved, err := r.Resolve()
+		if err != nil {
+			continue
+		}
+		for _, n := range resolved {
+			if n != "" && !slices.Contains(names, n) {
+				names = append(names, n)
+			}
+		}
+	}
+	return names
+}
+
+// templateRenderOptions builds the options used to render templates from
+// the CLI: active contexts, yesterday's entry and the configured strictness.
+func templateRenderOptions(vars map[string]string) template.RenderOptions {
+	now := time.Now()
+	return template.RenderOptions{
+		Vars:     vars,
+		Now:      now,
+		Contexts: activeContextNames,
+		Yesterday: func() string {
+			return daily.ContentOn(store, day.Of(now).AddDate(0, 0, -1))
+		},
+		Strict: appConfig.Templates.Strict,
+	}
+}
+
+// templateRules converts the configured default template rules.
+func templateRules() []template.Rule {
+	if appConfig == nil {
+		return nil
+	}
+	rules := make([]template.Rule, len(appConfig.Templates.Rules))
+	for i, r := range appConfig.Templates.Rules {
+		rules[i] = template.Rule{
+			Name:     r.Name,
+			Template: r.Template,
+			Days:     r.Days,
+			After:    r.After,
+			Before:   r.Before,
+			Contexts: r.Contexts,
+		}
+	}
+	return rules
+}
+
+// selectDefaultTemplate returns the default template for a new entry at now,
+// chosen by the first matching template rule or default_template. Malformed
+// rules are reported as warnings.
+func selectDefaultTemplate(now time.Time) string {
+	if appConfig == nil {
+		return ""
+	}
+	if len(appConfig.Templates.Rules) == 0 {
+		return appConfig.DefaultTemplate
+	}
+	sel, err := template.Select(templateRules(), appConfig.DefaultTemplate, now, activeContextNames())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
+	return sel.Template
+}
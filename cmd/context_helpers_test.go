@@ -2,6 +2,10 @@ package cmd
 
 import (
 	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/config"
+	"github.com/chris-regnier/diaryctl/internal/entry"
 )
 
 func TestBuildContentProviders(t *testing.T) {
@@ -48,3 +52,29 @@ func TestBuildContextResolversSkipsUnknown(t *testing.T) {
 		t.Fatalf("expected 0 resolvers, got %d", len(resolvers))
 	}
 }
+
+func TestProviderConfigGitActivitySince(t *testing.T) {
+	setupTestEnv(t)
+	appConfig.ContextProviders = []string{"git-activity"}
+	appConfig.GitActivity = config.GitActivityConfig{Author: "me@example.com", Lookback: "2h"}
+
+	// No entries: falls back to lookback window
+	cfg := providerConfig()
+	if cfg.GitActivity.Author != "me@example.com" {
+		t.Errorf("expected author to be passed through, got %q", cfg.GitActivity.Author)
+	}
+	if d := time.Since(cfg.GitActivity.Since); d < 2*time.Hour || d > 2*time.Hour+time.Minute {
+		t.Errorf("expected since ~2h ago, got %v ago", d)
+	}
+
+	// With an entry: since the most recent entry
+	id, _ := entry.NewID()
+	created := time.Now().Add(-5 * time.Hour).UTC().Truncate(time.Second)
+	if err := store.Create(entry.Entry{ID: id, Content: "earlier", CreatedAt: created, UpdatedAt: created}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	cfg = providerConfig()
+	if !cfg.GitActivity.Since.Equal(created) {
+		t.Errorf("expected since %v, got %v", created, cfg.GitActivity.Since)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseContextDuration(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"4h", 4 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"2d", 48 * time.Hour, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"", 0, true},
+		{"-1h", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseContextDuration(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseContextUntil(t *testing.T) {
+	// Wednesday 2026-03-04 10:30
+	now := time.Date(2026, 3, 4, 10, 30, 0, 0, time.Local)
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{"today", time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local), false},
+		{"tomorrow", time.Date(2026, 3, 6, 0, 0, 0, 0, time.Local), false},
+		{"friday", time.Date(2026, 3, 7, 0, 0, 0, 0, time.Local), false},
+		{"Fri", time.Date(2026, 3, 7, 0, 0, 0, 0, time.Local), false},
+		{"wednesday", time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local), false},
+		{"2026-03-10", time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local), false},
+		{"2026-03-10 17:00", time.Date(2026, 3, 10, 17, 0, 0, 0, time.Local), false},
+		{"eod", time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local), false},
+		{"next week", time.Date(2026, 3, 16, 0, 0, 0, 0, time.Local), false},
+		{"+2d", time.Date(2026, 3, 7, 0, 0, 0, 0, time.Local), false},
+		{"17:00", time.Date(2026, 3, 4, 17, 0, 0, 0, time.Local), false},
+		{"09:00", time.Date(2026, 3, 5, 9, 0, 0, 0, time.Local), false},
+		{"someday", time.Time{}, true},
+		{"yesterday", time.Time{}, true},
+		{"2020-01-01", time.Time{}, true},
+		{"2026-03-04 09:00", time.Time{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := parseContextUntil(tt.input, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
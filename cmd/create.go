@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -25,23 +26,52 @@ If "-" is provided, content is read from stdin.
 If no content is provided, your editor is opened.
 
 Use --template to pre-fill the editor with template content.
-Use --no-template to skip the default template.`,
+Without it, the default template is chosen by the template rules in the
+config (see "template which"), falling back to default_template.
+Use --no-template to skip the default template.
+
+Use --guided to be prompted for the variables a template declares in its
+front matter; the rendered template is saved without opening the editor
+unless --edit is given. Answers can also be supplied with --var key=value
+or --vars-file (YAML, "-" for stdin); when not running in a terminal, only
+these answers and the declared defaults are used.`,
 	Example: `  diaryctl create "Today was great"
   diaryctl create Today was a good day
   echo "piped content" | diaryctl create -
   diaryctl create
   diaryctl create --template daily
-  diaryctl create --template daily,prompts`,
+  diaryctl create --template daily,prompts
+  diaryctl create --template standup --guided
+  diaryctl create --template standup --guided --var blockers=none --edit
+  echo 'yesterday: auth work' | diaryctl create --template standup --guided --vars-file -`,
 	PostRunE: invalidateCachePostRun,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		templateFlag, _ := cmd.Flags().GetString("template")
 		noTemplate, _ := cmd.Flags().GetBool("no-template")
+		guided, _ := cmd.Flags().GetBool("guided")
+		editGuided, _ := cmd.Flags().GetBool("edit")
+		varFlags, _ := cmd.Flags().GetStringArray("var")
+		varsFile, _ := cmd.Flags().GetString("vars-file")
 
 		// Check for conflicting flags
 		if templateFlag != "" && noTemplate {
 			fmt.Fprintln(os.Stderr, "Error: --template and --no-template cannot be used together")
 			os.Exit(1)
 		}
+		if guided && (noTemplate || len(args) > 0) {
+			fmt.Fprintln(os.Stderr, "Error: --guided cannot be used with --no-template or inline content")
+			os.Exit(1)
+		}
+		if editGuided && !guided {
+			fmt.Fprintln(os.Stderr, "Error: --edit requires --guided")
+			os.Exit(1)
+		}
+
+		preset, err := presetAnswers(varFlags, varsFile)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
 
 		var content string
 		var templateRefs []entry.TemplateRef
@@ -63,15 +93,21 @@ Use --no-template to skip the default template.`,
 		default:
 			// Resolve template names
 			var templateContent string
+			var names []string
+			var defaultTemplate string
 			if !noTemplate {
-				names := tmpl.ParseNames(templateFlag)
-				if len(names) == 0 && appConfig.DefaultTemplate != "" {
-					// Use config default
-					names = tmpl.ParseNames(appConfig.DefaultTemplate)
+				names = tmpl.ParseNames(templateFlag)
+				if len(names) == 0 {
+					defaultTemplate = selectDefaultTemplate(time.Now())
+				}
+				if len(names) == 0 && defaultTemplate != "" {
+					// Use config default or the matching template rule
+					names = tmpl.ParseNames(defaultTemplate)
 					// Default template: graceful fallback on error
 					tc, refs, err := tmpl.Compose(store, names)
 					if err != nil {
-						fmt.Fprintf(os.Stderr, "Warning: default template %q not found, skipping\n", appConfig.DefaultTemplate)
+						fmt.Fprintf(os.Stderr, "Warning: default template %q not found, skipping\n", defaultTemplate)
+						names = nil
 					} else {
 						templateContent = tc
 						templateRefs = refs
@@ -88,13 +124,51 @@ Use --no-template to skip the default template.`,
 				}
 			}
 
+			// Collect answers for template variables
+			answers := preset
+			if guided {
+				if len(names) == 0 {
+					fmt.Fprintln(os.Stderr, "Error: --guided requires a template (use --template or set default_template)")
+					os.Exit(1)
+				}
+				answers, err = guidedAnswers(names, preset, isInteractive())
+				if errors.Is(err, ui.ErrGuidedCancelled) {
+					fmt.Fprintln(os.Stdout, "Cancelled.")
+					return nil
+				}
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "Error:", err)
+					os.Exit(1)
+				}
+			} else if len(names) > 0 {
+				answers = withDefaults(names, answers)
+			}
+
+			// Render template functions and variables
+			if templateContent != "" {
+				rendered, err := tmpl.RenderWith(templateContent, templateRenderOptions(answers))
+				switch {
+				case err == nil:
+					templateContent = rendered
+				case templateFlag != "" || guided:
+					fmt.Fprintln(os.Stderr, "Error:", err)
+					os.Exit(1)
+				default:
+					fmt.Fprintf(os.Stderr, "Warning: rendering default template %q: %v\n", defaultTemplate, err)
+				}
+			}
+
 			// Compose content from providers and template
 			providers := buildContentProviders(appConfig.ContextProviders)
 			editorContent := ctxpkg.ComposeContent(providers, templateContent)
 
+			if guided && !editGuided {
+				content = editorContent
+				break
+			}
+
 			// Open editor
 			editorCmd := editor.ResolveEditor(appConfig.Editor)
-			var err error
 			var changed bool
 			content, changed, err = editor.Edit(editorCmd, editorContent)
 			if err != nil {
@@ -137,6 +211,7 @@ Use --no-template to skip the default template.`,
 			fmt.Fprintln(os.Stderr, "Error:", err)
 			os.Exit(2)
 		}
+		enrichEntry(e.ID, e.Content)
 
 		if jsonOutput {
 			ui.FormatJSON(os.Stdout, e)
@@ -151,5 +226,9 @@ Use --no-template to skip the default template.`,
 func init() {
 	createCmd.Flags().String("template", "", "template(s) to use (comma-separated)")
 	createCmd.Flags().Bool("no-template", false, "skip default template")
+	createCmd.Flags().Bool("guided", false, "prompt for the template's variables")
+	createCmd.Flags().Bool("edit", false, "review guided content in the editor before saving")
+	createCmd.Flags().StringArray("var", nil, "template variable as key=value (repeatable)")
+	createCmd.Flags().String("vars-file", "", "YAML file of template variables (\"-\" for stdin)")
 	rootCmd.AddCommand(createCmd)
 }
@@ -31,28 +31,17 @@ In non-interactive mode (piped output, --no-interactive, or --json),
 prints a grouped-by-day summary to stdout.`,
 	Example: `  diaryctl daily
   diaryctl daily --from 2026-01-01 --to 2026-01-31
+  diaryctl daily --from "last month"
   diaryctl daily --template daily
   diaryctl daily --no-interactive
   diaryctl daily --no-interactive --json
   diaryctl daily | head -20`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Parse date flags
-		var startDate, endDate *time.Time
-		if dailyFrom != "" {
-			t, err := time.ParseInLocation("2006-01-02", dailyFrom, time.Local)
-			if err != nil {
-				fmt.Fprintln(os.Stderr, "Error: invalid --from date format (use YYYY-MM-DD):", dailyFrom)
-				os.Exit(1)
-			}
-			startDate = &t
-		}
-		if dailyTo != "" {
-			t, err := time.ParseInLocation("2006-01-02", dailyTo, time.Local)
-			if err != nil {
-				fmt.Fprintln(os.Stderr, "Error: invalid --to date format (use YYYY-MM-DD):", dailyTo)
-				os.Exit(1)
-			}
-			endDate = &t
+		startDate, endDate, err := parseDateBounds(dailyFrom, dailyTo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
 		}
 
 		// Mode selection
@@ -111,8 +100,8 @@ func runDailyNonInteractive(startDate, endDate *time.Time, templateFilter string
 }
 
 func init() {
-	dailyCmd.Flags().StringVar(&dailyFrom, "from", "", "start date filter (YYYY-MM-DD, inclusive)")
-	dailyCmd.Flags().StringVar(&dailyTo, "to", "", "end date filter (YYYY-MM-DD, inclusive)")
+	dailyCmd.Flags().StringVar(&dailyFrom, "from", "", "start date filter, inclusive ("+dateFlagHelp+")")
+	dailyCmd.Flags().StringVar(&dailyTo, "to", "", "end date filter, inclusive ("+dateFlagHelp+")")
 	dailyCmd.Flags().BoolVar(&dailyNoInteractive, "no-interactive", false, "force non-interactive output")
 	dailyCmd.Flags().StringVar(&dailyTemplateFilter, "template", "", "filter by template name")
 	rootCmd.AddCommand(dailyCmd)
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/dateparse"
+)
+
+// dates resolves the date flags of every command. Tests pin its clock.
+var dates dateparse.Parser
+
+// dateFlagHelp is appended to the help of date flags.
+const dateFlagHelp = "e.g. 2026-03-05, yesterday, -3d, last monday"
+
+// parseDateBounds resolves a --from/--to pair: --from to the first day of its
+// range and --to to the last, so "--from march --to march" covers March.
+// Empty flags are left nil.
+func parseDateBounds(from, to string) (start, end *time.Time, err error) {
+	if from != "" {
+		r, err := dates.Range(from)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --from date: %w", err)
+		}
+		start = &r.Start
+	}
+	if to != "" {
+		r, err := dates.Range(to)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid --to date: %w", err)
+		}
+		end = &r.End
+	}
+	return start, end, nil
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDateBounds(t *testing.T) {
+	dates.Now = func() time.Time { return time.Date(2026, 10, 15, 9, 0, 0, 0, time.Local) }
+	t.Cleanup(func() { dates.Now = nil })
+
+	start, end, err := parseDateBounds("march", "last week")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start.Format("2006-01-02") != "2026-03-01" || end.Format("2006-01-02") != "2026-10-11" {
+		t.Errorf("bounds = %s..%s, want 2026-03-01..2026-10-11", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	}
+
+	start, end, err = parseDateBounds("", "-3d")
+	if err != nil || start != nil || end.Format("2006-01-02") != "2026-10-12" {
+		t.Errorf("bounds = %v..%v, %v", start, end, err)
+	}
+
+	if _, _, err := parseDateBounds("someday", ""); err == nil {
+		t.Error("expected an error for an unrecognized --from")
+	}
+}
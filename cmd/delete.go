@@ -54,6 +54,9 @@ var deleteCmd = &cobra.Command{
 			fmt.Fprintln(os.Stderr, "Error:", err)
 			os.Exit(2)
 		}
+		if err := dismissPending(id); err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: could not drop pending suggestions:", err)
+		}
 
 		if jsonOutput {
 			ui.FormatJSON(os.Stdout, ui.DeleteResult{ID: id, Deleted: true})
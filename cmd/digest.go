@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/dateparse"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/digest"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	digestWeek  bool
+	digestMonth bool
+	digestFrom  string
+	digestTo    string
+	digestSave  bool
+)
+
+var digestCmd = &cobra.Command{
+	Use:   "digest",
+	Short: "Summarize a week, month or date range as markdown",
+	Long: `Summarize the entries of a period as markdown: the entry count, every jot in
+chronological order, entries grouped by context, the sections of templated
+entries gathered by heading (e.g. all "Blockers" from standups), and [[id]]
+links back to each source entry.
+
+The period defaults to the current week (Monday to Sunday). With --save the
+digest is also stored as a new entry attributed to the "digest" template,
+which is created if it doesn't exist. Saved digests are left out of later
+digests.`,
+	Example: `  diaryctl digest
+  diaryctl digest --month
+  diaryctl digest --from 2026-03-01 --to 2026-03-15
+  diaryctl digest --from "last month" --to "last month"
+  diaryctl digest --week --save`,
+	PostRunE: invalidateCachePostRun,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, to, title, err := digestRange(digestWeek, digestMonth, digestFrom, digestTo, time.Now())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		return digestRun(cmd.OutOrStdout(), from, to, title, digestSave)
+	},
+}
+
+func init() {
+	digestCmd.Flags().BoolVar(&digestWeek, "week", false, "summarize the current week (default)")
+	digestCmd.Flags().BoolVar(&digestMonth, "month", false, "summarize the current month")
+	digestCmd.Flags().StringVar(&digestFrom, "from", "", "first day to summarize ("+dateFlagHelp+")")
+	digestCmd.Flags().StringVar(&digestTo, "to", "", "last day to summarize ("+dateFlagHelp+"; default today)")
+	digestCmd.Flags().BoolVar(&digestSave, "save", false, "also save the digest as a new entry")
+	rootCmd.AddCommand(digestCmd)
+}
+
+// digestRange resolves the period flags to the first and last day of the
+// digest and its title, relative to now.
+func digestRange(week, month bool, from, to string, now time.Time) (time.Time, time.Time, string, error) {
+	custom := from != "" || to != ""
+	set := 0
+	for _, b := range []bool{week, month, custom} {
+		if b {
+			set++
+		}
+	}
+	if set > 1 {
+		return time.Time{}, time.Time{}, "", errors.New("--week, --month and --from/--to are mutually exclusive")
+	}
+
+	today := day.Of(now)
+	switch {
+	case month:
+		start := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.Local)
+		return start, start.AddDate(0, 1, -1), "Monthly Digest", nil
+	case custom:
+		if from == "" {
+			return time.Time{}, time.Time{}, "", errors.New("--to needs --from")
+		}
+		p := dateparse.Parser{Now: func() time.Time { return now }}
+		r, err := p.Range(from)
+		if err != nil {
+			return time.Time{}, time.Time{}, "", fmt.Errorf("invalid --from date: %w", err)
+		}
+		start, end := r.Start, today
+		if to != "" {
+			if r, err = p.Range(to); err != nil {
+				return time.Time{}, time.Time{}, "", fmt.Errorf("invalid --to date: %w", err)
+			}
+			end = r.End
+		}
+		if end.Before(start) {
+			return time.Time{}, time.Time{}, "", errors.New("--to is before --from")
+		}
+		return start, end, "Digest", nil
+	default:
+		// ISO weeks start on Monday
+		start := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 6), "Weekly Digest", nil
+	}
+}
+
+// digestRun builds the digest of the entries created from the first to the
+// last day, prints it and, when save is set, stores it as a new entry.
+func digestRun(w io.Writer, from, to time.Time, title string, save bool) error {
+	entries, err := store.List(storage.ListOptions{StartDate: &from, EndDate: &to})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+	d := digest.Build(title, from, to, entries)
+
+	var saved *entry.Entry
+	if save {
+		e, err := saveDigest(d)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(2)
+		}
+		saved = &e
+	}
+
+	if jsonOutput {
+		ui.FormatJSON(w, d)
+		return nil
+	}
+	fmt.Fprint(w, d.Markdown())
+	if saved != nil {
+		fmt.Fprintln(w)
+		ui.FormatEntryCreated(w, *saved)
+	}
+	return nil
+}
+
+// saveDigest stores d as a new entry attributed to the digest template,
+// creating the template first if needed.
+func saveDigest(d digest.Digest) (entry.Entry, error) {
+	tmpl, err := store.GetTemplateByName(digest.TemplateName)
+	if errors.Is(err, storage.ErrNotFound) {
+		id, idErr := entry.NewID()
+		if idErr != nil {
+			return entry.Entry{}, idErr
+		}
+		now := time.Now().UTC()
+		tmpl = storage.Template{
+			ID:        id,
+			Name:      digest.TemplateName,
+			Content:   "# Digest\n\nGenerated by `diaryctl digest --save`.\n",
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := store.CreateTemplate(tmpl); err != nil {
+			return entry.Entry{}, fmt.Errorf("creating template %q: %w", digest.TemplateName, err)
+		}
+		fmt.Fprintf(os.Stderr, "Created template %q for saved digests\n", digest.TemplateName)
+		if tmpl, err = store.GetTemplateByName(digest.TemplateName); err != nil {
+			return entry.Entry{}, err
+		}
+	} else if err != nil {
+		return entry.Entry{}, err
+	}
+
+	id, err := entry.NewID()
+	if err != nil {
+		return entry.Entry{}, err
+	}
+	now := time.Now().UTC()
+	e := entry.Entry{
+		ID:        id,
+		Content:   strings.TrimSpace(d.Markdown()),
+		CreatedAt: now,
+		UpdatedAt: now,
+		Templates: []entry.TemplateRef{{TemplateID: tmpl.ID, TemplateName: tmpl.Name, TemplateVersion: tmpl.Version}},
+	}
+	if err := store.Create(e); err != nil {
+		return entry.Entry{}, err
+	}
+	return e, nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/digest"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func TestDigestRange(t *testing.T) {
+	now := time.Date(2026, 3, 5, 15, 0, 0, 0, time.Local) // Thursday
+	tests := []struct {
+		name        string
+		week, month bool
+		from, to    string
+		want        string
+		wantErr     bool
+	}{
+		{name: "default week", want: "2026-03-02..2026-03-08 Weekly Digest"},
+		{name: "week", week: true, want: "2026-03-02..2026-03-08 Weekly Digest"},
+		{name: "month", month: true, want: "2026-03-01..2026-03-31 Monthly Digest"},
+		{name: "from", from: "2026-02-20", want: "2026-02-20..2026-03-05 Digest"},
+		{name: "from to", from: "2026-02-20", to: "2026-02-22", want: "2026-02-20..2026-02-22 Digest"},
+		{name: "to only", to: "2026-02-22", wantErr: true},
+		{name: "reversed", from: "2026-02-22", to: "2026-02-20", wantErr: true},
+		{name: "relative", from: "last monday", to: "yesterday", want: "2026-03-02..2026-03-04 Digest"},
+		{name: "periods", from: "last month", to: "last month", want: "2026-02-01..2026-02-28 Digest"},
+		{name: "bad date", from: "someday", wantErr: true},
+		{name: "exclusive", week: true, month: true, wantErr: true},
+		{name: "exclusive range", month: true, from: "2026-02-20", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, title, err := digestRange(tt.week, tt.month, tt.from, tt.to, now)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v..%v", from, to)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := from.Format("2006-01-02") + ".." + to.Format("2006-01-02") + " " + title; got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDigestRun_Save(t *testing.T) {
+	setupTestEnv(t)
+	for _, e := range []entry.Entry{
+		{ID: "dgst0001", Content: "- **09:10** kicked off auth", CreatedAt: time.Date(2026, 3, 2, 9, 10, 0, 0, time.Local),
+			Contexts: []entry.ContextRef{{ContextID: "ctx00001", ContextName: "auth"}}},
+		{ID: "dgst0002", Content: "### Blockers\nwaiting on review", CreatedAt: time.Date(2026, 3, 3, 9, 0, 0, 0, time.Local),
+			Templates: []entry.TemplateRef{{TemplateID: "tmpl0001", TemplateName: "standup"}}},
+		{ID: "dgst0003", Content: "outside the week", CreatedAt: time.Date(2026, 3, 9, 9, 0, 0, 0, time.Local)},
+	} {
+		e.UpdatedAt = e.CreatedAt
+		if err := store.Create(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 3, 8, 0, 0, 0, 0, time.Local)
+
+	var buf bytes.Buffer
+	if err := digestRun(&buf, from, to, "Weekly Digest", true); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"2026-03-02 to 2026-03-08: 2 entries on 2 days.",
+		"- **Mon 03-02 09:10** kicked off auth [[dgst0001]]",
+		"### auth",
+		"## Blockers (standup)",
+		"Created entry ",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "dgst0003") {
+		t.Errorf("output includes an entry outside the range:\n%s", out)
+	}
+
+	tmpl, err := store.GetTemplateByName(digest.TemplateName)
+	if err != nil {
+		t.Fatalf("digest template not created: %v", err)
+	}
+	saved, err := store.List(storage.ListOptions{TemplateName: digest.TemplateName})
+	if err != nil || len(saved) != 1 {
+		t.Fatalf("saved digests = %d, %v", len(saved), err)
+	}
+	if saved[0].Templates[0].TemplateID != tmpl.ID || !strings.HasPrefix(saved[0].Content, "# Weekly Digest") {
+		t.Errorf("saved digest = %+v", saved[0])
+	}
+}
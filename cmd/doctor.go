@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the diary for problems",
+	Long: `Check the diary for problems.
+
+Entries record the time zone they were written in and stay on the day they
+were written, wherever you read them. doctor lists the entries that fall on a
+different day in the current time zone (e.g. late-night entries written while
+travelling), and counts entries written before time zones were recorded,
+which follow the current zone.`,
+	Example: `  diaryctl doctor
+  diaryctl doctor --tz America/New_York
+  diaryctl doctor --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return doctorRun(cmd.OutOrStdout())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// dayShift is an entry whose day in the zone it was written in differs from
+// its day in the current zone.
+type dayShift struct {
+	ID       string    `json:"id"`
+	TimeZone string    `json:"timezone"`
+	Day      time.Time `json:"day"`       // day in TimeZone
+	LocalDay time.Time `json:"local_day"` // day in the current zone
+}
+
+// doctorReport is the result of the diary checks.
+type doctorReport struct {
+	TimeZone string     `json:"timezone"` // current zone
+	Shifted  []dayShift `json:"shifted"`
+	Unzoned  int        `json:"unzoned"` // entries without a recorded zone
+}
+
+// doctorRun checks every entry and prints the report.
+func doctorRun(w io.Writer) error {
+	entries, err := store.List(storage.ListOptions{})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	report := doctorReport{TimeZone: day.Zone(), Shifted: []dayShift{}}
+	if report.TimeZone == "" {
+		report.TimeZone = "local"
+	}
+	for i := len(entries) - 1; i >= 0; i-- { // oldest first
+		e := entries[i]
+		if e.TimeZone == "" {
+			report.Unzoned++
+			continue
+		}
+		written, local := day.OfZone(e.CreatedAt, e.TimeZone), day.Of(e.CreatedAt)
+		if !written.Equal(local) {
+			report.Shifted = append(report.Shifted, dayShift{ID: e.ID, TimeZone: e.TimeZone, Day: written, LocalDay: local})
+		}
+	}
+
+	if jsonOutput {
+		return ui.FormatJSON(w, report)
+	}
+	fmt.Fprintf(w, "Time zones (current: %s)\n", report.TimeZone)
+	switch len(report.Shifted) {
+	case 0:
+		fmt.Fprintln(w, "  ✓ every entry is on the same day as in the current zone")
+	case 1:
+		fmt.Fprintln(w, "  ! 1 entry is on a different day than in the current zone:")
+	default:
+		fmt.Fprintf(w, "  ! %d entries are on a different day than in the current zone:\n", len(report.Shifted))
+	}
+	for _, s := range report.Shifted {
+		fmt.Fprintf(w, "    %s  %s (%s), %s here\n", s.ID,
+			s.Day.Format("2006-01-02"), s.TimeZone, s.LocalDay.Format("2006-01-02"))
+	}
+	switch report.Unzoned {
+	case 0:
+	case 1:
+		fmt.Fprintln(w, "  ! 1 entry predates recorded time zones and follows the current zone")
+	default:
+		fmt.Fprintf(w, "  ! %d entries predate recorded time zones and follow the current zone\n", report.Unzoned)
+	}
+	return nil
+}
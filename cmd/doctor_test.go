@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+func TestDoctorRun(t *testing.T) {
+	setupTestEnv(t)
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	for _, e := range []entry.Entry{
+		// 01:00 on Mar 6 in Tokyo, Mar 5 in UTC
+		{ID: "doc00001", Content: "landed in tokyo", CreatedAt: time.Date(2026, 3, 5, 16, 0, 0, 0, time.UTC), TimeZone: "Asia/Tokyo"},
+		// 22:00 on Mar 5 in Los Angeles, Mar 6 in UTC
+		{ID: "doc00002", Content: "late in la", CreatedAt: time.Date(2026, 3, 6, 6, 0, 0, 0, time.UTC), TimeZone: "America/Los_Angeles"},
+		{ID: "doc00003", Content: "lunch in london", CreatedAt: time.Date(2026, 3, 5, 12, 0, 0, 0, time.UTC), TimeZone: "Europe/London"},
+	} {
+		e.UpdatedAt = e.CreatedAt
+		if err := store.Create(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := doctorRun(&buf); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"2 entries are on a different day",
+		"doc00001  2026-03-06 (Asia/Tokyo), 2026-03-05 here",
+		"doc00002  2026-03-05 (America/Los_Angeles), 2026-03-06 here",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "doc00003") || strings.Contains(out, "predate") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	jsonOutput = true
+	buf.Reset()
+	if err := doctorRun(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var report doctorReport
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(report.Shifted) != 2 || report.Shifted[0].ID != "doc00001" || report.Unzoned != 0 {
+		t.Errorf("report = %+v", report)
+	}
+}
@@ -50,6 +50,11 @@ Use --template to append template content to the entry.`,
 					fmt.Fprintln(os.Stderr, "Error:", err)
 					os.Exit(1)
 				}
+				tc, err = tmpl.RenderWith(tc, templateRenderOptions(withDefaults(names, map[string]string{})))
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "Error:", err)
+					os.Exit(1)
+				}
 				editorContent = e.Content + "\n\n" + tc
 				newRefs = refs
 			}
@@ -22,7 +22,7 @@ import (
 //   - --template: Template name to use (renders with --var flags)
 //   - --var: Template variables in KEY=value format (repeatable)
 //   - --attr: Block attributes in key=value format (repeatable)
-//   - --date: Target date for the block (YYYY-MM-DD format, default: today)
+//   - --date: Target date for the block (e.g. 2024-01-15 or yesterday, default: today)
 //
 // Template Behavior:
 //   - If --template is provided, the template is loaded and rendered with vars
@@ -61,13 +61,13 @@ be rendered with the given variables and used as initial content in the editor.`
 			// Parse date (default to today)
 			var targetDate time.Time
 			if dateStr != "" {
-				parsedDate, err := time.Parse("2006-01-02", dateStr)
+				parsedDate, err := dates.Day(dateStr)
 				if err != nil {
-					return fmt.Errorf("invalid date format %q: use YYYY-MM-DD (e.g., 2024-01-15)", dateStr)
+					return fmt.Errorf("invalid date: %w", err)
 				}
 				targetDate = day.NormalizeDate(parsedDate)
 			} else {
-				targetDate = day.NormalizeDate(time.Now())
+				targetDate = day.Today()
 			}
 
 			// Parse variables
@@ -110,8 +110,13 @@ be rendered with the given variables and used as initial content in the editor.`
 					return fmt.Errorf("getting template %q: %w", templateName, err)
 				}
 
+				content, err := template.Resolve(store, templateName)
+				if err != nil {
+					return err
+				}
+
 				// Render template with variables
-				rendered, err := template.Render(tmpl.Content, templateVars)
+				rendered, err := template.Render(content, templateVars)
 				if err != nil {
 					return fmt.Errorf("rendering template: %w", err)
 				}
@@ -172,7 +177,7 @@ be rendered with the given variables and used as initial content in the editor.`
 	cmd.Flags().StringVar(&templateName, "template", "", "template name to use")
 	cmd.Flags().StringArrayVar(&vars, "var", []string{}, "template variables in KEY=value format (repeatable)")
 	cmd.Flags().StringArrayVar(&attrs, "attr", []string{}, "block attributes in key=value format (repeatable)")
-	cmd.Flags().StringVar(&dateStr, "date", "", "date for the block (YYYY-MM-DD, default: today)")
+	cmd.Flags().StringVar(&dateStr, "date", "", "date for the block ("+dateFlagHelp+"; default: today)")
 
 	return cmd
 }
@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/context"
+)
+
+// enrichEntry asks the language model configured under [enrich] for tags,
+// a mood and follow-ups about content, and saves them as pending suggestions
+// for the entry. Does nothing unless enrichment is enabled; failures are
+// reported as warnings and never undo the write.
+func enrichEntry(entryID string, content string) {
+	if appConfig == nil || !appConfig.Enrich.Enabled {
+		return
+	}
+	p, err := enrichSuggestions(entryID, content)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning: enrichment failed:", err)
+		return
+	}
+	if names := p.Names(); len(names) > 0 {
+		fmt.Fprintf(os.Stderr, "Suggested contexts: %s (accept with 'a' in the TUI entry view)\n", strings.Join(names, ", "))
+	}
+}
+
+// dismissPending drops the enrichment suggestions pending for a deleted
+// entry.
+func dismissPending(entryID string) error {
+	return context.DismissPendingSuggestion(appConfig.DataDir, entryID)
+}
+
+// tuiEnricher returns the enrichment hook for the TUI's write paths, or nil
+// when enrichment is disabled. Failures are returned for the TUI to show
+// rather than printed over the screen.
+func tuiEnricher() func(entryID string, content string) error {
+	if appConfig == nil || !appConfig.Enrich.Enabled {
+		return nil
+	}
+	return func(entryID string, content string) error {
+		_, err := enrichSuggestions(entryID, content)
+		return err
+	}
+}
+
+func enrichSuggestions(entryID string, content string) (context.PendingSuggestion, error) {
+	ec := appConfig.Enrich
+	var timeout time.Duration
+	if ec.Timeout != "" {
+		d, err := time.ParseDuration(ec.Timeout)
+		if err != nil {
+			return context.PendingSuggestion{}, fmt.Errorf("invalid enrich.timeout %q: %w", ec.Timeout, err)
+		}
+		timeout = d
+	}
+	e, err := store.Get(entryID)
+	if err != nil {
+		return context.PendingSuggestion{}, err
+	}
+	contexts, err := store.ListContexts()
+	if err != nil {
+		return context.PendingSuggestion{}, err
+	}
+	known := make([]string, len(contexts))
+	for i, c := range contexts {
+		known[i] = c.Name
+	}
+
+	enricher, err := context.NewLLMEnricher(context.LLMOptions{
+		URL:           ec.URL,
+		Model:         ec.Model,
+		APIKey:        ec.APIKey,
+		Timeout:       timeout,
+		KnownContexts: known,
+	})
+	if err != nil {
+		return context.PendingSuggestion{}, err
+	}
+	enriched, err := enricher.Enrich(gocontext.Background(), content)
+	if err != nil {
+		return context.PendingSuggestion{}, err
+	}
+	p, err := context.NewPendingSuggestion(store, e, enriched)
+	if err != nil {
+		return context.PendingSuggestion{}, err
+	}
+	return p, context.SavePendingSuggestion(appConfig.DataDir, p)
+}
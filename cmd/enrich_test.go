@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/daily"
+)
+
+func TestJotEnrichment(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		reply := `{"tags": ["groceries", "errands"], "mood": "calm", "follow_ups": []}`
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": reply}}},
+		})
+	}))
+	defer srv.Close()
+
+	setupTestEnv(t)
+	appConfig.DataDir = t.TempDir()
+	appConfig.Enrich.URL = srv.URL
+
+	// Disabled unless opted in, even with a url configured
+	if err := jotRun(io.Discard, "bought groceries", ""); err != nil {
+		t.Fatalf("jotRun: %v", err)
+	}
+	if requests != 0 {
+		t.Fatalf("expected no enrichment request while disabled, got %d", requests)
+	}
+
+	appConfig.Enrich.Enabled = true
+	if err := jotRun(io.Discard, "ran errands", ""); err != nil {
+		t.Fatalf("jotRun: %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected 1 enrichment request, got %d", requests)
+	}
+	e, _, err := daily.GetOrCreateToday(store, "")
+	if err != nil {
+		t.Fatalf("GetOrCreateToday: %v", err)
+	}
+	p, ok, err := context.PendingFor(appConfig.DataDir, e.ID)
+	if err != nil || !ok {
+		t.Fatalf("expected pending suggestion, got ok=%v err=%v", ok, err)
+	}
+	if !reflect.DeepEqual(p.Tags, []string{"groceries", "errands"}) || p.Mood != "calm" {
+		t.Errorf("unexpected pending suggestion %+v", p)
+	}
+
+	// A failing endpoint only warns
+	srv.Close()
+	if err := jotRun(io.Discard, "one more", ""); err != nil {
+		t.Fatalf("jotRun with unreachable endpoint: %v", err)
+	}
+}
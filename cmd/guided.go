@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tmpl "github.com/chris-regnier/diaryctl/internal/template"
+	"github.com/chris-regnier/diaryctl/internal/ui"
+	"go.yaml.in/yaml/v3"
+	"golang.org/x/term"
+)
+
+// isInteractive reports whether guided capture can prompt on the terminal.
+// Tests replace it to exercise the non-TTY path.
+var isInteractive = func() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// presetAnswers merges answers from a YAML vars file and --var key=value
+// flags. Flags win over the file. A vars file of "-" is read from stdin.
+func presetAnswers(varFlags []string, varsFile string) (map[string]string, error) {
+	answers := map[string]string{}
+	if varsFile != "" {
+		var data []byte
+		var err error
+		if varsFile == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(varsFile)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading vars file: %w", err)
+		}
+		var raw map[string]any
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("parsing vars file: %w", err)
+		}
+		for k, v := range raw {
+			if v == nil {
+				answers[k] = ""
+				continue
+			}
+			answers[k] = strings.TrimSpace(fmt.Sprint(v))
+		}
+	}
+	for _, kv := range varFlags {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid --var %q: expected key=value", kv)
+		}
+		answers[strings.TrimSpace(k)] = v
+	}
+	return answers, nil
+}
+
+// guidedAnswers returns answers for the variables declared by the named
+// templates. Preset answers are used as given; when interactive, the user is
+// prompted for the rest. Defaults are filled in and answers validated.
+func guidedAnswers(names []string, preset map[string]string, interactive bool) (map[string]string, error) {
+	vars, err := tmpl.Variables(store, names)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := preset
+	if interactive {
+		var missing []tmpl.Variable
+		for _, v := range vars {
+			if _, ok := preset[v.Name]; !ok {
+				missing = append(missing, v)
+			}
+		}
+		if len(missing) > 0 {
+			answers, err = ui.RunGuidedForm(strings.Join(names, " + "), missing, preset, ui.ResolveTheme(appConfig.Theme))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return tmpl.ApplyAnswers(vars, answers)
+}
+
+// withDefaults fills in declared defaults for variables missing from answers
+// without validating, for templates rendered outside guided capture.
+func withDefaults(names []string, answers map[string]string) map[string]string {
+	vars, err := tmpl.Variables(store, names)
+	if err != nil {
+		return answers
+	}
+	for _, v := range vars {
+		if _, ok := answers[v.Name]; !ok && v.Default != "" {
+			answers[v.Name] = v.Default
+		}
+	}
+	return answers
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+const guidedStandup = `---
+variables:
+  - name: yesterday
+    required: true
+  - name: blockers
+    default: none
+  - name: energy
+    type: number
+---
+## Yesterday
+{{.yesterday}}
+## Blockers
+{{.blockers}}`
+
+func TestPresetAnswers(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "vars.yaml")
+	if err := os.WriteFile(file, []byte("yesterday: auth work\nenergy: 7\nblockers: review\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		vars    []string
+		file    string
+		want    map[string]string
+		wantErr string
+	}{
+		{"flags", []string{"a=1", "b=x=y"}, "", map[string]string{"a": "1", "b": "x=y"}, ""},
+		{"file", nil, file, map[string]string{"yesterday": "auth work", "energy": "7", "blockers": "review"}, ""},
+		{"flags override file", []string{"blockers="}, file, map[string]string{"yesterday": "auth work", "energy": "7", "blockers": ""}, ""},
+		{"bad flag", []string{"novalue"}, "", nil, `invalid --var "novalue"`},
+		{"missing file", nil, filepath.Join(t.TempDir(), "nope.yaml"), nil, "reading vars file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := presetAnswers(tt.vars, tt.file)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("presetAnswers: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGuidedAnswersNonInteractive(t *testing.T) {
+	setupTestEnv(t)
+	createTestTemplate(t, "standup", guidedStandup)
+
+	got, err := guidedAnswers([]string{"standup"}, map[string]string{"yesterday": "auth work"}, false)
+	if err != nil {
+		t.Fatalf("guidedAnswers: %v", err)
+	}
+	want := map[string]string{"yesterday": "auth work", "blockers": "none", "energy": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	_, err = guidedAnswers([]string{"standup"}, map[string]string{"energy": "high"}, false)
+	if err == nil || !strings.Contains(err.Error(), "yesterday is required") || !strings.Contains(err.Error(), "energy must be a number") {
+		t.Errorf("expected validation errors, got %v", err)
+	}
+}
+
+func TestJotGuided(t *testing.T) {
+	setupTestEnv(t)
+	createTestTemplate(t, "standup", guidedStandup)
+
+	origInteractive := isInteractive
+	isInteractive = func() bool { return false }
+	jotVars = []string{"yesterday=auth work"}
+	t.Cleanup(func() {
+		isInteractive = origInteractive
+		jotVars = nil
+	})
+
+	content, err := jotGuidedContent("standup")
+	if err != nil {
+		t.Fatalf("jotGuidedContent: %v", err)
+	}
+	if content != "## Yesterday\nauth work\n## Blockers\nnone" {
+		t.Errorf("got %q", content)
+	}
+	if err := jotRun(io.Discard, content, ""); err != nil {
+		t.Fatalf("jotRun: %v", err)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	entries, _ := store.List(storage.ListOptions{Date: &today})
+	if len(entries) != 1 || !strings.Contains(entries[0].Content, "auth work") {
+		t.Errorf("expected guided content jotted, got %v", entries)
+	}
+
+	if _, err := jotGuidedContent(""); err == nil {
+		t.Error("expected error without --template")
+	}
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var heatmapYear int
+
+var heatmapCmd = &cobra.Command{
+	Use:   "heatmap",
+	Short: "Show a calendar heatmap of entries",
+	Long: `Show a GitHub-style calendar heatmap of a year: one column per week and one
+row per weekday, shaded by the number of entries that day.
+
+In the TUI, press H for an interactive heatmap.`,
+	Example: `  diaryctl heatmap
+  diaryctl heatmap --year 2025
+  diaryctl heatmap --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		year := heatmapYear
+		if year == 0 {
+			year = time.Now().Year()
+		}
+		return heatmapRun(cmd.OutOrStdout(), year)
+	},
+}
+
+func init() {
+	heatmapCmd.Flags().IntVar(&heatmapYear, "year", 0, "year to show (default: current year)")
+	rootCmd.AddCommand(heatmapCmd)
+}
+
+// heatmapRun prints the heatmap for year, or the entries per day as JSON.
+func heatmapRun(w io.Writer, year int) error {
+	counts, err := ui.HeatmapCounts(store, year)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+	if jsonOutput {
+		return ui.FormatJSON(w, counts)
+	}
+
+	theme := ui.ResolveTheme(appConfig.Theme)
+	// Print on the terminal's own background
+	theme.Background = ""
+	fmt.Fprintln(w, ui.RenderHeatmap(theme, year, counts, nil))
+	return nil
+}
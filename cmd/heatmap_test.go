@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+func TestHeatmapRun(t *testing.T) {
+	setupTestEnv(t)
+	for i, at := range []time.Time{
+		time.Date(2025, 6, 2, 9, 0, 0, 0, time.Local),
+		time.Date(2025, 6, 2, 18, 0, 0, 0, time.Local),
+		time.Date(2024, 6, 2, 9, 0, 0, 0, time.Local),
+	} {
+		e := entry.Entry{ID: "heat000" + string(rune('1'+i)), Content: "note", CreatedAt: at, UpdatedAt: at}
+		if err := store.Create(e); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := heatmapRun(&buf, 2025); err != nil {
+		t.Fatalf("heatmapRun: %v", err)
+	}
+	out := stripANSI(buf.String())
+	if !strings.Contains(out, "Less · ░ ▒ ▓ █ More") || !strings.Contains(out, "2 entries on 1 day in 2025") {
+		t.Errorf("unexpected heatmap:\n%s", out)
+	}
+
+	jsonOutput = true
+	buf.Reset()
+	if err := heatmapRun(&buf, 2025); err != nil {
+		t.Fatalf("heatmapRun json: %v", err)
+	}
+	var counts map[string]int
+	if err := json.Unmarshal(buf.Bytes(), &counts); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(counts) != 1 || counts["2025-06-02"] != 2 {
+		t.Errorf("counts = %v", counts)
+	}
+}
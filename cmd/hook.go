@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/githook"
+	"github.com/chris-regnier/diaryctl/internal/template"
+	"github.com/spf13/cobra"
+)
+
+var hookCheckout bool
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Manage git hooks that auto-jot commits",
+	Long: `Manage git hooks in the current repository that jot to today's entry.
+
+The post-commit hook jots the commit subject with the repository and branch
+attached as contexts. The optional post-checkout hook jots branch switches.
+Existing hooks are preserved and run first.
+
+Commits made during a rebase or cherry-pick are skipped. At most one jot per
+repository is made within hooks.min_interval (default 1m); commits made in
+between are held back and jotted with the next one after it, each under the
+day, time and branch it was made on.`,
+}
+
+var hookInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install diaryctl git hooks in the current repository",
+	Example: `  diaryctl hook install
+  diaryctl hook install --checkout`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hooksDir := currentHooksDir()
+		exe, err := os.Executable()
+		if err != nil {
+			return fmt.Errorf("locating diaryctl executable: %w", err)
+		}
+		hooks := []string{githook.PostCommit}
+		if hookCheckout {
+			hooks = append(hooks, githook.PostCheckout)
+		}
+		for _, name := range hooks {
+			if err := githook.Install(hooksDir, name, exe); err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stdout, "Installed %s hook in %s\n", name, hooksDir)
+		}
+		return nil
+	},
+}
+
+var hookUninstallCmd = &cobra.Command{
+	Use:     "uninstall",
+	Short:   "Remove diaryctl git hooks from the current repository",
+	Example: `  diaryctl hook uninstall`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		hooksDir := currentHooksDir()
+		removed := 0
+		for _, name := range []string{githook.PostCommit, githook.PostCheckout} {
+			ok, err := githook.Uninstall(hooksDir, name)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+			if ok {
+				removed++
+				fmt.Fprintf(os.Stdout, "Removed %s hook from %s\n", name, hooksDir)
+			}
+		}
+		if removed == 0 {
+			fmt.Fprintln(os.Stdout, "No diaryctl hooks installed.")
+		}
+		return nil
+	},
+}
+
+var hookDisableCmd = &cobra.Command{
+	Use:     "disable",
+	Short:   "Stop auto-jotting in the current repository",
+	Long:    "Opt the current repository out of auto-jotting without removing the hooks.\nSets git config diaryctl.autojot=false.",
+	Example: `  diaryctl hook disable`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setHookDisabled(true)
+	},
+}
+
+var hookEnableCmd = &cobra.Command{
+	Use:     "enable",
+	Short:   "Resume auto-jotting in the current repository",
+	Example: `  diaryctl hook enable`,
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return setHookDisabled(false)
+	},
+}
+
+var hookRunCmd = &cobra.Command{
+	Use:      "run <hook> [args...]",
+	Short:    "Run a git hook (invoked by installed hook scripts)",
+	Hidden:   true,
+	Args:     cobra.MinimumNArgs(1),
+	PostRunE: invalidateCachePostRun,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		return hookRun(os.Stdout, args[0], args[1:], dir, time.Now())
+	},
+}
+
+// currentHooksDir returns the hooks directory of the repository in the
+// working directory, exiting if there is none.
+func currentHooksDir() string {
+	dir, err := os.Getwd()
+	if err == nil {
+		var hooksDir string
+		if hooksDir, err = githook.HooksDir(dir); err == nil {
+			return hooksDir
+		}
+	}
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	os.Exit(1)
+	return ""
+}
+
+func setHookDisabled(disabled bool) error {
+	dir, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if githook.Toplevel(dir) == "" {
+		fmt.Fprintf(os.Stderr, "Error: not a git repository: %s\n", dir)
+		os.Exit(1)
+	}
+	if err := githook.SetDisabled(dir, disabled); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+	if disabled {
+		fmt.Fprintln(os.Stdout, "Auto-jotting disabled for this repository.")
+	} else {
+		fmt.Fprintln(os.Stdout, "Auto-jotting enabled for this repository.")
+	}
+	return nil
+}
+
+// hookRun handles a git hook event for the repository containing dir.
+// Events in opted-out repositories or during history rewrites are skipped
+// silently. Events within the rate limit interval are held back and jotted
+// together with the next event after it.
+func hookRun(w io.Writer, name string, args []string, dir string, now time.Time) error {
+	top := githook.Toplevel(dir)
+	if top == "" || githook.Disabled(dir) || githook.Rewriting(dir) {
+		return nil
+	}
+	repo := filepath.Base(top)
+
+	var content, branch string
+	switch name {
+	case githook.PostCommit:
+		c, err := githook.HeadCommit(dir)
+		if err != nil {
+			return err
+		}
+		content = fmt.Sprintf("%s (`%s`)", c.Subject, c.Hash)
+		branch = c.Branch
+	case githook.PostCheckout:
+		// args: previous HEAD, new HEAD, 1 for a branch checkout
+		if len(args) < 3 || args[2] != "1" || args[0] == args[1] {
+			return nil
+		}
+		branch = githook.CurrentBranch(dir)
+		if branch == "" {
+			return nil
+		}
+		content = fmt.Sprintf("Switched to **%s**", branch)
+	default:
+		return fmt.Errorf("unsupported hook %q", name)
+	}
+
+	interval, err := time.ParseDuration(appConfig.Hooks.MinInterval)
+	if err != nil {
+		interval = 0
+	}
+	contexts := activeContextNames()
+	for _, name := range []string{repo, branch} {
+		if name != "" && !slices.Contains(contexts, name) {
+			contexts = append(contexts, name)
+		}
+	}
+	line := githook.Line{Text: content, At: now, Contexts: contexts}
+	_, err = githook.Batch(appConfig.DataDir, top, interval, line, func(lines []githook.Line) (int, error) {
+		return jotHookLines(w, lines)
+	})
+	return err
+}
+
+// jotHookLines jots hook lines, oldest first. Consecutive lines made on the
+// same day under the same contexts share one jot, stamped with the first
+// one's time and written to that day's entry, so held-back lines never move
+// to a later day or pick up a later branch. Returns how many lines were
+// written.
+func jotHookLines(w io.Writer, lines []githook.Line) (int, error) {
+	written := 0
+	for written < len(lines) {
+		first := lines[written]
+		texts := []string{first.Text}
+		for _, l := range lines[written+1:] {
+			if !day.Of(l.At).Equal(day.Of(first.At)) || !slices.Equal(l.Contexts, first.Contexts) {
+				break
+			}
+			texts = append(texts, l.Text)
+		}
+		jotLine, err := jotHookLine(strings.Join(texts, "; "), first)
+		if err != nil {
+			return written, err
+		}
+		fmt.Fprintln(w, jotLine)
+		written += len(texts)
+	}
+	return written, nil
+}
+
+// jotHookLine appends content to the entry for the day of line, creating it
+// with hooks.template or the template rules if needed, and attaches the
+// line's contexts.
+func jotHookLine(content string, line githook.Line) (string, error) {
+	selectTemplate := func(now time.Time) string {
+		if appConfig.Hooks.Template != "" {
+			return appConfig.Hooks.Template
+		}
+		if len(appConfig.Templates.Rules) == 0 {
+			return appConfig.DefaultTemplate
+		}
+		sel, err := template.Select(templateRules(), appConfig.DefaultTemplate, now, line.Contexts)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Warning:", err)
+		}
+		return sel.Template
+	}
+	opts := templateRenderOptions(nil)
+	opts.Now = line.At
+	opts.Yesterday = nil // relative to the line's day
+	updated, jotLine, err := daily.JotAt(store, content, line.At, selectTemplate, opts)
+	if err != nil {
+		return "", err
+	}
+
+	refs, warnings := context.ResolveActiveContexts(nil, line.Contexts, store)
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", w)
+	}
+	for _, ref := range refs {
+		_ = store.AttachContext(updated.ID, ref.ContextID)
+	}
+	return jotLine, nil
+}
+
+func init() {
+	hookInstallCmd.Flags().BoolVar(&hookCheckout, "checkout", false, "also install a post-checkout hook that jots branch switches")
+
+	hookCmd.AddCommand(hookInstallCmd)
+	hookCmd.AddCommand(hookUninstallCmd)
+	hookCmd.AddCommand(hookDisableCmd)
+	hookCmd.AddCommand(hookEnableCmd)
+	hookCmd.AddCommand(hookRunCmd)
+	rootCmd.AddCommand(hookCmd)
+}
@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/githook"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func setupHookRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "feature/auth"},
+		{"-c", "user.name=test", "-c", "user.email=test@test.com", "commit", "--allow-empty", "-m", "Add login form"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	return dir
+}
+
+func TestHookRunPostCommit(t *testing.T) {
+	setupTestEnv(t)
+	appConfig.DataDir = t.TempDir()
+	appConfig.Hooks.MinInterval = "1m"
+	dir := setupHookRepo(t)
+	now := time.Now()
+
+	var buf bytes.Buffer
+	if err := hookRun(&buf, githook.PostCommit, nil, dir, now); err != nil {
+		t.Fatalf("hookRun: %v", err)
+	}
+	if !strings.Contains(buf.String(), "Add login form") {
+		t.Errorf("expected jot line, got %q", buf.String())
+	}
+
+	e, _, err := daily.GetOrCreateToday(store, "")
+	if err != nil {
+		t.Fatalf("GetOrCreateToday: %v", err)
+	}
+	if !strings.Contains(e.Content, "Add login form (`") {
+		t.Errorf("expected commit subject in entry, got:\n%s", e.Content)
+	}
+	got := map[string]bool{}
+	for _, c := range e.Contexts {
+		got[c.ContextName] = true
+	}
+	if !got[filepath.Base(dir)] || !got["feature/auth"] {
+		t.Errorf("expected repo and branch contexts, got %+v", e.Contexts)
+	}
+
+	// A second commit inside the interval is rate limited
+	buf.Reset()
+	if err := hookRun(&buf, githook.PostCommit, nil, dir, now.Add(10*time.Second)); err != nil {
+		t.Fatalf("hookRun: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected rate-limited run to jot nothing, got %q", buf.String())
+	}
+
+	// The held-back commit is jotted with the next one after the interval
+	buf.Reset()
+	if err := hookRun(&buf, githook.PostCommit, nil, dir, now.Add(2*time.Minute)); err != nil {
+		t.Fatalf("hookRun: %v", err)
+	}
+	if strings.Count(buf.String(), "Add login form (`") != 2 {
+		t.Errorf("expected held-back and new commit in one jot, got %q", buf.String())
+	}
+}
+
+func TestHookRunHeldBackAcrossDays(t *testing.T) {
+	setupTestEnv(t)
+	appConfig.DataDir = t.TempDir()
+	appConfig.Hooks.MinInterval = "1m"
+	dir := setupHookRepo(t)
+	now := time.Now()
+	y := now.AddDate(0, 0, -1)
+	evening := time.Date(y.Year(), y.Month(), y.Day(), 18, 0, 0, 0, time.Local)
+
+	var buf bytes.Buffer
+	for _, at := range []time.Time{evening, evening.Add(10 * time.Second)} {
+		if err := hookRun(&buf, githook.PostCommit, nil, dir, at); err != nil {
+			t.Fatalf("hookRun: %v", err)
+		}
+	}
+
+	// The next commit comes the following day on another branch
+	cmd := exec.Command("git", "checkout", "-q", "-b", "main")
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git checkout: %v\n%s", err, out)
+	}
+	if err := hookRun(&buf, githook.PostCommit, nil, dir, now); err != nil {
+		t.Fatalf("hookRun: %v", err)
+	}
+
+	yesterday := day.Of(evening)
+	entries, err := store.List(storage.ListOptions{Date: &yesterday})
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("expected yesterday's entry, got %d (%v)", len(entries), err)
+	}
+	if strings.Count(entries[0].Content, "Add login form (`") != 2 || !strings.Contains(entries[0].Content, "- **18:00** Add login form") {
+		t.Errorf("expected both of yesterday's commits in yesterday's entry at 18:00, got:\n%s", entries[0].Content)
+	}
+	for _, c := range entries[0].Contexts {
+		if c.ContextName == "main" {
+			t.Errorf("yesterday's entry picked up today's branch: %+v", entries[0].Contexts)
+		}
+	}
+
+	today, _, err := daily.GetOrCreateToday(store, "")
+	if err != nil {
+		t.Fatalf("GetOrCreateToday: %v", err)
+	}
+	if strings.Count(today.Content, "Add login form (`") != 1 {
+		t.Errorf("expected only today's commit in today's entry, got:\n%s", today.Content)
+	}
+}
+
+func TestHookRunOptOut(t *testing.T) {
+	setupTestEnv(t)
+	appConfig.DataDir = t.TempDir()
+	dir := setupHookRepo(t)
+	if err := githook.SetDisabled(dir, true); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := hookRun(&buf, githook.PostCommit, nil, dir, time.Now()); err != nil {
+		t.Fatalf("hookRun: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected opted-out repo to jot nothing, got %q", buf.String())
+	}
+}
+
+func TestHookRunPostCheckout(t *testing.T) {
+	setupTestEnv(t)
+	appConfig.DataDir = t.TempDir()
+	dir := setupHookRepo(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"file checkout", []string{"abc", "abc", "0"}, ""},
+		{"same head", []string{"abc", "abc", "1"}, ""},
+		{"branch switch", []string{"abc", "def", "1"}, "Switched to **feature/auth**"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := hookRun(&buf, githook.PostCheckout, tt.args, dir, time.Now()); err != nil {
+				t.Fatalf("hookRun: %v", err)
+			}
+			if tt.want == "" && buf.Len() != 0 {
+				t.Errorf("expected no jot, got %q", buf.String())
+			}
+			if tt.want != "" && !strings.Contains(buf.String(), tt.want) {
+				t.Errorf("expected %q, got %q", tt.want, buf.String())
+			}
+		})
+	}
+}
+
+func TestHookRunOutsideRepo(t *testing.T) {
+	setupTestEnv(t)
+	appConfig.DataDir = t.TempDir()
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	if err := hookRun(&buf, githook.PostCommit, nil, dir, time.Now()); err != nil {
+		t.Fatalf("hookRun: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("expected no jot outside a repo, got %q", buf.String())
+	}
+}
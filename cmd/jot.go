@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -8,11 +9,18 @@ import (
 	"time"
 
 	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/template"
 	"github.com/chris-regnier/diaryctl/internal/ui"
 	"github.com/spf13/cobra"
 )
 
-var jotTemplate string
+var (
+	jotTemplate string
+	jotGuided   bool
+	jotVars     []string
+	jotVarsFile string
+)
 
 var jotCmd = &cobra.Command{
 	Use:   "jot [text...]",
@@ -20,12 +28,33 @@ var jotCmd = &cobra.Command{
 	Long: `Append a timestamped note to today's daily entry.
 
 The note is formatted as a bullet with a timestamp: - **HH:MM** text
-If no entry exists for today, one is created automatically.`,
+If no entry exists for today, one is created automatically.
+
+With --guided, the template given by --template is not used for today's
+entry; instead you are prompted for its variables and the rendered template
+is jotted. Answers can also be supplied with --var or --vars-file.`,
 	Example: `  diaryctl jot "bought groceries"
   diaryctl jot meeting went well
-  echo "note from pipe" | diaryctl jot -`,
+  echo "note from pipe" | diaryctl jot -
+  diaryctl jot --guided --template mood
+  diaryctl jot --guided --template mood --var mood=good --var energy=7`,
 	PostRunE: invalidateCachePostRun,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if jotGuided {
+			if len(args) > 0 {
+				return fmt.Errorf("--guided cannot be used with text")
+			}
+			content, err := jotGuidedContent(jotTemplate)
+			if errors.Is(err, ui.ErrGuidedCancelled) {
+				fmt.Fprintln(os.Stdout, "Cancelled.")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			return jotRun(os.Stdout, content, "")
+		}
+
 		var content string
 
 		switch {
@@ -41,46 +70,38 @@ If no entry exists for today, one is created automatically.`,
 			return fmt.Errorf("jot requires text: diaryctl jot \"some text\"")
 		}
 
-		templateName := jotTemplate
-		if templateName == "" {
-			templateName = appConfig.DefaultTemplate
-		}
-
-		return jotRun(os.Stdout, content, templateName)
+		return jotRun(os.Stdout, content, jotTemplate)
 	},
 }
 
-func jotRun(w io.Writer, content string, templateName string) error {
-	content = strings.TrimSpace(content)
-	if content == "" {
-		return fmt.Errorf("jot: empty content")
+// jotGuidedContent prompts for the variables of the named template and
+// returns the rendered template.
+func jotGuidedContent(templateName string) (string, error) {
+	if templateName == "" {
+		return "", fmt.Errorf("--guided requires --template")
 	}
-
-	e, _, err := daily.GetOrCreateToday(store, templateName)
+	names := template.ParseNames(templateName)
+	preset, err := presetAnswers(jotVars, jotVarsFile)
 	if err != nil {
-		return fmt.Errorf("getting today's entry: %w", err)
+		return "", err
 	}
-
-	timestamp := time.Now().Format("15:04")
-	jotLine := fmt.Sprintf("- **%s** %s", timestamp, content)
-
-	var newContent string
-	if strings.TrimSpace(e.Content) == "" {
-		newContent = jotLine
-	} else {
-		newContent = e.Content + "\n" + jotLine
+	answers, err := guidedAnswers(names, preset, isInteractive())
+	if err != nil {
+		return "", err
 	}
-
-	updated, err := store.Update(e.ID, newContent, nil)
+	content, _, err := template.Compose(store, names)
 	if err != nil {
-		return fmt.Errorf("updating entry: %w", err)
+		return "", err
 	}
+	return template.RenderWith(content, templateRenderOptions(answers))
+}
 
-	// Resolve and attach contexts
-	contextRefs := resolveContexts()
-	for _, ref := range contextRefs {
-		_ = store.AttachContext(e.ID, ref.ContextID)
+func jotRun(w io.Writer, content string, templateName string) error {
+	updated, jotLine, err := appendJot(content, templateName, nil)
+	if err != nil {
+		return err
 	}
+	enrichEntry(updated.ID, content)
 
 	if jsonOutput {
 		return ui.FormatJSON(w, updated)
@@ -90,7 +111,33 @@ func jotRun(w io.Writer, content string, templateName string) error {
 	return nil
 }
 
+// appendJot appends a timestamped note to today's entry and attaches the
+// active contexts plus any extra context names. If today's entry has to be
+// created, templateName is used, or the template chosen by the template rules
+// when it is empty. Returns the updated entry and the jotted line.
+func appendJot(content string, templateName string, extraContexts []string) (entry.Entry, string, error) {
+	selectTemplate := selectDefaultTemplate
+	if templateName != "" {
+		selectTemplate = func(time.Time) string { return templateName }
+	}
+	updated, jotLine, err := daily.Jot(store, content, selectTemplate, templateRenderOptions(nil))
+	if err != nil {
+		return entry.Entry{}, "", err
+	}
+
+	// Resolve and attach contexts
+	contextRefs := resolveContextsWith(extraContexts)
+	for _, ref := range contextRefs {
+		_ = store.AttachContext(updated.ID, ref.ContextID)
+	}
+
+	return updated, jotLine, nil
+}
+
 func init() {
-	jotCmd.Flags().StringVar(&jotTemplate, "template", "", "template to use when creating today's entry")
+	jotCmd.Flags().StringVar(&jotTemplate, "template", "", "template to use when creating today's entry (with --guided: template to fill in)")
+	jotCmd.Flags().BoolVar(&jotGuided, "guided", false, "prompt for the template's variables and jot the result")
+	jotCmd.Flags().StringArrayVar(&jotVars, "var", nil, "template variable as key=value (repeatable)")
+	jotCmd.Flags().StringVar(&jotVarsFile, "vars-file", "", "YAML file of template variables (\"-\" for stdin)")
 	rootCmd.AddCommand(jotCmd)
 }
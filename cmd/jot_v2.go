@@ -16,7 +16,7 @@ import (
 // a new block in the storage backend.
 //
 // Flags:
-//   - --date: Specify the date for the block (YYYY-MM-DD format, default: today)
+//   - --date: Specify the date for the block (e.g. 2024-01-15 or yesterday, default: today)
 //   - --attr: Add attributes to the block (key=value format, repeatable)
 //
 // The command:
@@ -50,13 +50,13 @@ You can optionally specify a date and add attributes to the block.`,
 			// Parse date (default to today)
 			var targetDate time.Time
 			if dateStr != "" {
-				parsedDate, err := time.Parse("2006-01-02", dateStr)
+				parsedDate, err := dates.Day(dateStr)
 				if err != nil {
-					return fmt.Errorf("invalid date format %q: use YYYY-MM-DD (e.g., 2024-01-15)", dateStr)
+					return fmt.Errorf("invalid date: %w", err)
 				}
 				targetDate = day.NormalizeDate(parsedDate)
 			} else {
-				targetDate = day.NormalizeDate(time.Now())
+				targetDate = day.Today()
 			}
 
 			// Parse attributes
@@ -101,7 +101,7 @@ You can optionally specify a date and add attributes to the block.`,
 	}
 
 	// Add flags
-	cmd.Flags().StringVar(&dateStr, "date", "", "date for the block (YYYY-MM-DD, default: today)")
+	cmd.Flags().StringVar(&dateStr, "date", "", "date for the block ("+dateFlagHelp+"; default: today)")
 	cmd.Flags().StringArrayVar(&attrs, "attr", []string{}, "block attributes in key=value format (repeatable)")
 
 	return cmd
@@ -2,11 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/chris-regnier/diaryctl/internal/block"
+	"github.com/chris-regnier/diaryctl/internal/config"
 	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
@@ -244,3 +246,38 @@ func TestJotV2CommandInvalidAttributeFormat(t *testing.T) {
 		t.Errorf("expected error about invalid attribute format, got: %v", err)
 	}
 }
+
+// TestOpenStoreV2 checks that v2 blocks land under DataDir/v2 and that other
+// backends are rejected.
+func TestOpenStoreV2(t *testing.T) {
+	dataDir := t.TempDir()
+	s, err := OpenStoreV2(&config.Config{Storage: "markdown", DataDir: dataDir})
+	if err != nil {
+		t.Fatalf("OpenStoreV2() error = %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	rootCmd := NewRootV2Command(s)
+	rootCmd.SetOut(&bytes.Buffer{})
+	rootCmd.SetArgs([]string{"jot", "shared path"})
+	if err := rootCmd.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	reopened, err := markdown.NewV2(filepath.Join(dataDir, "v2"))
+	if err != nil {
+		t.Fatalf("NewV2() error = %v", err)
+	}
+	t.Cleanup(func() { reopened.Close() })
+	blocks, err := reopened.ListBlocks(day.NormalizeDate(time.Now()))
+	if err != nil {
+		t.Fatalf("ListBlocks() error = %v", err)
+	}
+	if len(blocks) != 1 || blocks[0].Content != "shared path" {
+		t.Errorf("blocks under %s/v2 = %+v, want the jotted block", dataDir, blocks)
+	}
+
+	if _, err := OpenStoreV2(&config.Config{Storage: "sqlite", DataDir: dataDir}); err == nil {
+		t.Error("expected an error for sqlite storage")
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/chris-regnier/diaryctl/internal/ui"
@@ -24,18 +23,23 @@ var listCmd = &cobra.Command{
 	Long:  "List diary entries with preview, sorted by date (newest first).",
 	Example: `  diaryctl list
   diaryctl list --date 2026-01-31
+  diaryctl list --date "last week"
   diaryctl list --template daily
   diaryctl list --json`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		opts := storage.ListOptions{}
 
 		if dateFilter != "" {
-			t, err := time.ParseInLocation("2006-01-02", dateFilter, time.Local)
+			r, err := dates.Range(dateFilter)
 			if err != nil {
-				fmt.Fprintln(os.Stderr, "Error: invalid date format (use YYYY-MM-DD):", dateFilter)
+				fmt.Fprintln(os.Stderr, "Error: invalid --date:", err)
 				os.Exit(1)
 			}
-			opts.Date = &t
+			if r.SingleDay() {
+				opts.Date = &r.Start
+			} else {
+				opts.StartDate, opts.EndDate = &r.Start, &r.End
+			}
 		}
 
 		if listTemplateFilter != "" {
@@ -73,7 +77,7 @@ var listCmd = &cobra.Command{
 }
 
 func init() {
-	listCmd.Flags().StringVar(&dateFilter, "date", "", "filter by date (YYYY-MM-DD)")
+	listCmd.Flags().StringVar(&dateFilter, "date", "", "filter by date or period ("+dateFlagHelp+", last week)")
 	listCmd.Flags().StringVar(&listTemplateFilter, "template", "", "filter by template name")
 	listCmd.Flags().StringVar(&listContextFilter, "context", "", "filter by context name")
 	listCmd.Flags().BoolVar(&listIDOnly, "id-only", false, "print just entry IDs, one per line")
@@ -2,8 +2,15 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/chris-regnier/diaryctl/internal/mcptools"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -12,16 +19,50 @@ import (
 
 var mcpServeCmd = &cobra.Command{
 	Use:   "mcp-serve",
-	Short: "Run MCP server on stdio",
-	Long: `Starts a Model Context Protocol (MCP) server that exposes diary tools
-over stdio transport. This allows MCP clients like Claude Desktop to interact
-with your diary.
+	Short: "Run MCP server on stdio or HTTP",
+	Long: `Starts a Model Context Protocol (MCP) server that exposes diary tools,
+resources and prompts over stdio transport. This allows MCP clients like
+Claude Desktop to interact with your diary.
 
-Available tools:
+With --http the server instead listens on the given address using the
+streamable HTTP transport, so several clients can share one diary. Clients
+must send "Authorization: Bearer <token>" with the token set in the config:
+
+  [mcp]
+  token = "..."
+  read_only = false
+
+--read-only (or read_only in the config) registers only the read tools.
+
+Available tools (the last six are left out in read-only mode):
   - search_entries: Fuzzy text search over diary content
-  - filter_entries: Filter entries by date range and template
-  - create_entry: Create entries with optional template composition
+  - filter_entries: Filter entries by date range, templates and contexts (paged)
+  - get_entry: Read an entry's full content
+  - list_days: List days with entries
+  - list_contexts: List contexts
   - list_templates: Discover available templates
+  - create_entry: Create entries with optional template composition
+  - update_entry: Replace or append to an entry's content
+  - jot: Append a timestamped note to today's entry
+  - delete_entry: Delete an entry (two-step, with a confirmation token)
+  - attach_context: Tag an entry with a context
+
+With model = "v2" in the config, the block-based tool set is served instead:
+  - get_day: Read a day's blocks and their attributes
+  - search_blocks: Search blocks by content, date range and attributes
+  - add_block: Add a block with attributes (not in read-only mode)
+  - update_block_attributes: Set or remove block attributes (not in read-only mode)
+Resources and prompts are only available with the entry model.
+
+Available resources (attachable by clients; list changes are notified after writes):
+  - diary://today, diary://day/{date}, diary://entry/{id}
+  - diary://template/{name}, diary://context/{name}
+
+Available prompts:
+  - weekly_review: Review a week of entries
+  - standup_from_yesterday: Draft a standup from the previous day's entries
+  - summarize_context: Summarize the entries tagged with a context
+  - mood_check: Reflect on mood across recent entries
 
 Example usage in Claude Desktop config:
   {
@@ -35,26 +76,114 @@ Example usage in Claude Desktop config:
 	RunE: runMCPServe,
 }
 
+var (
+	mcpServeHTTP     string
+	mcpServeReadOnly bool
+)
+
+// mcpShutdownTimeout bounds how long open HTTP sessions are given to finish.
+const mcpShutdownTimeout = 5 * time.Second
+
 func init() {
+	mcpServeCmd.Flags().StringVar(&mcpServeHTTP, "http", "", "serve over streamable HTTP on this address (e.g. :8765) instead of stdio")
+	mcpServeCmd.Flags().BoolVar(&mcpServeReadOnly, "read-only", false, "register only tools that don't modify the diary")
 	rootCmd.AddCommand(mcpServeCmd)
 }
 
-func runMCPServe(cmd *cobra.Command, args []string) error {
+func runMCPServe(cmd *cobra.Command, args []string) (err error) {
 	// Storage is already initialized in PersistentPreRunE
 	if store == nil {
 		return cmd.Help()
 	}
+	closers := []io.Closer{store}
+	defer func() {
+		for _, c := range closers {
+			if cerr := c.Close(); err == nil && cerr != nil {
+				err = fmt.Errorf("closing storage: %w", cerr)
+			}
+		}
+	}()
+	if mcpServeHTTP != "" && appConfig.MCP.Token == "" {
+		return fmt.Errorf("--http requires a bearer token: set token under [mcp] in the config")
+	}
+
+	// Create MCP server with the tool set for the configured data model
+	readOnly := mcpServeReadOnly || appConfig.MCP.ReadOnly
+	opts := mcptools.Options{
+		DataDir:         appConfig.DataDir,
+		ReadOnly:        readOnly,
+		StrictTemplates: appConfig.Templates.Strict,
+		SelectTemplate:  selectDefaultTemplate,
+		AfterDelete:     dismissPending,
+	}
+	var server *mcp.Server
+	switch appConfig.Model {
+	case "", "v1":
+		server = mcptools.CreateMCPServerWithOptions(store, opts)
+	case "v2":
+		storeV2, err := OpenStoreV2(appConfig)
+		if err != nil {
+			return err
+		}
+		closers = append(closers, storeV2)
+		server = mcptools.CreateMCPServerV2(storeV2, opts)
+	default:
+		return fmt.Errorf("unknown model %q: expected v1 or v2", appConfig.Model)
+	}
 
-	// Create MCP server with registered tools
-	server := mcptools.CreateMCPServer(store, appConfig.DataDir)
+	if mcpServeHTTP != "" {
+		return serveMCPHTTP(server, mcpServeHTTP, readOnly)
+	}
 
 	// Log to stderr (stdout is reserved for MCP protocol)
 	log.SetOutput(os.Stderr)
 	log.Printf("Starting diaryctl MCP server (stdio transport)")
 	log.Printf("Storage backend: %s", appConfig.Storage)
 	log.Printf("Data directory: %s", appConfig.DataDir)
+	log.Printf("Data model: %s", appConfig.Model)
+	if readOnly {
+		log.Printf("Read-only mode: write tools are disabled")
+	}
 
 	// Run server with stdio transport
 	// This blocks until the transport is closed
 	return server.Run(context.Background(), &mcp.StdioTransport{})
 }
+
+// serveMCPHTTP serves server on addr until interrupted, then shuts down.
+func serveMCPHTTP(server *mcp.Server, addr string, readOnly bool) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: mcptools.NewHTTPHandler(server, appConfig.MCP.Token),
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.ListenAndServe() }()
+
+	log.Printf("Starting diaryctl MCP server (HTTP transport on %s)", addr)
+	log.Printf("Storage backend: %s", appConfig.Storage)
+	log.Printf("Data directory: %s", appConfig.DataDir)
+	log.Printf("Data model: %s", appConfig.Model)
+	if readOnly {
+		log.Printf("Read-only mode: write tools are disabled")
+	}
+
+	var err error
+	select {
+	case err = <-errc:
+	case <-ctx.Done():
+		log.Printf("Shutting down")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), mcpShutdownTimeout)
+		defer cancel()
+		if err = srv.Shutdown(shutdownCtx); err != nil {
+			// Streaming sessions don't go idle on their own
+			err = srv.Close()
+		}
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
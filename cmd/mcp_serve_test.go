@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/chris-regnier/diaryctl/internal/config"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// closeCountingStore records how often Close is called.
+type closeCountingStore struct {
+	storage.Storage
+	closed int
+}
+
+func (s *closeCountingStore) Close() error {
+	s.closed++
+	return nil
+}
+
+func TestMCPServeHTTPWithoutTokenClosesStore(t *testing.T) {
+	s := &closeCountingStore{Storage: setupTestStore(t)}
+	store = s
+	appConfig = &config.Config{Model: "v2", Storage: "markdown", DataDir: t.TempDir()}
+	mcpServeHTTP = ":0"
+	t.Cleanup(func() { mcpServeHTTP = "" })
+
+	err := runMCPServe(mcpServeCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "bearer token") {
+		t.Fatalf("expected a missing token error, got %v", err)
+	}
+	if s.closed != 1 {
+		t.Errorf("store closed %d times, want 1", s.closed)
+	}
+}
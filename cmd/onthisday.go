@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var onThisDayDate string
+
+var onThisDayCmd = &cobra.Command{
+	Use:   "onthisday",
+	Short: "Show entries from this day in past years",
+	Long: `Show entries written on the same calendar date in previous years, plus one
+month and one week ago, most recent first.
+
+The TUI today screen shows the same memories in an "On this day" panel.`,
+	Example: `  diaryctl onthisday
+  diaryctl onthisday --date 2026-03-05
+  diaryctl onthisday --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		date := day.Today()
+		if onThisDayDate != "" {
+			t, err := dates.Day(onThisDayDate)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error: invalid --date:", err)
+				os.Exit(1)
+			}
+			date = t
+		}
+		return onThisDayRun(cmd.OutOrStdout(), date)
+	},
+}
+
+func init() {
+	onThisDayCmd.Flags().StringVar(&onThisDayDate, "date", "", "day to look back from ("+dateFlagHelp+"; default today)")
+	rootCmd.AddCommand(onThisDayCmd)
+}
+
+// onThisDayRun prints the memories for day.
+func onThisDayRun(w io.Writer, day time.Time) error {
+	memories, err := daily.OnThisDay(store, day)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+	if jsonOutput {
+		if memories == nil {
+			memories = []daily.Memory{}
+		}
+		return ui.FormatJSON(w, memories)
+	}
+	ui.FormatMemories(w, memories)
+	return nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+func TestOnThisDayRun(t *testing.T) {
+	setupTestEnv(t)
+	for _, e := range []entry.Entry{
+		{ID: "otd00001", Content: "first day at the new job", CreatedAt: time.Date(2024, 3, 5, 9, 0, 0, 0, time.Local)},
+		{ID: "otd00002", Content: "unrelated", CreatedAt: time.Date(2025, 3, 6, 9, 0, 0, 0, time.Local)},
+	} {
+		e.UpdatedAt = e.CreatedAt
+		if err := store.Create(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	day := time.Date(2026, 3, 5, 0, 0, 0, 0, time.Local)
+
+	var buf bytes.Buffer
+	if err := onThisDayRun(&buf, day); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "── 2 years ago · Tue 2024-03-05 (1 entry)") || !strings.Contains(out, "otd00001") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	if strings.Contains(out, "otd00002") {
+		t.Errorf("output includes an entry from another day:\n%s", out)
+	}
+
+	jsonOutput = true
+	buf.Reset()
+	if err := onThisDayRun(&buf, day.AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+	var memories []daily.Memory
+	if err := json.Unmarshal(buf.Bytes(), &memories); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(memories) != 1 || memories[0].Label != "1 year ago" || memories[0].Entries[0].ID != "otd00002" {
+		t.Errorf("memories = %+v", memories)
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	gocontext "context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recallFrom     string
+	recallTo       string
+	recallTemplate string
+	recallContext  string
+	recallLimit    int
+	recallEnrich   bool
+)
+
+var recallCmd = &cobra.Command{
+	Use:   "recall [query...]",
+	Short: "Recall past entries through the configured provider",
+	Long: `Search past entries for text, or filter them by date, template and context,
+through the recall provider set under [recall] in the config:
+
+  direct    read the local store (default)
+  mcp       go through the in-process MCP server's tools
+  external  call an external MCP server (url and token, or command)
+
+With --enrich, each result is followed by suggested tags, mood and follow-up
+prompts from providers that support enrichment.`,
+	Example: `  diaryctl recall "database migration"
+  diaryctl recall --from 2026-01-01 --to 2026-01-31 --context feature/auth
+  diaryctl recall standup --enrich --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		query := strings.Join(args, " ")
+		opts := storage.ListOptions{TemplateName: recallTemplate, ContextName: recallContext, Limit: recallLimit}
+		var err error
+		opts.StartDate, opts.EndDate, err = parseDateBounds(recallFrom, recallTo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		if query != "" && (opts.StartDate != nil || opts.EndDate != nil || opts.TemplateName != "" || opts.ContextName != "") {
+			fmt.Fprintln(os.Stderr, "Error: --from, --to, --template and --context filter entries and cannot be combined with a search query")
+			os.Exit(1)
+		}
+
+		provider, err := newRecallProvider()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		defer closeRecallProvider(provider)
+
+		if err := recallRun(cmd.OutOrStdout(), provider, query, opts, recallEnrich, jsonOutput); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(2)
+		}
+		return nil
+	},
+}
+
+func init() {
+	recallCmd.Flags().StringVar(&recallFrom, "from", "", "only entries on or after this date ("+dateFlagHelp+")")
+	recallCmd.Flags().StringVar(&recallTo, "to", "", "only entries on or before this date ("+dateFlagHelp+")")
+	recallCmd.Flags().StringVar(&recallTemplate, "template", "", "only entries using this template")
+	recallCmd.Flags().StringVar(&recallContext, "context", "", "only entries tagged with this context")
+	recallCmd.Flags().IntVar(&recallLimit, "limit", 10, "maximum number of results")
+	recallCmd.Flags().BoolVar(&recallEnrich, "enrich", false, "add suggested tags, mood and follow-ups to each result")
+	rootCmd.AddCommand(recallCmd)
+}
+
+// newRecallProvider creates the provider configured under [recall].
+func newRecallProvider() (context.Provider, error) {
+	rc := appConfig.Recall
+	return context.NewProvider(store, context.RecallConfig{
+		Provider: rc.Provider,
+		URL:      rc.URL,
+		Token:    rc.Token,
+		Command:  rc.Command,
+	})
+}
+
+// closeRecallProvider releases a provider's connection, if it holds one.
+func closeRecallProvider(p context.Provider) {
+	if c, ok := p.(io.Closer); ok {
+		_ = c.Close()
+	}
+}
+
+// recallResult is a recalled entry with its optional enrichment.
+type recallResult struct {
+	context.EntryResult
+	Enrichment *context.EnrichedContent `json:"enrichment,omitempty"`
+}
+
+// recallRun searches for query, or filters by opts when query is empty, and
+// prints the results.
+func recallRun(w io.Writer, p context.Provider, query string, opts storage.ListOptions, enrich, asJSON bool) error {
+	ctx := gocontext.Background()
+	var found []context.EntryResult
+	var err error
+	if query != "" {
+		found, err = p.Search(ctx, query, opts.Limit)
+	} else {
+		found, err = p.Filter(ctx, opts)
+	}
+	if err != nil {
+		return err
+	}
+
+	results := make([]recallResult, len(found))
+	for i, r := range found {
+		results[i] = recallResult{EntryResult: r}
+		if !enrich {
+			continue
+		}
+		content := r.Preview
+		if e, err := store.Get(r.ID); err == nil {
+			content = e.Content
+		}
+		enriched, err := context.Enrich(ctx, p, content)
+		if err != nil {
+			return fmt.Errorf("enriching %s: %w", r.ID, err)
+		}
+		enriched.Content = ""
+		results[i].Enrichment = &enriched
+	}
+
+	if asJSON {
+		return ui.FormatJSON(w, results)
+	}
+	if len(results) == 0 {
+		fmt.Fprintln(w, "No matching entries.")
+		return nil
+	}
+	for _, r := range results {
+		fmt.Fprintf(w, "%s  %s  %s\n", r.Date, r.ID, r.Preview)
+		if r.Enrichment == nil {
+			continue
+		}
+		var meta []string
+		if len(r.Enrichment.SuggestedTags) > 0 {
+			meta = append(meta, "tags: "+strings.Join(r.Enrichment.SuggestedTags, ", "))
+		}
+		if r.Enrichment.Mood != "" {
+			meta = append(meta, "mood: "+r.Enrichment.Mood)
+		}
+		if len(meta) > 0 {
+			fmt.Fprintf(w, "    %s\n", strings.Join(meta, " · "))
+		}
+		for _, f := range r.Enrichment.FollowUpPrompts {
+			fmt.Fprintf(w, "    → %s\n", f)
+		}
+	}
+	return nil
+}
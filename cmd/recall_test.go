@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func TestRecallRun(t *testing.T) {
+	setupTestEnv(t)
+	day := time.Date(2026, 2, 3, 9, 0, 0, 0, time.Local)
+	for i, e := range []entry.Entry{
+		{ID: "rcl00001", Content: "Planned the database migration\n- [ ] write rollback"},
+		{ID: "rcl00002", Content: "Lunch with the team"},
+	} {
+		e.CreatedAt = day.Add(time.Duration(i) * time.Hour)
+		e.UpdatedAt = e.CreatedAt
+		if err := store.Create(e); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	appConfig.Recall.Provider = "direct"
+	provider, err := newRecallProvider()
+	if err != nil {
+		t.Fatalf("newRecallProvider: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		query  string
+		opts   storage.ListOptions
+		enrich bool
+		want   []string
+		absent []string
+	}{
+		{"search", "migration", storage.ListOptions{Limit: 10}, false, []string{"2026-02-03  rcl00001  Planned the database migration"}, []string{"rcl00002", "Follow up"}},
+		{"filter", "", storage.ListOptions{Limit: 1}, false, []string{"rcl00002"}, []string{"rcl00001"}},
+		{"enrich", "migration", storage.ListOptions{Limit: 10}, true, []string{"    → Follow up on: write rollback"}, nil},
+		{"no results", "zebra", storage.ListOptions{Limit: 10}, false, []string{"No matching entries."}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := recallRun(&buf, provider, tt.query, tt.opts, tt.enrich, false); err != nil {
+				t.Fatalf("recallRun: %v", err)
+			}
+			out := buf.String()
+			for _, s := range tt.want {
+				if !strings.Contains(out, s) {
+					t.Errorf("output missing %q:\n%s", s, out)
+				}
+			}
+			for _, s := range tt.absent {
+				if strings.Contains(out, s) {
+					t.Errorf("output should not contain %q:\n%s", s, out)
+				}
+			}
+		})
+	}
+
+	var buf bytes.Buffer
+	if err := recallRun(&buf, provider, "migration", storage.ListOptions{Limit: 10}, true, true); err != nil {
+		t.Fatalf("recallRun json: %v", err)
+	}
+	var results []recallResult
+	if err := json.Unmarshal(buf.Bytes(), &results); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(results) != 1 || results[0].Enrichment == nil || len(results[0].Enrichment.FollowUpPrompts) != 1 {
+		t.Errorf("unexpected JSON results %+v", results)
+	}
+}
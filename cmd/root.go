@@ -3,8 +3,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/chris-regnier/diaryctl/internal/config"
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/editor"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
@@ -18,6 +20,7 @@ var (
 	cfgFile        string
 	jsonOutput     bool
 	storageBackend string
+	tzOverride     string
 	appConfig      *config.Config
 	store          storage.Storage
 )
@@ -27,6 +30,14 @@ var rootCmd = &cobra.Command{
 	Short: "A diary management CLI tool",
 	Long:  "diaryctl is a command-line tool for managing personal diary entries with pluggable storage backends.",
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Act as if the machine were in --tz, e.g. to keep writing in the
+		// home zone while travelling
+		if tzOverride != "" {
+			if err := day.SetZone(tzOverride); err != nil {
+				return fmt.Errorf("invalid --tz: %w", err)
+			}
+		}
+
 		// Load config
 		cfg, err := config.Load(cfgFile)
 		if err != nil {
@@ -34,6 +45,13 @@ var rootCmd = &cobra.Command{
 		}
 		appConfig = cfg
 
+		// Days start at day_starts_at rather than midnight
+		startsAt, err := day.ParseStartsAt(appConfig.DayStartsAt)
+		if err != nil {
+			return fmt.Errorf("invalid day_starts_at: %w", err)
+		}
+		day.SetStartsAt(startsAt)
+
 		// Override storage backend from flag
 		if storageBackend != "" {
 			appConfig.Storage = storageBackend
@@ -62,36 +80,69 @@ var rootCmd = &cobra.Command{
 			// Non-TTY: fall back to today's entry
 			return todayRun(os.Stdout, false, false)
 		}
+		recall, err := newRecallProvider()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Warning: recall disabled:", err)
+		} else {
+			defer closeRecallProvider(recall)
+		}
 		return ui.RunTUI(store, ui.TUIConfig{
 			Editor:           editor.ResolveEditor(appConfig.Editor),
 			DefaultTemplate:  appConfig.DefaultTemplate,
+			TemplateRules:    templateRules(),
 			MaxWidth:         appConfig.MaxWidth,
 			Theme:            ui.ResolveTheme(appConfig.Theme),
 			ContextProviders: appConfig.ContextProviders,
 			ContextResolvers: appConfig.ContextResolvers,
 			DataDir:          appConfig.DataDir,
+			ProviderConfig:   providerConfig(),
+			RenderOptions:    templateRenderOptions,
+			Recall:           recall,
+			Enrich:           tuiEnricher(),
 		})
 	},
 }
 
-// Execute runs the root command.
+// Execute runs the root command. Cobra's own error printing is silenced, so
+// errors returned by commands are reported here.
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+	}
+	return err
 }
 
 func init() {
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file path")
 	rootCmd.PersistentFlags().BoolVar(&jsonOutput, "json", false, "output in JSON format")
 	rootCmd.PersistentFlags().StringVar(&storageBackend, "storage", "", "storage backend (markdown|sqlite)")
+	rootCmd.PersistentFlags().StringVar(&tzOverride, "tz", "", "time zone to use instead of the system one (e.g. Europe/Paris)")
 
 	// Silence Cobra's built-in error and usage printing so we control stderr output
 	rootCmd.SilenceErrors = true
 	rootCmd.SilenceUsage = true
 }
 
+// OpenStoreV2 opens the block store for the v2 data model. Blocks live in
+// DataDir/v2, next to the v1 entries; every v2 entry point (mcp-serve and
+// callers of NewRootV2Command) opens the store here so they share one path.
+// Only the markdown backend implements the v2 model.
+func OpenStoreV2(cfg *config.Config) (storage.StorageV2, error) {
+	if cfg.Storage != "markdown" {
+		return nil, fmt.Errorf("model v2 is only supported with markdown storage")
+	}
+	s, err := markdown.NewV2(filepath.Join(cfg.DataDir, "v2"))
+	if err != nil {
+		return nil, fmt.Errorf("initializing v2 storage: %w", err)
+	}
+	return s, nil
+}
+
 // NewRootV2Command creates a root command for the v2 data model.
 // This is a helper function for testing and programmatic usage of the CLI
-// with the StorageV2 interface.
+// with the StorageV2 interface. Open the store with OpenStoreV2 so blocks
+// land in the same place mcp-serve reads them from.
 //
 // Parameters:
 //   - store: A StorageV2 implementation to use for all operations
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRootInvalidTZ(t *testing.T) {
+	tzOverride = "Nowhere/Bogus"
+	t.Cleanup(func() { tzOverride = "" })
+
+	err := rootCmd.PersistentPreRunE(rootCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid --tz") {
+		t.Fatalf("expected an invalid --tz error, got %v", err)
+	}
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/stats"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	statsFrom    string
+	statsTo      string
+	statsContext string
+	statsBy      string
+	statsTop     int
+)
+
+// statsSparkWidth is the number of most recent periods drawn as a sparkline.
+const statsSparkWidth = 60
+
+// statsBarWidth is the width of the longest bar in bar charts.
+const statsBarWidth = 30
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show writing statistics",
+	Long: `Show writing statistics over a date range or context: entries and words per
+day, week or month, streaks, the busiest weekday and hour, top contexts and
+templates, and when notes are jotted.
+
+The SQLite backend computes the figures with SQL aggregates; the markdown
+backend reads the matching entries.`,
+	Example: `  diaryctl stats
+  diaryctl stats --by month --from 2026-01-01
+  diaryctl stats --by day --from "last month" --to "last month"
+  diaryctl stats --context feature/auth --by day
+  diaryctl stats --json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts := storage.StatsOptions{ContextName: statsContext, Top: statsTop}
+		var err error
+		opts.StartDate, opts.EndDate, err = parseDateBounds(statsFrom, statsTo)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		return statsRun(cmd.OutOrStdout(), opts, statsBy, day.Today())
+	},
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsFrom, "from", "", "only entries on or after this date ("+dateFlagHelp+")")
+	statsCmd.Flags().StringVar(&statsTo, "to", "", "only entries on or before this date ("+dateFlagHelp+")")
+	statsCmd.Flags().StringVar(&statsContext, "context", "", "only entries tagged with this context")
+	statsCmd.Flags().StringVar(&statsBy, "by", "week", "period to group entries and words by (day, week, month)")
+	statsCmd.Flags().IntVar(&statsTop, "top", 5, "number of contexts and templates to list")
+	rootCmd.AddCommand(statsCmd)
+}
+
+// statsRun computes statistics for the entries matching opts and prints them,
+// measuring streaks up to now.
+func statsRun(w io.Writer, opts storage.StatsOptions, by string, now time.Time) error {
+	agg, err := storage.Aggregate(store, opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+	report, err := stats.Build(agg, by, now)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		return ui.FormatJSON(w, report)
+	}
+	if report.Entries == 0 {
+		fmt.Fprintln(w, "No entries.")
+		return nil
+	}
+
+	days := "days"
+	if report.ActiveDays == 1 {
+		days = "day"
+	}
+	fmt.Fprintf(w, "Entries   %d on %d %s, %s to %s\n", report.Entries, report.ActiveDays, days, report.FirstDay, report.LastDay)
+	fmt.Fprintf(w, "Words     %d (%.0f per entry)\n", report.Words, report.WordsPerEntry)
+	fmt.Fprintf(w, "Streaks   current %d · longest %d · average %.1f days\n", report.Streaks.Current, report.Streaks.Longest, report.Streaks.Average)
+
+	periods := report.Periods
+	if len(periods) > statsSparkWidth {
+		periods = periods[len(periods)-statsSparkWidth:]
+	}
+	entries := make([]int, len(periods))
+	words := make([]int, len(periods))
+	for i, p := range periods {
+		entries[i] = p.Entries
+		words[i] = p.Words
+	}
+	fmt.Fprintf(w, "\nPer %s, %s to %s\n", by, periods[0].Period, periods[len(periods)-1].Period)
+	fmt.Fprintf(w, "  entries  %s\n", ui.Sparkline(entries))
+	fmt.Fprintf(w, "  words    %s\n", ui.Sparkline(words))
+
+	fmt.Fprintf(w, "\nBusiest weekday: %s\n", report.BusiestWeekday)
+	peak := report.Weekdays[0]
+	for _, n := range report.Weekdays {
+		peak = max(peak, n)
+	}
+	for i := range 7 {
+		wd := time.Weekday((i + 1) % 7) // Monday first
+		n := report.Weekdays[wd]
+		fmt.Fprintf(w, "  %s  %-*s %d\n", wd.String()[:3], statsBarWidth, ui.Bar(n, peak, statsBarWidth), n)
+	}
+
+	fmt.Fprintf(w, "\nBusiest hour: %02d:00\n", report.BusiestHour)
+	fmt.Fprintf(w, "  entries  %s\n", ui.Sparkline(report.Hours[:]))
+	fmt.Fprintf(w, "  jots     %s\n", ui.Sparkline(report.JotHours[:]))
+	fmt.Fprintln(w, "           0     6     12    18  23")
+
+	for _, section := range []struct {
+		title  string
+		counts []storage.NameCount
+	}{{"Top contexts", report.Contexts}, {"Top templates", report.Templates}} {
+		if len(section.counts) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s\n", section.title)
+		width := 0
+		for _, c := range section.counts {
+			width = max(width, len(c.Name))
+		}
+		for _, c := range section.counts {
+			bar := ui.Bar(c.Count, section.counts[0].Count, statsBarWidth)
+			fmt.Fprintf(w, "  %-*s  %s %d\n", width, c.Name, bar, c.Count)
+		}
+	}
+	return nil
+}
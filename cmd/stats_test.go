@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/stats"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func TestStatsRun(t *testing.T) {
+	setupTestEnv(t)
+	for i, e := range []entry.Entry{
+		{ID: "stat0001", Content: "- **09:10** standup notes", CreatedAt: time.Date(2026, 3, 2, 9, 10, 0, 0, time.Local)},
+		{ID: "stat0002", Content: "deep work on auth", CreatedAt: time.Date(2026, 3, 3, 9, 30, 0, 0, time.Local),
+			Templates: []entry.TemplateRef{{TemplateID: "tmpl0001", TemplateName: "daily"}}},
+		{ID: "stat0003", Content: "retro", CreatedAt: time.Date(2026, 3, 3, 16, 0, 0, 0, time.Local)},
+	} {
+		e.UpdatedAt = e.CreatedAt
+		if err := store.Create(e); err != nil {
+			t.Fatalf("create %d: %v", i, err)
+		}
+	}
+	now := time.Date(2026, 3, 3, 18, 0, 0, 0, time.Local)
+
+	var buf bytes.Buffer
+	if err := statsRun(&buf, storage.StatsOptions{Top: 5}, "day", now); err != nil {
+		t.Fatalf("statsRun: %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"Entries   3 on 2 days, 2026-03-02 to 2026-03-03",
+		"Words     9 (3 per entry)",
+		"Streaks   current 2 · longest 2 · average 2.0 days",
+		"Per day, 2026-03-02 to 2026-03-03",
+		"  entries  ▅█",
+		"Busiest weekday: Tuesday",
+		"Busiest hour: 09:00",
+		"Top templates\n  daily  " + strings.Repeat("█", statsBarWidth) + " 1",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Top contexts") {
+		t.Errorf("expected no contexts section:\n%s", out)
+	}
+
+	jsonOutput = true
+	buf.Reset()
+	start := time.Date(2026, 3, 3, 0, 0, 0, 0, time.Local)
+	if err := statsRun(&buf, storage.StatsOptions{StartDate: &start}, "week", now); err != nil {
+		t.Fatalf("statsRun json: %v", err)
+	}
+	var report stats.Report
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	if report.Entries != 2 || report.JotHours[9] != 0 || len(report.Periods) != 1 || report.Periods[0].Period != "2026-W10" {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
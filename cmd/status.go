@@ -7,6 +7,7 @@ import (
 	"text/template"
 	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/shell"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +20,10 @@ type statusData struct {
 	Template   string
 	Backend    string
 	HasToday   bool
+	// Memories is the number of "on this day" entries (0 unless
+	// shell.show_memories is set).
+	Memories     int
+	MemoriesIcon string
 }
 
 var statusCmd = &cobra.Command{
@@ -56,12 +61,22 @@ Use --format with a Go template for custom output.`,
 				os.Exit(2)
 			}
 
+			var memories int
+			if appConfig.Shell.ShowMemories {
+				memories, err = shell.CountMemories(store, day.Today())
+				if err != nil {
+					fmt.Fprintln(os.Stderr, "Error computing status:", err)
+					os.Exit(2)
+				}
+			}
+
 			cache = &shell.PromptCache{
 				Today:           todayExists,
 				Streak:          streak,
-				TodayDate:       time.Now().Format("2006-01-02"),
+				TodayDate:       day.Today().Format("2006-01-02"),
 				DefaultTemplate: appConfig.DefaultTemplate,
 				StorageBackend:  appConfig.Storage,
+				Memories:        memories,
 				UpdatedAt:       time.Now(),
 			}
 
@@ -92,7 +107,7 @@ func buildStatusData(cache *shell.PromptCache) statusData {
 		icon = appConfig.Shell.TodayIcon
 	}
 
-	return statusData{
+	data := statusData{
 		TodayIcon:  icon,
 		Streak:     cache.Streak,
 		StreakIcon: appConfig.Shell.StreakIcon,
@@ -100,6 +115,11 @@ func buildStatusData(cache *shell.PromptCache) statusData {
 		Backend:    cache.StorageBackend,
 		HasToday:   cache.Today,
 	}
+	if appConfig.Shell.ShowMemories {
+		data.Memories = cache.Memories
+		data.MemoriesIcon = appConfig.Shell.MemoriesIcon
+	}
+	return data
 }
 
 func outputEnv(data statusData) error {
@@ -112,6 +132,11 @@ func outputEnv(data statusData) error {
 	if data.Backend != "" {
 		fmt.Printf("export DIARYCTL_BACKEND=%q\n", data.Backend)
 	}
+	if data.Memories > 0 {
+		fmt.Printf("export DIARYCTL_MEMORIES=%q\n", fmt.Sprintf("%d", data.Memories))
+	} else if appConfig.Shell.ShowMemories {
+		fmt.Println("unset DIARYCTL_MEMORIES")
+	}
 	return nil
 }
 
@@ -143,6 +168,11 @@ func outputDefault(data statusData) error {
 		parts = append(parts, data.Backend)
 	}
 
+	// Optional: "on this day" memories
+	if data.Memories > 0 {
+		parts = append(parts, fmt.Sprintf("%s%d", data.MemoriesIcon, data.Memories))
+	}
+
 	fmt.Println(strings.Join(parts, " "))
 	return nil
 }
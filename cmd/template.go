@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/dateparse"
 	"github.com/chris-regnier/diaryctl/internal/editor"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/template"
 	"github.com/chris-regnier/diaryctl/internal/ui"
 	"github.com/spf13/cobra"
 )
@@ -41,11 +44,18 @@ var templateListCmd = &cobra.Command{
 	},
 }
 
+var (
+	templateShowResolved bool
+	templateShowVersion  int
+)
+
 var templateShowCmd = &cobra.Command{
-	Use:     "show <name>",
-	Short:   "Show a template",
-	Example: `  diaryctl template show daily`,
-	Args:    cobra.ExactArgs(1),
+	Use:   "show <name>",
+	Short: "Show a template",
+	Example: `  diaryctl template show daily
+  diaryctl template show standup --resolved
+  diaryctl template show standup --version 2`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
@@ -59,6 +69,28 @@ var templateShowCmd = &cobra.Command{
 			os.Exit(2)
 		}
 
+		if templateShowVersion > 0 && templateShowVersion != tmpl.Version {
+			if templateShowResolved {
+				fmt.Fprintln(os.Stderr, "Error: --resolved cannot be combined with --version")
+				os.Exit(1)
+			}
+			v, err := findTemplateVersion(tmpl, templateShowVersion)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+			tmpl.Content, tmpl.Version, tmpl.UpdatedAt = v.Content, v.Version, v.CreatedAt
+		}
+
+		if templateShowResolved {
+			content, err := template.Resolve(store, name)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+			tmpl.Content = content
+		}
+
 		if jsonOutput {
 			ui.FormatJSON(os.Stdout, tmpl)
 		} else {
@@ -246,7 +278,373 @@ var templateDeleteCmd = &cobra.Command{
 	},
 }
 
+var templateExportCmd = &cobra.Command{
+	Use:   "export <dir> [name...]",
+	Short: "Export templates to markdown files",
+	Long: `Export templates to <dir>, one <name>.md file per template.
+
+Each file has front matter with the template name and attributes, followed by
+the template content. Export all templates, or only the ones named.`,
+	Example: `  diaryctl template export ~/team-templates
+  diaryctl template export ./shared standup retro`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir, names := args[0], args[1:]
+
+		var templates []storage.Template
+		if len(names) == 0 {
+			var err error
+			templates, err = store.ListTemplates()
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(2)
+			}
+		}
+		for _, name := range names {
+			tmpl, err := store.GetTemplateByName(name)
+			if err != nil {
+				if errors.Is(err, storage.ErrNotFound) {
+					fmt.Fprintf(os.Stderr, "Error: template %q not found\n", name)
+					os.Exit(1)
+				}
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(2)
+			}
+			templates = append(templates, tmpl)
+		}
+
+		if err := template.Export(dir, templates); err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(2)
+		}
+
+		if jsonOutput {
+			exported := make([]string, len(templates))
+			for i, t := range templates {
+				exported[i] = t.Name
+			}
+			ui.FormatJSON(os.Stdout, map[string]interface{}{"dir": dir, "exported": exported})
+		} else {
+			fmt.Fprintf(os.Stdout, "Exported %d template(s) to %s\n", len(templates), dir)
+		}
+		return nil
+	},
+}
+
+var templateImportCmd = &cobra.Command{
+	Use:   "import <dir|file>...",
+	Short: "Import templates from markdown files",
+	Long: `Import templates from files written by "template export", or from a
+directory of them. New templates are created; existing templates with the same
+name are updated only if their content or attributes changed.`,
+	Example: `  diaryctl template import ~/team-templates
+  diaryctl template import ./standup.md`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var templates []storage.Template
+		for _, path := range args {
+			t, err := template.ReadFiles(path)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+			templates = append(templates, t...)
+		}
+		return templateImportRun(os.Stdout, templates)
+	},
+}
+
+var templateInstallCmd = &cobra.Command{
+	Use:   "install [pack]",
+	Short: "Install a built-in template pack",
+	Long: `Install one of the template packs shipped with diaryctl. Without an
+argument, list the available packs. Installing again updates templates that
+have changed in the pack.`,
+	Example: `  diaryctl template install
+  diaryctl template install standup-pack`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			packs := template.Packs()
+			if jsonOutput {
+				ui.FormatJSON(os.Stdout, packs)
+				return nil
+			}
+			for _, name := range packs {
+				templates, _ := template.Pack(name)
+				names := make([]string, len(templates))
+				for i, t := range templates {
+					names[i] = t.Name
+				}
+				fmt.Fprintf(os.Stdout, "%s  %s\n", name, strings.Join(names, ", "))
+			}
+			return nil
+		}
+
+		templates, err := template.Pack(args[0])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(1)
+		}
+		return templateImportRun(os.Stdout, templates)
+	},
+}
+
+// templateImportRun imports templates and reports what happened to each.
+func templateImportRun(w io.Writer, templates []storage.Template) error {
+	results, err := template.Import(store, templates)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+
+	if jsonOutput {
+		return ui.FormatJSON(w, results)
+	}
+	for _, r := range results {
+		fmt.Fprintf(w, "%-9s %s\n", r.Action, r.Name)
+	}
+	return nil
+}
+
+// findTemplateVersion returns the snapshot of tmpl at version.
+func findTemplateVersion(tmpl storage.Template, version int) (storage.TemplateVersion, error) {
+	versions, err := store.ListTemplateVersions(tmpl.ID)
+	if err != nil {
+		return storage.TemplateVersion{}, err
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return storage.TemplateVersion{}, fmt.Errorf("template %q has no version %d (latest is %d)", tmpl.Name, version, tmpl.Version)
+}
+
+var templateHistoryCmd = &cobra.Command{
+	Use:     "history <name>",
+	Short:   "List a template's versions",
+	Example: `  diaryctl template history standup`,
+	Args:    cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := args[0]
+		tmpl, err := store.GetTemplateByName(name)
+		if err != nil {
+			if errors.Is(err, storage.ErrNotFound) {
+				fmt.Fprintf(os.Stderr, "Error: template %q not found\n", name)
+				os.Exit(1)
+			}
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(2)
+		}
+		versions, err := store.ListTemplateVersions(tmpl.ID)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			os.Exit(2)
+		}
+
+		if jsonOutput {
+			return ui.FormatJSON(os.Stdout, versions)
+		}
+		for _, v := range versions {
+			current := ""
+			if v.Version == tmpl.Version {
+				current = "  (current)"
+			}
+			fmt.Fprintf(os.Stdout, "v%-3d %s  %d lines%s\n", v.Version, v.CreatedAt.Local().Format("2006-01-02 15:04"), strings.Count(v.Content, "\n")+1, current)
+		}
+		return nil
+	},
+}
+
+var (
+	templateStatsBy    string
+	templateStatsSince string
+)
+
+var templateStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how often each template is used",
+	Long: `Count the entries created from each template, broken down by period and
+template version, and list templates that have never been used.`,
+	Example: `  diaryctl template stats
+  diaryctl template stats --by week --since 2026-01-01
+  diaryctl template stats --json`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var since *time.Time
+		if templateStatsSince != "" {
+			r, err := dates.Range(templateStatsSince)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error: invalid --since:", err)
+				os.Exit(1)
+			}
+			since = &r.Start
+		}
+		return templateStatsRun(os.Stdout, templateStatsBy, since)
+	},
+}
+
+// templateStatsRun reports template usage over entries created since since
+// (all entries when nil), bucketed by period.
+func templateStatsRun(w io.Writer, by string, since *time.Time) error {
+	templates, err := store.ListTemplates()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+	entries, err := store.List(storage.ListOptions{StartDate: since})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(2)
+	}
+	report, err := template.UsageStats(templates, entries, by)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+
+	if jsonOutput {
+		return ui.FormatJSON(w, report)
+	}
+
+	if len(report.Templates) == 0 {
+		fmt.Fprintln(w, "No entries use templates.")
+	}
+	for _, u := range report.Templates {
+		label := u.Name
+		if u.Deleted {
+			label += " (deleted)"
+		}
+		uses := "uses"
+		if u.Total == 1 {
+			uses = "use"
+		}
+		fmt.Fprintf(w, "%s  %d %s, last used %s\n", label, u.Total, uses, u.LastUsed.Local().Format("2006-01-02"))
+		if versions := formatVersionCounts(u.Versions); versions != "" {
+			fmt.Fprintf(w, "  versions: %s\n", versions)
+		}
+		for _, p := range u.Periods {
+			fmt.Fprintf(w, "  %-10s %d\n", p.Period, p.Count)
+		}
+	}
+	if len(report.NeverUsed) > 0 {
+		fmt.Fprintf(w, "\nNever used: %s\n", strings.Join(report.NeverUsed, ", "))
+	}
+	return nil
+}
+
+// formatVersionCounts renders per-version counts as "v1: 3, v2: 5", or ""
+// when no entry recorded a version.
+func formatVersionCounts(counts map[int]int) string {
+	versions := make([]int, 0, len(counts))
+	for v := range counts {
+		versions = append(versions, v)
+	}
+	if len(versions) == 1 && versions[0] == 0 {
+		return ""
+	}
+	sort.Ints(versions)
+	parts := make([]string, 0, len(versions))
+	for _, v := range versions {
+		label := fmt.Sprintf("v%d", v)
+		if v == 0 {
+			label = "unversioned"
+		}
+		parts = append(parts, fmt.Sprintf("%s: %d", label, counts[v]))
+	}
+	return strings.Join(parts, ", ")
+}
+
+var (
+	templateWhichAt       string
+	templateWhichContexts []string
+)
+
+var templateWhichCmd = &cobra.Command{
+	Use:   "which",
+	Short: "Show which default template applies and why",
+	Long: `Evaluate the template rules in the config against the current time and
+active contexts, and show the default template that new entries would use and
+the rule that chose it.
+
+Use --at and --context to check other times and contexts.`,
+	Example: `  diaryctl template which
+  diaryctl template which --at "2026-10-16 09:00"
+  diaryctl template which --context incident/db-outage`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		now := time.Now()
+		if templateWhichAt != "" {
+			var err error
+			now, err = parseWhichTime(templateWhichAt, now)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "Error:", err)
+				os.Exit(1)
+			}
+		}
+		contexts := templateWhichContexts
+		if !cmd.Flags().Changed("context") {
+			contexts = activeContextNames()
+		}
+		return templateWhichRun(os.Stdout, now, contexts)
+	},
+}
+
+// parseWhichTime parses "YYYY-MM-DD HH:MM", "HH:MM" (today) or a day such as
+// "2026-10-16" or "yesterday" (at midnight) in local time.
+func parseWhichTime(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02 15:04", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse("15:04", s); err == nil {
+		return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, time.Local), nil
+	}
+	p := dateparse.Parser{Now: func() time.Time { return now }}
+	if t, err := p.Day(s); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --at %q: expected YYYY-MM-DD HH:MM, HH:MM or a day (%s)", s, dateFlagHelp)
+}
+
+// templateWhichRun reports the default template selected at now for contexts.
+func templateWhichRun(w io.Writer, now time.Time, contexts []string) error {
+	sel, err := template.Select(templateRules(), appConfig.DefaultTemplate, now, contexts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Warning:", err)
+	}
+
+	if jsonOutput {
+		return ui.FormatJSON(w, struct {
+			template.Selection
+			At       time.Time `json:"at"`
+			Contexts []string  `json:"contexts"`
+		}{sel, now, contexts})
+	}
+
+	tmplName := sel.Template
+	if tmplName == "" {
+		tmplName = "(none)"
+	}
+	fmt.Fprintf(w, "Template: %s\n", tmplName)
+	if sel.Rule != "" {
+		fmt.Fprintf(w, "Rule:     %s\n", sel.Rule)
+	}
+	fmt.Fprintf(w, "Reason:   %s\n", sel.Reason)
+	fmt.Fprintf(w, "At:       %s\n", now.Format("Mon 2006-01-02 15:04"))
+	if len(contexts) > 0 {
+		fmt.Fprintf(w, "Contexts: %s\n", strings.Join(contexts, ", "))
+	}
+	return nil
+}
+
 func init() {
+	templateWhichCmd.Flags().StringVar(&templateWhichAt, "at", "", "time to evaluate (YYYY-MM-DD HH:MM, HH:MM or a day, "+dateFlagHelp+")")
+	templateWhichCmd.Flags().StringArrayVar(&templateWhichContexts, "context", nil, "active context to assume instead of the resolved ones (repeatable)")
+	templateShowCmd.Flags().BoolVar(&templateShowResolved, "resolved", false, "expand includes and inheritance")
+	templateShowCmd.Flags().IntVar(&templateShowVersion, "version", 0, "show an earlier version of the template")
+	templateStatsCmd.Flags().StringVar(&templateStatsBy, "by", "month", "period to group usage by (day, week, month, year)")
+	templateStatsCmd.Flags().StringVar(&templateStatsSince, "since", "", "only count entries created on or after this date ("+dateFlagHelp+")")
 	templateDeleteCmd.Flags().BoolVar(&forceDeleteTemplate, "force", false, "skip confirmation prompt")
 
 	templateCmd.AddCommand(templateListCmd)
@@ -254,6 +652,12 @@ func init() {
 	templateCmd.AddCommand(templateCreateCmd)
 	templateCmd.AddCommand(templateEditCmd)
 	templateCmd.AddCommand(templateDeleteCmd)
+	templateCmd.AddCommand(templateExportCmd)
+	templateCmd.AddCommand(templateImportCmd)
+	templateCmd.AddCommand(templateInstallCmd)
+	templateCmd.AddCommand(templateWhichCmd)
+	templateCmd.AddCommand(templateHistoryCmd)
+	templateCmd.AddCommand(templateStatsCmd)
 
 	rootCmd.AddCommand(templateCmd)
 }
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/config"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/template"
 )
 
 func createTestTemplate(t *testing.T, name, content string) storage.Template {
@@ -111,3 +116,156 @@ func TestTemplateDelete(t *testing.T) {
 		t.Errorf("expected ErrNotFound after delete, got %v", err)
 	}
 }
+
+func TestTemplateInstallPack(t *testing.T) {
+	setupTestEnv(t)
+
+	templates, err := template.Pack("standup-pack")
+	if err != nil {
+		t.Fatalf("Pack: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := templateImportRun(&buf, templates); err != nil {
+		t.Fatalf("templateImportRun: %v", err)
+	}
+	if got := buf.String(); got != "created   standup\ncreated   standup-async\n" {
+		t.Errorf("first install output = %q", got)
+	}
+
+	tmpl, err := store.GetTemplateByName("standup")
+	if err != nil {
+		t.Fatalf("GetTemplateByName: %v", err)
+	}
+	if tmpl.Attributes["type"] != "standup" {
+		t.Errorf("expected pack attributes, got %v", tmpl.Attributes)
+	}
+
+	// Reinstalling leaves unchanged templates alone and restores edited ones
+	if _, err := store.UpdateTemplate(tmpl.ID, "standup", "# mine", tmpl.Attributes); err != nil {
+		t.Fatalf("UpdateTemplate: %v", err)
+	}
+	buf.Reset()
+	if err := templateImportRun(&buf, templates); err != nil {
+		t.Fatalf("templateImportRun: %v", err)
+	}
+	if got := buf.String(); got != "updated   standup\nunchanged standup-async\n" {
+		t.Errorf("second install output = %q", got)
+	}
+}
+
+func TestTemplateWhich(t *testing.T) {
+	setupTestEnv(t)
+	appConfig.DefaultTemplate = "daily"
+	appConfig.Templates.Rules = []config.TemplateRuleConfig{
+		{Name: "incidents", Template: "incident", Contexts: []string{"incident/*"}},
+		{Name: "mornings", Template: "standup", Days: []string{"weekday"}, Before: "12:00"},
+	}
+	monday := time.Date(2026, 10, 12, 9, 30, 0, 0, time.Local)
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		contexts []string
+		want     string
+	}{
+		{"morning rule", monday, nil, "Template: standup\nRule:     mornings\nReason:   day is Monday, before 12:00\nAt:       Mon 2026-10-12 09:30\n"},
+		{"context rule", monday, []string{"incident/db"}, "Template: incident\nRule:     incidents\nReason:   context incident/db matches incident/*\nAt:       Mon 2026-10-12 09:30\nContexts: incident/db\n"},
+		{"fallback", monday.Add(5 * time.Hour), nil, "Template: daily\nReason:   no rule matched; using default_template\nAt:       Mon 2026-10-12 14:30\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := templateWhichRun(&buf, tt.now, tt.contexts); err != nil {
+				t.Fatalf("templateWhichRun: %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWhichTime(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 30, 0, 0, time.Local)
+	tests := map[string]time.Time{
+		"2026-10-16 08:15": time.Date(2026, 10, 16, 8, 15, 0, 0, time.Local),
+		"2026-10-16":       time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local),
+		"17:45":            time.Date(2026, 10, 12, 17, 45, 0, 0, time.Local),
+		"yesterday":        time.Date(2026, 10, 11, 0, 0, 0, 0, time.Local),
+		"friday":           time.Date(2026, 10, 9, 0, 0, 0, 0, time.Local),
+	}
+	for in, want := range tests {
+		got, err := parseWhichTime(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("parseWhichTime(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := parseWhichTime("someday", now); err == nil {
+		t.Error("expected error for unsupported format")
+	}
+}
+
+func TestJotUsesTemplateRules(t *testing.T) {
+	setupTestEnv(t)
+	createTestTemplate(t, "daily", "# Daily")
+	createTestTemplate(t, "weekly", "# Weekly review")
+	appConfig.DefaultTemplate = "daily"
+	appConfig.Templates.Rules = []config.TemplateRuleConfig{{Template: "weekly"}}
+
+	if err := jotRun(io.Discard, "note", ""); err != nil {
+		t.Fatalf("jotRun: %v", err)
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	entries, _ := store.List(storage.ListOptions{Date: &today})
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Content, "# Weekly review") {
+		t.Errorf("expected rule template to be used, got %v", entries)
+	}
+}
+
+func TestTemplateStats(t *testing.T) {
+	setupTestEnv(t)
+	standup := createTestTemplate(t, "standup", "## Yesterday")
+	createTestTemplate(t, "retro", "## Retro")
+
+	create := func(at time.Time) {
+		t.Helper()
+		_, refs, err := template.Compose(store, []string{"standup"})
+		if err != nil {
+			t.Fatalf("Compose: %v", err)
+		}
+		id, _ := entry.NewID()
+		if err := store.Create(entry.Entry{ID: id, Content: "note", CreatedAt: at, UpdatedAt: at, Templates: refs}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	create(time.Date(2026, 9, 30, 9, 0, 0, 0, time.Local))
+	if _, err := store.UpdateTemplate(standup.ID, "standup", "## Yesterday\n## Today", nil); err != nil {
+		t.Fatalf("UpdateTemplate: %v", err)
+	}
+	create(time.Date(2026, 10, 1, 9, 0, 0, 0, time.Local))
+	create(time.Date(2026, 10, 2, 9, 0, 0, 0, time.Local))
+
+	var buf bytes.Buffer
+	if err := templateStatsRun(&buf, "month", nil); err != nil {
+		t.Fatalf("templateStatsRun: %v", err)
+	}
+	want := "standup  3 uses, last used 2026-10-02\n" +
+		"  versions: v1: 1, v2: 2\n" +
+		"  2026-09    1\n" +
+		"  2026-10    2\n" +
+		"\nNever used: retro\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.Local)
+	if err := templateStatsRun(&buf, "month", &since); err != nil {
+		t.Fatalf("templateStatsRun: %v", err)
+	}
+	if !strings.HasPrefix(buf.String(), "standup  2 uses") {
+		t.Errorf("expected --since to limit counted entries, got:\n%s", buf.String())
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"os"
 
+	"github.com/chris-regnier/diaryctl/internal/context"
 	"github.com/chris-regnier/diaryctl/internal/daily"
 	"github.com/chris-regnier/diaryctl/internal/editor"
 	"github.com/chris-regnier/diaryctl/internal/ui"
@@ -38,7 +39,7 @@ If no entry exists for today, one is created automatically.`,
 }
 
 func todayRun(w io.Writer, idOnly bool, contentOnly bool) error {
-	e, _, err := daily.GetOrCreateToday(store, appConfig.DefaultTemplate)
+	e, _, err := daily.GetOrCreateTodayFunc(store, selectDefaultTemplate, templateRenderOptions(nil))
 	if err != nil {
 		return fmt.Errorf("getting today's entry: %w", err)
 	}
@@ -70,7 +71,11 @@ func todayRun(w io.Writer, idOnly bool, contentOnly bool) error {
 }
 
 func todayEditRun() error {
-	e, _, err := daily.GetOrCreateToday(store, appConfig.DefaultTemplate)
+	// Build providers before creating today's entry so git activity is
+	// collected since the previous entry rather than the new one.
+	providers := buildContentProviders(appConfig.ContextProviders)
+
+	e, created, err := daily.GetOrCreateTodayFunc(store, selectDefaultTemplate, templateRenderOptions(nil))
 	if err != nil {
 		return fmt.Errorf("getting today's entry: %w", err)
 	}
@@ -81,8 +86,14 @@ func todayEditRun() error {
 		_ = store.AttachContext(e.ID, ref.ContextID)
 	}
 
+	// Prefill a freshly created entry with provider content
+	initial := e.Content
+	if created {
+		initial = context.ComposeContent(providers, e.Content)
+	}
+
 	editorCmd := editor.ResolveEditor(appConfig.Editor)
-	content, changed, err := editor.Edit(editorCmd, e.Content)
+	content, changed, err := editor.Edit(editorCmd, initial)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Editor error:", err)
 		os.Exit(3)
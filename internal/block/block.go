@@ -27,6 +27,10 @@ type Block struct {
 	// UpdatedAt tracks when the block was last modified
 	UpdatedAt time.Time
 
+	// TimeZone is the IANA name of the time zone the block was written in
+	// (empty when unknown)
+	TimeZone string `json:",omitempty"`
+
 	// Attributes is a flat map of key-value pairs for metadata
 	// Examples: type=note, tags=work,personal, mood=happy
 	Attributes map[string]string
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/spf13/viper"
 )
@@ -15,6 +16,9 @@ type ShellConfig struct {
 	StreakIcon  string `mapstructure:"streak_icon"`
 	ShowContext bool   `mapstructure:"show_context"`
 	ShowBackend bool   `mapstructure:"show_backend"`
+	// ShowMemories adds an indicator when "on this day" entries exist.
+	ShowMemories bool   `mapstructure:"show_memories"`
+	MemoriesIcon string `mapstructure:"memories_icon"`
 }
 
 // ThemeConfig holds TUI and markdown theme configuration.
@@ -29,17 +33,84 @@ type ThemeConfig struct {
 	MarkdownStyle string `mapstructure:"markdown_style"`
 }
 
+// GitActivityConfig holds settings for the git-activity content provider.
+type GitActivityConfig struct {
+	Author    string `mapstructure:"author"`    // git log --author pattern; empty = repo user.email
+	Workspace string `mapstructure:"workspace"` // root scanned for repos; empty = current repo
+	Lookback  string `mapstructure:"lookback"`  // window used when there is no previous entry
+}
+
+// CalendarConfig holds settings for the calendar provider and resolver.
+type CalendarConfig struct {
+	Paths []string `mapstructure:"paths"` // .ics files or directories of .ics files
+}
+
+// TemplatesConfig holds template rendering and selection settings.
+type TemplatesConfig struct {
+	Strict bool                 `mapstructure:"strict"` // error on missing variables instead of "<no value>"
+	Rules  []TemplateRuleConfig `mapstructure:"rules"`  // default template rules, first match wins
+}
+
+// TemplateRuleConfig selects a default template when all its conditions hold.
+type TemplateRuleConfig struct {
+	Name     string   `mapstructure:"name"`
+	Template string   `mapstructure:"template"`
+	Days     []string `mapstructure:"days"`     // "mon", "fri", "weekday", "weekend"
+	After    string   `mapstructure:"after"`    // HH:MM
+	Before   string   `mapstructure:"before"`   // HH:MM
+	Contexts []string `mapstructure:"contexts"` // glob patterns, e.g. "incident/*"
+}
+
+// HooksConfig holds settings for git hook auto-jotting.
+type HooksConfig struct {
+	MinInterval string `mapstructure:"min_interval"` // minimum time between jots per repo
+	Template    string `mapstructure:"template"`     // template for today's entry if the hook creates it
+}
+
+// MCPConfig holds settings for the MCP server.
+type MCPConfig struct {
+	Token    string `mapstructure:"token"`     // bearer token required by the HTTP transport
+	ReadOnly bool   `mapstructure:"read_only"` // register only tools that don't modify the diary
+}
+
+// RecallConfig selects the provider used by recall and the TUI.
+type RecallConfig struct {
+	Provider string   `mapstructure:"provider"` // "direct", "mcp" or "external"
+	URL      string   `mapstructure:"url"`      // external: streamable HTTP endpoint
+	Token    string   `mapstructure:"token"`    // external: bearer token
+	Command  []string `mapstructure:"command"`  // external: stdio server command, used when url is empty
+}
+
+// EnrichConfig holds settings for suggesting tags after create and jot with
+// a language model behind an OpenAI-compatible API. Disabled by default.
+type EnrichConfig struct {
+	Enabled bool   `mapstructure:"enabled"`
+	URL     string `mapstructure:"url"`     // API base URL, e.g. http://localhost:8080/v1
+	Model   string `mapstructure:"model"`   // model name sent with each request
+	APIKey  string `mapstructure:"api_key"` // bearer token (empty = none)
+	Timeout string `mapstructure:"timeout"` // per-request timeout, e.g. "30s"
+}
+
 // Config holds the application configuration.
 type Config struct {
-	Storage          string      `mapstructure:"storage"`
-	DataDir          string      `mapstructure:"data_dir"`
-	Editor           string      `mapstructure:"editor"`
-	DefaultTemplate  string      `mapstructure:"default_template"`
-	MaxWidth         int         `mapstructure:"max_width"`
-	ContextProviders []string    `mapstructure:"context_providers"`
-	ContextResolvers []string    `mapstructure:"context_resolvers"`
-	Shell            ShellConfig `mapstructure:"shell"`
-	Theme            ThemeConfig `mapstructure:"theme"`
+	Storage          string            `mapstructure:"storage"`
+	Model            string            `mapstructure:"model"` // data model: "v1" (entries) or "v2" (days and blocks)
+	DataDir          string            `mapstructure:"data_dir"`
+	Editor           string            `mapstructure:"editor"`
+	DefaultTemplate  string            `mapstructure:"default_template"`
+	MaxWidth         int               `mapstructure:"max_width"`
+	DayStartsAt      string            `mapstructure:"day_starts_at"` // HH:MM; earlier times count as the previous day
+	ContextProviders []string          `mapstructure:"context_providers"`
+	ContextResolvers []string          `mapstructure:"context_resolvers"`
+	Shell            ShellConfig       `mapstructure:"shell"`
+	Theme            ThemeConfig       `mapstructure:"theme"`
+	GitActivity      GitActivityConfig `mapstructure:"git_activity"`
+	Hooks            HooksConfig       `mapstructure:"hooks"`
+	Calendar         CalendarConfig    `mapstructure:"calendar"`
+	Templates        TemplatesConfig   `mapstructure:"templates"`
+	MCP              MCPConfig         `mapstructure:"mcp"`
+	Recall           RecallConfig      `mapstructure:"recall"`
+	Enrich           EnrichConfig      `mapstructure:"enrich"`
 }
 
 // DefaultDataDir returns the default data directory (~/.diaryctl/).
@@ -51,16 +122,30 @@ func DefaultDataDir() string {
 	return filepath.Join(home, ".diaryctl")
 }
 
+// ExpandHome replaces a leading "~/" in path with the user's home directory.
+func ExpandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
+
 // Load reads configuration from file, environment variables, and defaults.
 func Load(configPath string) (*Config, error) {
 	v := viper.New()
 
 	// Defaults
 	v.SetDefault("storage", "markdown")
+	v.SetDefault("model", "v1")
 	v.SetDefault("data_dir", DefaultDataDir())
 	v.SetDefault("editor", "")
 	v.SetDefault("default_template", "")
 	v.SetDefault("max_width", 100)
+	v.SetDefault("day_starts_at", "00:00")
 	v.SetDefault("context_providers", []string{})
 	v.SetDefault("context_resolvers", []string{})
 	v.SetDefault("shell.cache_ttl", "5m")
@@ -69,7 +154,14 @@ func Load(configPath string) (*Config, error) {
 	v.SetDefault("shell.streak_icon", "🔥")
 	v.SetDefault("shell.show_context", true)
 	v.SetDefault("shell.show_backend", false)
+	v.SetDefault("shell.show_memories", false)
+	v.SetDefault("shell.memories_icon", "✦")
 	v.SetDefault("theme.preset", "default-dark")
+	v.SetDefault("git_activity.lookback", "24h")
+	v.SetDefault("hooks.min_interval", "1m")
+	v.SetDefault("recall.provider", "direct")
+	v.SetDefault("enrich.enabled", false)
+	v.SetDefault("enrich.timeout", "30s")
 
 	// Config file
 	if configPath != "" {
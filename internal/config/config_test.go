@@ -49,3 +49,93 @@ markdown_style = "light"
 		t.Errorf("expected markdown_style 'light', got %q", cfg.Theme.MarkdownStyle)
 	}
 }
+
+func TestLoadTemplateRules(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+
+	content := `
+default_template = "daily"
+
+[[templates.rules]]
+name = "incidents"
+template = "incident"
+contexts = ["incident/*"]
+
+[[templates.rules]]
+template = "standup"
+days = ["weekday"]
+before = "12:00"
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rules := cfg.Templates.Rules
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if rules[0].Name != "incidents" || rules[0].Contexts[0] != "incident/*" {
+		t.Errorf("unexpected first rule: %+v", rules[0])
+	}
+	if rules[1].Template != "standup" || rules[1].Days[0] != "weekday" || rules[1].Before != "12:00" {
+		t.Errorf("unexpected second rule: %+v", rules[1])
+	}
+}
+
+func TestLoadEnrichDisabledByDefault(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Enrich.Enabled {
+		t.Error("enrichment must be opt-in")
+	}
+	if cfg.Enrich.Timeout != "30s" {
+		t.Errorf("expected timeout '30s', got %q", cfg.Enrich.Timeout)
+	}
+}
+
+func TestLoadDayStartsAt(t *testing.T) {
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DayStartsAt != "00:00" {
+		t.Errorf("expected days to start at midnight by default, got %q", cfg.DayStartsAt)
+	}
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(configPath, []byte(`day_starts_at = "04:00"`+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = Load(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.DayStartsAt != "04:00" {
+		t.Errorf("expected day_starts_at '04:00', got %q", cfg.DayStartsAt)
+	}
+}
+
+func TestExpandHome(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	tests := map[string]string{
+		"~/src":     filepath.Join(home, "src"),
+		"/abs/path": "/abs/path",
+		"rel/~/dir": "rel/~/dir",
+		"~other":    "~other",
+	}
+	for in, want := range tests {
+		if got := ExpandHome(in); got != want {
+			t.Errorf("ExpandHome(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package calendar
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Options configures the calendar provider and resolver.
+type Options struct {
+	Paths []string // .ics files or directories containing .ics files
+}
+
+// Event is a single occurrence of a calendar event.
+type Event struct {
+	Summary  string
+	Location string
+	Start    time.Time
+	End      time.Time
+	AllDay   bool
+}
+
+// EventsBetween returns the event occurrences overlapping [from, to), sorted by
+// start time with all-day events first.
+func EventsBetween(paths []string, from, to time.Time) ([]Event, error) {
+	vevents, err := loadEvents(paths)
+	if err != nil {
+		return nil, err
+	}
+	var events []Event
+	for _, v := range vevents {
+		length := v.end.Sub(v.start)
+		for _, start := range v.occurrences(from, to) {
+			events = append(events, Event{
+				Summary:  v.summary,
+				Location: v.location,
+				Start:    start,
+				End:      start.Add(length),
+				AllDay:   v.allDay,
+			})
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].AllDay != events[j].AllDay {
+			return events[i].AllDay
+		}
+		return events[i].Start.Before(events[j].Start)
+	})
+	return events, nil
+}
+
+// ContentProvider lists today's events from local calendar files.
+type ContentProvider struct {
+	opts Options
+	now  func() time.Time // injectable for testing
+}
+
+// NewContentProvider creates a calendar content provider.
+func NewContentProvider(opts Options) *ContentProvider {
+	return &ContentProvider{opts: opts, now: time.Now}
+}
+
+func (p *ContentProvider) Name() string { return "calendar" }
+
+func (p *ContentProvider) Generate() (string, error) {
+	if len(p.opts.Paths) == 0 {
+		return "", nil
+	}
+	from, to := dayBounds(p.now())
+	events, err := EventsBetween(p.opts.Paths, from, to)
+	if err != nil {
+		return "", err
+	}
+	if len(events) == 0 {
+		return "", nil
+	}
+
+	var b strings.Builder
+	b.WriteString("## Meetings\n")
+	for _, e := range events {
+		if e.AllDay {
+			fmt.Fprintf(&b, "\n- all day %s", e.Summary)
+		} else {
+			fmt.Fprintf(&b, "\n- %s–%s %s", e.Start.Local().Format("15:04"), e.End.Local().Format("15:04"), e.Summary)
+		}
+		if e.Location != "" {
+			fmt.Fprintf(&b, " (%s)", e.Location)
+		}
+	}
+	return b.String(), nil
+}
+
+// ContextResolver emits a context for each meeting in progress.
+type ContextResolver struct {
+	opts Options
+	now  func() time.Time // injectable for testing
+}
+
+// NewContextResolver creates a calendar context resolver.
+func NewContextResolver(opts Options) *ContextResolver {
+	return &ContextResolver{opts: opts, now: time.Now}
+}
+
+func (r *ContextResolver) Name() string { return "calendar" }
+
+// Resolve returns "meeting/<slug>" for each timed event happening now.
+// All-day events are ignored.
+func (r *ContextResolver) Resolve() ([]string, error) {
+	if len(r.opts.Paths) == 0 {
+		return nil, nil
+	}
+	now := r.now()
+	events, err := EventsBetween(r.opts.Paths, now, now.Add(time.Second))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range events {
+		if e.AllDay {
+			continue
+		}
+		if slug := Slug(e.Summary); slug != "" {
+			names = append(names, "meeting/"+slug)
+		}
+	}
+	return names, nil
+}
+
+var nonSlug = regexp.MustCompile(`[^a-z0-9]+`)
+
+// Slug converts an event summary into a context-friendly name.
+func Slug(s string) string {
+	return strings.Trim(nonSlug.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+func dayBounds(t time.Time) (time.Time, time.Time) {
+	t = t.Local()
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+	return start, start.AddDate(0, 0, 1)
+}
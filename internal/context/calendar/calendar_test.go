@@ -0,0 +1,152 @@
+package calendar
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testICS = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Daily Standup\r\n" +
+	"DTSTART:20260302T091500\r\n" +
+	"DTEND:20260302T093000\r\n" +
+	"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR\r\n" +
+	"EXDATE:20260304T091500\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Design review\\, auth\r\n" +
+	"LOCATION:Room 4\r\n" +
+	"DTSTART:20260303T140000\r\n" +
+	"DURATION:PT1H\r\n" +
+	"BEGIN:VALARM\r\n" +
+	"TRIGGER:-PT10M\r\n" +
+	"END:VALARM\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Offsite\r\n" +
+	"DTSTART;VALUE=DATE:20260303\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"SUMMARY:Cancelled sync\r\n" +
+	"STATUS:CANCELLED\r\n" +
+	"DTSTART:20260303T100000\r\n" +
+	"DTEND:20260303T110000\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func writeCalendar(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "work.ics"), []byte(testICS), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func at(day, hour, min int) time.Time {
+	return time.Date(2026, 3, day, hour, min, 0, 0, time.Local)
+}
+
+func TestContentProvider_Generate(t *testing.T) {
+	dir := writeCalendar(t)
+	p := &ContentProvider{opts: Options{Paths: []string{dir}}, now: func() time.Time { return at(3, 8, 0) }}
+
+	got, err := p.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	want := "## Meetings\n" +
+		"\n- all day Offsite" +
+		"\n- 09:15–09:30 Daily Standup" +
+		"\n- 14:00–15:00 Design review, auth (Room 4)"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestContentProvider_NoEvents(t *testing.T) {
+	dir := writeCalendar(t)
+	// Saturday: no standup, no other events
+	p := &ContentProvider{opts: Options{Paths: []string{dir}}, now: func() time.Time { return at(7, 8, 0) }}
+	got, err := p.Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if got != "" {
+		t.Errorf("expected empty output, got %q", got)
+	}
+}
+
+func TestContentProvider_MissingPath(t *testing.T) {
+	p := NewContentProvider(Options{Paths: []string{filepath.Join(t.TempDir(), "missing.ics")}})
+	if _, err := p.Generate(); err == nil {
+		t.Error("expected error for missing calendar")
+	}
+}
+
+func TestContextResolver_Resolve(t *testing.T) {
+	path := filepath.Join(writeCalendar(t), "work.ics")
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []string
+	}{
+		{"during standup", at(2, 9, 20), []string{"meeting/daily-standup"}},
+		{"excluded date", at(4, 9, 20), nil},
+		{"between meetings", at(3, 12, 0), nil},
+		{"during review", at(3, 14, 30), []string{"meeting/design-review-auth"}},
+		{"cancelled", at(3, 10, 30), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ContextResolver{opts: Options{Paths: []string{path}}, now: func() time.Time { return tt.now }}
+			got, err := r.Resolve()
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNames(t *testing.T) {
+	if NewContentProvider(Options{}).Name() != "calendar" {
+		t.Error("unexpected content provider name")
+	}
+	if NewContextResolver(Options{}).Name() != "calendar" {
+		t.Error("unexpected resolver name")
+	}
+}
+
+func TestUnconfigured(t *testing.T) {
+	out, err := NewContentProvider(Options{}).Generate()
+	if err != nil || out != "" {
+		t.Errorf("expected no output, got %q, %v", out, err)
+	}
+	names, err := NewContextResolver(Options{}).Resolve()
+	if err != nil || names != nil {
+		t.Errorf("expected no contexts, got %v, %v", names, err)
+	}
+}
+
+func TestSlug(t *testing.T) {
+	tests := map[string]string{
+		"Daily Standup":        "daily-standup",
+		"1:1 w/ Sam":           "1-1-w-sam",
+		"  Q3 Planning!  ":     "q3-planning",
+		strings.Repeat("-", 3): "",
+	}
+	for in, want := range tests {
+		if got := Slug(in); got != want {
+			t.Errorf("Slug(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
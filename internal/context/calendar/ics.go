@@ -0,0 +1,639 @@
+package calendar
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/config"
+)
+
+// maxOccurrenceScan bounds the number of days scanned past the start of the
+// requested range when expanding a recurring event, guarding against
+// malformed rules.
+const maxOccurrenceScan = 20000
+
+// warnf reports a calendar file or event that was skipped.
+var warnf = defaultWarnf
+
+func defaultWarnf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "Warning: "+format+"\n", args...)
+}
+
+// vevent is a parsed VEVENT component.
+type vevent struct {
+	uid          string
+	summary      string
+	location     string
+	start        time.Time
+	end          time.Time
+	allDay       bool
+	rule         *rrule
+	exdates      map[time.Time]bool
+	recurrenceID time.Time // set on overrides of a single occurrence
+	cancelled    bool      // only kept for overrides, to drop the occurrence
+}
+
+// rrule is the subset of RFC 5545 recurrence rules supported:
+// FREQ=DAILY|WEEKLY|MONTHLY|YEARLY with INTERVAL, UNTIL, COUNT, BYDAY
+// (ordinals such as 2TU or -1FR for monthly and yearly rules), BYMONTHDAY
+// and BYMONTH.
+type rrule struct {
+	freq       string
+	interval   int
+	until      time.Time
+	count      int
+	byDay      []weekdayNum
+	byMonthDay []int
+	byMonth    []time.Month
+}
+
+// weekdayNum is a BYDAY value: a weekday with an optional ordinal (0 = every).
+type weekdayNum struct {
+	n  int
+	wd time.Weekday
+}
+
+// errUnsupportedRule marks recurrence rules outside the supported subset.
+var errUnsupportedRule = errors.New("unsupported RRULE")
+
+// property is a single content line: NAME;PARAM=VALUE:value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// loadEvents reads events from .ics files, or from all .ics files in
+// directories, listed in paths. Unreadable and malformed files are skipped
+// with a warning; a missing path is an error.
+func loadEvents(paths []string) ([]vevent, error) {
+	var events []vevent
+	for _, p := range paths {
+		p = config.ExpandHome(p)
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, fmt.Errorf("reading calendar %s: %w", p, err)
+		}
+		files := []string{p}
+		if info.IsDir() {
+			files, err = filepath.Glob(filepath.Join(p, "*.ics"))
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, f := range files {
+			fh, err := os.Open(f)
+			if err != nil {
+				warnf("skipping calendar %s: %v", f, err)
+				continue
+			}
+			evs, err := parseICS(fh)
+			fh.Close()
+			if err != nil {
+				warnf("skipping calendar %s: %v", f, err)
+				continue
+			}
+			events = append(events, evs...)
+		}
+	}
+	return applyOverrides(events), nil
+}
+
+// applyOverrides replaces the occurrences of recurring events that were moved
+// or cancelled with their overrides, matched by UID and RECURRENCE-ID.
+func applyOverrides(events []vevent) []vevent {
+	type key struct {
+		uid string
+		at  time.Time
+	}
+	overrides := map[key]vevent{}
+	var order []key
+	for _, e := range events {
+		if e.uid == "" || e.recurrenceID.IsZero() {
+			continue
+		}
+		k := key{e.uid, e.recurrenceID.UTC()}
+		if _, ok := overrides[k]; !ok {
+			order = append(order, k)
+		}
+		overrides[k] = e // later overrides win
+	}
+	if len(overrides) == 0 {
+		return events
+	}
+
+	out := make([]vevent, 0, len(events))
+	for _, e := range events {
+		if !e.recurrenceID.IsZero() {
+			continue
+		}
+		for k := range overrides {
+			if k.uid == e.uid {
+				e.exdates[k.at] = true
+			}
+		}
+		out = append(out, e)
+	}
+	for _, k := range order {
+		if o := overrides[k]; !o.cancelled {
+			o.rule = nil // an override is a single occurrence
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+// parseICS parses the VEVENT components of an iCalendar stream.
+// Cancelled events and events without a start time are skipped, as are
+// events with unsupported recurrence rules (with a warning). Cancelled
+// overrides of a single occurrence are kept so the occurrence can be dropped.
+func parseICS(r io.Reader) ([]vevent, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []vevent
+	var cur *vevent
+	var duration time.Duration
+	var hasEnd bool
+	var skip error
+	depth := 0 // nesting inside VEVENT (e.g. VALARM)
+
+	for _, line := range lines {
+		prop := parseProperty(line)
+		switch {
+		case prop.name == "BEGIN" && prop.value == "VEVENT":
+			cur = &vevent{exdates: map[time.Time]bool{}}
+			duration, hasEnd, skip, depth = 0, false, nil, 0
+			continue
+		case cur == nil:
+			continue
+		case prop.name == "BEGIN":
+			depth++
+			continue
+		case prop.name == "END" && prop.value != "VEVENT":
+			depth--
+			continue
+		case prop.name == "END":
+			if skip != nil {
+				warnf("skipping calendar event %q: %v", cur.summary, skip)
+			} else if !cur.start.IsZero() && (!cur.cancelled || !cur.recurrenceID.IsZero()) {
+				if !hasEnd {
+					switch {
+					case duration > 0:
+						cur.end = cur.start.Add(duration)
+					case cur.allDay:
+						cur.end = cur.start.AddDate(0, 0, 1)
+					default:
+						cur.end = cur.start
+					}
+				}
+				events = append(events, *cur)
+			}
+			cur = nil
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+
+		switch prop.name {
+		case "SUMMARY":
+			cur.summary = unescape(prop.value)
+		case "LOCATION":
+			cur.location = unescape(prop.value)
+		case "UID":
+			cur.uid = prop.value
+		case "STATUS":
+			cur.cancelled = strings.EqualFold(prop.value, "CANCELLED")
+		case "DTSTART":
+			t, allDay, err := parseDateTime(prop)
+			if err != nil {
+				return nil, err
+			}
+			cur.start, cur.allDay = t, allDay
+		case "DTEND":
+			t, _, err := parseDateTime(prop)
+			if err != nil {
+				return nil, err
+			}
+			cur.end, hasEnd = t, true
+		case "DURATION":
+			d, err := parseDuration(prop.value)
+			if err != nil {
+				return nil, err
+			}
+			duration = d
+		case "RECURRENCE-ID":
+			t, _, err := parseDateTime(prop)
+			if err != nil {
+				return nil, err
+			}
+			cur.recurrenceID = t
+		case "RRULE":
+			rule, err := parseRRule(prop.value)
+			if errors.Is(err, errUnsupportedRule) {
+				skip = err
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			cur.rule = rule
+		case "EXDATE":
+			for _, v := range strings.Split(prop.value, ",") {
+				t, _, err := parseDateTime(property{name: prop.name, params: prop.params, value: v})
+				if err != nil {
+					return nil, err
+				}
+				cur.exdates[t.UTC()] = true
+			}
+		}
+	}
+	return events, nil
+}
+
+// unfold joins folded content lines (continuations start with a space or tab).
+func unfold(r io.Reader) ([]string, error) {
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
+}
+
+func parseProperty(line string) property {
+	prop := property{params: map[string]string{}}
+	head, value, _ := strings.Cut(line, ":")
+	prop.value = value
+	parts := strings.Split(head, ";")
+	prop.name = strings.ToUpper(parts[0])
+	for _, p := range parts[1:] {
+		k, v, _ := strings.Cut(p, "=")
+		prop.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+	return prop
+}
+
+// parseDateTime parses DATE and DATE-TIME values. UTC ("Z") times, TZID-qualified
+// times and floating times (interpreted as local) are supported.
+func parseDateTime(prop property) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+	loc := time.Local
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	if prop.params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.ParseInLocation("20060102", value, time.Local)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s %q", prop.name, value)
+		}
+		return t, true, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("invalid %s %q", prop.name, value)
+		}
+		return t, false, nil
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s %q", prop.name, value)
+	}
+	return t, false, nil
+}
+
+var durationPattern = regexp.MustCompile(`^([+-])?P(?:(\d+)W)?(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+)S)?)?$`)
+
+// parseDuration parses an RFC 5545 duration such as PT30M or P1DT2H.
+func parseDuration(s string) (time.Duration, error) {
+	m := durationPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if m == nil {
+		return 0, fmt.Errorf("invalid DURATION %q", s)
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] != "" {
+			n, _ := strconv.Atoi(m[i+2])
+			d += time.Duration(n) * unit
+		}
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, nil
+}
+
+var icsWeekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+var byDayPattern = regexp.MustCompile(`^([+-]?\d{1,2})?(SU|MO|TU|WE|TH|FR|SA)$`)
+
+// parseRRule parses a recurrence rule. Rules outside the supported subset
+// return an error wrapping errUnsupportedRule.
+func parseRRule(s string) (*rrule, error) {
+	r := &rrule{interval: 1}
+	for _, part := range strings.Split(s, ";") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k = strings.ToUpper(k); k {
+		case "FREQ":
+			r.freq = strings.ToUpper(v)
+		case "INTERVAL":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid RRULE INTERVAL %q", v)
+			}
+			r.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid RRULE COUNT %q", v)
+			}
+			r.count = n
+		case "UNTIL":
+			t, _, err := parseDateTime(property{name: "UNTIL", params: map[string]string{}, value: v})
+			if err != nil {
+				return nil, err
+			}
+			r.until = t
+		case "BYDAY":
+			for _, d := range strings.Split(v, ",") {
+				m := byDayPattern.FindStringSubmatch(strings.ToUpper(d))
+				if m == nil {
+					return nil, fmt.Errorf("invalid RRULE BYDAY %q", v)
+				}
+				n, _ := strconv.Atoi(m[1])
+				if n < -53 || n > 53 {
+					return nil, fmt.Errorf("invalid RRULE BYDAY %q", v)
+				}
+				r.byDay = append(r.byDay, weekdayNum{n: n, wd: icsWeekdays[m[2]]})
+			}
+		case "BYMONTHDAY":
+			for _, d := range strings.Split(v, ",") {
+				n, err := strconv.Atoi(d)
+				if err != nil || n == 0 || n < -31 || n > 31 {
+					return nil, fmt.Errorf("invalid RRULE BYMONTHDAY %q", v)
+				}
+				r.byMonthDay = append(r.byMonthDay, n)
+			}
+		case "BYMONTH":
+			for _, m := range strings.Split(v, ",") {
+				n, err := strconv.Atoi(m)
+				if err != nil || n < 1 || n > 12 {
+					return nil, fmt.Errorf("invalid RRULE BYMONTH %q", v)
+				}
+				r.byMonth = append(r.byMonth, time.Month(n))
+			}
+		case "WKST":
+			// weeks are counted from Monday
+		default:
+			return nil, fmt.Errorf("%w: %s", errUnsupportedRule, k)
+		}
+	}
+	switch r.freq {
+	case "DAILY", "WEEKLY", "MONTHLY", "YEARLY":
+		return r, nil
+	}
+	return nil, fmt.Errorf("%w: FREQ=%s", errUnsupportedRule, r.freq)
+}
+
+// occurrences returns the start times of the event that begin before to and
+// end after from.
+func (e vevent) occurrences(from, to time.Time) []time.Time {
+	length := e.end.Sub(e.start)
+	overlaps := func(s time.Time) bool {
+		end := s.Add(length)
+		if length == 0 {
+			return !s.Before(from) && s.Before(to)
+		}
+		return s.Before(to) && end.After(from)
+	}
+
+	if e.rule == nil {
+		if overlaps(e.start) && !e.excluded(e.start) {
+			return []time.Time{e.start}
+		}
+		return nil
+	}
+
+	loc := e.start.Location()
+	start := e.start
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	weekStart := startDay.AddDate(0, 0, -int((start.Weekday()+6)%7)) // Monday of DTSTART's week
+
+	// Jump to the period holding the first occurrence that can overlap from.
+	// With COUNT every earlier day is still scanned so that the occurrences
+	// skipped over are counted.
+	first := 0
+	if e.rule.count == 0 {
+		first = e.rule.periodOffset(startDay, weekStart, from.Add(-length).In(loc))
+	}
+	limit := max(first, daysBetween(startDay, from.In(loc))) + maxOccurrenceScan
+
+	var out []time.Time
+	n := 0
+	for i := first; i < limit; i++ {
+		day := startDay.AddDate(0, 0, i)
+		occ := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, loc)
+		if !occ.Before(to) {
+			break
+		}
+		if !e.rule.until.IsZero() && occ.After(e.rule.until) {
+			break
+		}
+
+		if !e.rule.matches(day, start, weekStart, i) {
+			continue
+		}
+		n++
+		if e.rule.count > 0 && n > e.rule.count {
+			break
+		}
+		if e.excluded(occ) {
+			continue
+		}
+		if overlaps(occ) {
+			out = append(out, occ)
+		}
+	}
+	return out
+}
+
+// periodOffset returns how many days after startDay the rule's period (day,
+// week, month or year, stepped by INTERVAL) containing t begins, or 0 when t
+// falls in the first period. weekStart is the Monday of startDay's week.
+func (r *rrule) periodOffset(startDay, weekStart, t time.Time) int {
+	loc := startDay.Location()
+	t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	if !t.After(startDay) {
+		return 0
+	}
+	var p time.Time
+	switch r.freq {
+	case "DAILY":
+		d := daysBetween(startDay, t)
+		p = startDay.AddDate(0, 0, d-d%r.interval)
+	case "WEEKLY":
+		w := daysBetween(weekStart, t) / 7
+		p = weekStart.AddDate(0, 0, (w-w%r.interval)*7)
+	case "MONTHLY":
+		m := (t.Year()-startDay.Year())*12 + int(t.Month()-startDay.Month())
+		p = time.Date(startDay.Year(), startDay.Month()+time.Month(m-m%r.interval), 1, 0, 0, 0, 0, loc)
+	case "YEARLY":
+		y := t.Year() - startDay.Year()
+		p = time.Date(startDay.Year()+y-y%r.interval, time.January, 1, 0, 0, 0, 0, loc)
+	}
+	if !p.After(startDay) {
+		return 0
+	}
+	return daysBetween(startDay, p)
+}
+
+// daysBetween returns the number of calendar days from the day of a to the
+// day of b, ignoring DST changes in between.
+func daysBetween(a, b time.Time) int {
+	da := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	db := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+	return int(db.Sub(da).Hours() / 24)
+}
+
+// excluded reports whether the occurrence at occ is listed in EXDATE, either
+// by its start time or, for date-only EXDATEs, by its day.
+func (e vevent) excluded(occ time.Time) bool {
+	midnight := time.Date(occ.Year(), occ.Month(), occ.Day(), 0, 0, 0, 0, time.Local)
+	return e.exdates[occ.UTC()] || e.exdates[midnight.UTC()]
+}
+
+// matches reports whether the rule has an occurrence on day, which is i days
+// after the day of start. weekStart is the Monday of start's week.
+func (r *rrule) matches(day, start, weekStart time.Time, i int) bool {
+	switch r.freq {
+	case "DAILY":
+		return i%r.interval == 0 && r.matchesFilters(day)
+	case "WEEKLY":
+		week := int(day.Sub(weekStart).Hours()/24+0.5) / 7
+		if week%r.interval != 0 || !r.matchesFilters(day) {
+			return false
+		}
+		if len(r.byDay) == 0 {
+			return day.Weekday() == start.Weekday()
+		}
+		return r.matchesByDay(day, false)
+	case "MONTHLY":
+		months := (day.Year()-start.Year())*12 + int(day.Month()-start.Month())
+		if months%r.interval != 0 || !r.matchesFilters(day) {
+			return false
+		}
+		if len(r.byDay) == 0 && len(r.byMonthDay) == 0 {
+			return day.Day() == start.Day()
+		}
+		return r.matchesByDay(day, false) && r.matchesByMonthDay(day)
+	case "YEARLY":
+		if (day.Year()-start.Year())%r.interval != 0 || !r.matchesFilters(day) {
+			return false
+		}
+		if len(r.byDay) == 0 && len(r.byMonthDay) == 0 {
+			if len(r.byMonth) == 0 && day.Month() != start.Month() {
+				return false
+			}
+			return day.Day() == start.Day()
+		}
+		return r.matchesByDay(day, len(r.byMonth) == 0) && r.matchesByMonthDay(day)
+	}
+	return false
+}
+
+// matchesFilters applies BYMONTH, and BYDAY and BYMONTHDAY where they limit
+// rather than expand the rule.
+func (r *rrule) matchesFilters(day time.Time) bool {
+	if len(r.byMonth) > 0 && !containsMonth(r.byMonth, day.Month()) {
+		return false
+	}
+	switch r.freq {
+	case "DAILY":
+		return r.matchesByDay(day, false) && r.matchesByMonthDay(day)
+	case "WEEKLY":
+		return r.matchesByMonthDay(day)
+	}
+	return true
+}
+
+// matchesByDay reports whether day matches one of the BYDAY values (true when
+// there are none). Ordinals count within the month, or within the year when
+// inYear is set, and only apply to monthly and yearly rules.
+func (r *rrule) matchesByDay(day time.Time, inYear bool) bool {
+	if len(r.byDay) == 0 {
+		return true
+	}
+	ordinal := r.freq == "MONTHLY" || r.freq == "YEARLY"
+	for _, bd := range r.byDay {
+		if bd.wd != day.Weekday() {
+			continue
+		}
+		if bd.n == 0 || !ordinal {
+			return true
+		}
+		pos, total := day.Day(), time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		if inYear {
+			pos, total = day.YearDay(), time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		}
+		if bd.n > 0 && (pos-1)/7+1 == bd.n || bd.n < 0 && (total-pos)/7+1 == -bd.n {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesByMonthDay reports whether day matches one of the BYMONTHDAY values
+// (true when there are none). Negative values count from the end of the month.
+func (r *rrule) matchesByMonthDay(day time.Time) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	last := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, md := range r.byMonthDay {
+		if md == day.Day() || md < 0 && last+md+1 == day.Day() {
+			return true
+		}
+	}
+	return false
+}
+
+func containsMonth(months []time.Month, m time.Month) bool {
+	for _, mo := range months {
+		if mo == m {
+			return true
+		}
+	}
+	return false
+}
+
+func unescape(s string) string {
+	r := strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return strings.TrimSpace(r.Replace(s))
+}
@@ -0,0 +1,284 @@
+package calendar
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseICS_Folding(t *testing.T) {
+	ics := "BEGIN:VEVENT\r\nSUMMARY:A very long\r\n  meeting title\r\nDTSTART:20260302T090000Z\r\nDTEND:20260302T100000Z\r\nEND:VEVENT\r\n"
+	events, err := parseICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("parseICS: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].summary != "A very long meeting title" {
+		t.Errorf("got summary %q", events[0].summary)
+	}
+	if !events[0].start.Equal(time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("got start %v", events[0].start)
+	}
+}
+
+func TestParseDateTime(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("tzdata unavailable")
+	}
+	tests := []struct {
+		name       string
+		prop       property
+		want       time.Time
+		wantAllDay bool
+	}{
+		{"utc", property{value: "20260302T090000Z"}, time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC), false},
+		{"floating", property{value: "20260302T090000"}, time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local), false},
+		{"tzid", property{params: map[string]string{"TZID": "America/New_York"}, value: "20260302T090000"}, time.Date(2026, 3, 2, 9, 0, 0, 0, ny), false},
+		{"date", property{params: map[string]string{"VALUE": "DATE"}, value: "20260302"}, time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.prop.params == nil {
+				tt.prop.params = map[string]string{}
+			}
+			got, allDay, err := parseDateTime(tt.prop)
+			if err != nil {
+				t.Fatalf("parseDateTime: %v", err)
+			}
+			if !got.Equal(tt.want) || allDay != tt.wantAllDay {
+				t.Errorf("got %v (allDay=%v), want %v (allDay=%v)", got, allDay, tt.want, tt.wantAllDay)
+			}
+		})
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"PT30M", 30 * time.Minute},
+		{"PT1H30M", 90 * time.Minute},
+		{"P1D", 24 * time.Hour},
+		{"P1W", 7 * 24 * time.Hour},
+		{"-PT15M", -15 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := parseDuration(tt.in)
+		if err != nil {
+			t.Fatalf("parseDuration(%q): %v", tt.in, err)
+		}
+		if got != tt.want {
+			t.Errorf("parseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	if _, err := parseDuration("30 minutes"); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+}
+
+func TestOccurrences_Rules(t *testing.T) {
+	start := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local) // Monday
+	from := start
+	to := start.AddDate(0, 0, 14)
+
+	tests := []struct {
+		name string
+		rule string
+		want int
+	}{
+		{"daily", "FREQ=DAILY", 14},
+		{"every other day", "FREQ=DAILY;INTERVAL=2", 7},
+		{"count", "FREQ=DAILY;COUNT=3", 3},
+		{"until", "FREQ=DAILY;UNTIL=20260304T235959Z", 3},
+		{"weekly default day", "FREQ=WEEKLY", 2},
+		{"weekly byday", "FREQ=WEEKLY;BYDAY=MO,WE", 4},
+		{"biweekly", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", 1},
+		{"monthly same day", "FREQ=MONTHLY", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRRule: %v", err)
+			}
+			e := vevent{start: start, end: start.Add(time.Hour), rule: rule, exdates: map[time.Time]bool{}}
+			got := e.occurrences(from, to)
+			if len(got) != tt.want {
+				t.Errorf("got %d occurrences, want %d: %v", len(got), tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOccurrences_MonthlyYearly(t *testing.T) {
+	start := time.Date(2026, 1, 15, 9, 0, 0, 0, time.Local) // Thursday
+	from := start
+	to := time.Date(2028, 1, 1, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name  string
+		rule  string
+		want  int
+		first time.Time
+	}{
+		{"monthly same day", "FREQ=MONTHLY", 24, start},
+		{"quarterly", "FREQ=MONTHLY;INTERVAL=3", 8, start},
+		{"by month day", "FREQ=MONTHLY;BYMONTHDAY=15,-1", 48, start},
+		{"second tuesday", "FREQ=MONTHLY;BYDAY=2TU", 23, time.Date(2026, 2, 10, 9, 0, 0, 0, time.Local)},
+		{"last friday", "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", 3, time.Date(2026, 1, 30, 9, 0, 0, 0, time.Local)},
+		{"yearly", "FREQ=YEARLY", 2, start},
+		{"thanksgiving", "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH", 2, time.Date(2026, 11, 26, 9, 0, 0, 0, time.Local)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRRule: %v", err)
+			}
+			e := vevent{start: start, end: start.Add(time.Hour), rule: rule, exdates: map[time.Time]bool{}}
+			got := e.occurrences(from, to)
+			if len(got) != tt.want {
+				t.Fatalf("got %d occurrences, want %d: %v", len(got), tt.want, got)
+			}
+			if !got[0].Equal(tt.first) {
+				t.Errorf("first occurrence %v, want %v", got[0], tt.first)
+			}
+		})
+	}
+}
+
+func TestOccurrences_OldStart(t *testing.T) {
+	// A birthday exported with the birth year as DTSTART, far past the scan bound
+	start := time.Date(1960, 5, 14, 0, 0, 0, 0, time.Local)
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.Local)
+	to := time.Date(2026, 6, 1, 0, 0, 0, 0, time.Local)
+	birthday := time.Date(2026, 5, 14, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name string
+		rule string
+		want []time.Time
+	}{
+		{"yearly", "FREQ=YEARLY", []time.Time{birthday}},
+		{"every other year", "FREQ=YEARLY;INTERVAL=2", []time.Time{birthday}},
+		{"every fourth year", "FREQ=YEARLY;INTERVAL=4", nil},
+		{"monthly", "FREQ=MONTHLY", []time.Time{birthday}},
+		{"fortnightly on saturdays", "FREQ=WEEKLY;INTERVAL=2", []time.Time{
+			time.Date(2026, 5, 2, 0, 0, 0, 0, time.Local), time.Date(2026, 5, 16, 0, 0, 0, 0, time.Local),
+			time.Date(2026, 5, 30, 0, 0, 0, 0, time.Local),
+		}},
+		{"count used up", "FREQ=YEARLY;COUNT=3", nil},
+		{"count reaches from", "FREQ=YEARLY;COUNT=67", []time.Time{birthday}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := parseRRule(tt.rule)
+			if err != nil {
+				t.Fatalf("parseRRule: %v", err)
+			}
+			e := vevent{start: start, end: start.AddDate(0, 0, 1), rule: rule, exdates: map[time.Time]bool{}}
+			got := e.occurrences(from, to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseRRule_Unsupported(t *testing.T) {
+	for _, rule := range []string{"FREQ=HOURLY", "FREQ=MONTHLY;BYSETPOS=-1;BYDAY=MO,TU,WE,TH,FR"} {
+		if _, err := parseRRule(rule); !errors.Is(err, errUnsupportedRule) {
+			t.Errorf("parseRRule(%q) = %v, want unsupported", rule, err)
+		}
+	}
+	if _, err := parseRRule("FREQ=MONTHLY;BYMONTHDAY=40"); err == nil || errors.Is(err, errUnsupportedRule) {
+		t.Errorf("expected invalid BYMONTHDAY error, got %v", err)
+	}
+}
+
+func TestParseICS_UnsupportedRuleSkipped(t *testing.T) {
+	var warnings []string
+	warnf = func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+	t.Cleanup(func() { warnf = defaultWarnf })
+
+	ics := "BEGIN:VEVENT\r\nSUMMARY:Pomodoro\r\nDTSTART:20260302T090000Z\r\nRRULE:FREQ=HOURLY\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Lunch\r\nDTSTART:20260302T120000Z\r\nEND:VEVENT\r\n"
+	events, err := parseICS(strings.NewReader(ics))
+	if err != nil {
+		t.Fatalf("parseICS: %v", err)
+	}
+	if len(events) != 1 || events[0].summary != "Lunch" {
+		t.Errorf("expected only the supported event, got %+v", events)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "Pomodoro") {
+		t.Errorf("expected a warning for the skipped event, got %v", warnings)
+	}
+}
+
+func TestLoadEvents_OverridesAndExdates(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\nUID:sync@example.com\r\nSUMMARY:Team sync\r\n" +
+		"DTSTART:20260302T100000\r\nDTEND:20260302T103000\r\nRRULE:FREQ=DAILY;COUNT=5\r\n" +
+		"EXDATE;VALUE=DATE:20260306\r\nEND:VEVENT\r\n" +
+		// Tuesday's sync moved to 15:00
+		"BEGIN:VEVENT\r\nUID:sync@example.com\r\nSUMMARY:Team sync (moved)\r\nRECURRENCE-ID:20260303T100000\r\n" +
+		"DTSTART:20260303T150000\r\nDTEND:20260303T153000\r\nEND:VEVENT\r\n" +
+		// Wednesday's sync cancelled
+		"BEGIN:VEVENT\r\nUID:sync@example.com\r\nSUMMARY:Team sync\r\nRECURRENCE-ID:20260304T100000\r\n" +
+		"STATUS:CANCELLED\r\nDTSTART:20260304T100000\r\nDTEND:20260304T103000\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	path := filepath.Join(t.TempDir(), "sync.ics")
+	if err := os.WriteFile(path, []byte(ics), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := EventsBetween([]string{path}, at(2, 0, 0), at(9, 0, 0))
+	if err != nil {
+		t.Fatalf("EventsBetween: %v", err)
+	}
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Start.Format("02 15:04 ")+e.Summary)
+	}
+	want := []string{"02 10:00 Team sync", "03 15:00 Team sync (moved)", "05 10:00 Team sync"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestLoadEvents_SkipsBadFiles(t *testing.T) {
+	var warnings []string
+	warnf = func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+	t.Cleanup(func() { warnf = defaultWarnf })
+
+	dir := writeCalendar(t)
+	bad := "BEGIN:VEVENT\r\nSUMMARY:Broken\r\nDTSTART:not-a-date\r\nEND:VEVENT\r\n"
+	if err := os.WriteFile(filepath.Join(dir, "broken.ics"), []byte(bad), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := EventsBetween([]string{dir}, at(3, 0, 0), at(4, 0, 0))
+	if err != nil {
+		t.Fatalf("EventsBetween: %v", err)
+	}
+	if len(events) != 3 {
+		t.Errorf("expected the events of the readable calendar, got %+v", events)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "broken.ics") {
+		t.Errorf("expected a warning for the broken file, got %v", warnings)
+	}
+}
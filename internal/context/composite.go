@@ -1,17 +1,18 @@
 package context
 
-// TODO: Wire CompositeProvider into the application layer (cmd/root.go or TUI)
-// to enable MCP-backed context search and filter capabilities.
-// See: docs/plans/2026-03-03-context-wiring-design.md
-
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
 
 	"github.com/chris-regnier/diaryctl/internal/mcptools"
 	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
-// CompositeProvider implements Provider using the local MCP server.
+// CompositeProvider implements Provider using an MCP server's diary tools,
+// either the in-memory server or an external one.
 type CompositeProvider struct {
 	client *MCPClient
 }
@@ -27,6 +28,52 @@ func NewCompositeProvider(store storage.Storage) (*CompositeProvider, error) {
 	return &CompositeProvider{client: client}, nil
 }
 
+// ExternalOptions locates an external MCP server serving the diary tools.
+type ExternalOptions struct {
+	URL     string   // streamable HTTP endpoint, e.g. http://localhost:8765
+	Token   string   // bearer token sent to URL
+	Command []string // stdio server command, used when URL is empty
+}
+
+// NewExternalProvider creates a provider backed by an external MCP server,
+// such as another "diaryctl mcp-serve".
+func NewExternalProvider(opts ExternalOptions) (*CompositeProvider, error) {
+	var transport mcp.Transport
+	switch {
+	case opts.URL != "":
+		httpClient := http.DefaultClient
+		if opts.Token != "" {
+			httpClient = &http.Client{Transport: bearerTransport{token: opts.Token}}
+		}
+		transport = &mcp.StreamableClientTransport{Endpoint: opts.URL, HTTPClient: httpClient}
+	case len(opts.Command) > 0:
+		transport = &mcp.CommandTransport{Command: exec.Command(opts.Command[0], opts.Command[1:]...)}
+	default:
+		return nil, fmt.Errorf("external provider needs a url or command")
+	}
+	client, err := NewMCPClient(transport)
+	if err != nil {
+		return nil, err
+	}
+	return &CompositeProvider{client: client}, nil
+}
+
+// bearerTransport adds an Authorization header to every request.
+type bearerTransport struct {
+	token string
+}
+
+func (b bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// Close closes the connection to the MCP server.
+func (p *CompositeProvider) Close() error {
+	return p.client.Close()
+}
+
 // Search implements Provider.Search.
 func (p *CompositeProvider) Search(ctx context.Context, query string, limit int) ([]EntryResult, error) {
 	return p.client.Search(ctx, query, limit)
@@ -46,6 +93,9 @@ func (p *CompositeProvider) Filter(ctx context.Context, opts storage.ListOptions
 	if opts.TemplateName != "" {
 		input.TemplateNames = []string{opts.TemplateName}
 	}
+	if opts.ContextName != "" {
+		input.ContextNames = []string{opts.ContextName}
+	}
 	return p.client.Filter(ctx, input)
 }
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/context/calendar"
 	"github.com/chris-regnier/diaryctl/internal/context/datetime"
 	gitctx "github.com/chris-regnier/diaryctl/internal/context/git"
 	"github.com/chris-regnier/diaryctl/internal/entry"
@@ -130,29 +131,53 @@ func ComposeContent(providers []ContentProvider, templateContent string) string
 	}
 }
 
-var contentProviders = map[string]func() ContentProvider{
-	"datetime": func() ContentProvider { return datetime.New() },
-	"git":      func() ContentProvider { return gitctx.NewContentProvider() },
+// ProviderConfig carries settings for content providers and context resolvers
+// that need more than their name to be constructed.
+type ProviderConfig struct {
+	GitActivity gitctx.ActivityOptions
+	Calendar    calendar.Options
 }
 
-var contextResolvers = map[string]func() ContextResolver{
-	"git": func() ContextResolver { return gitctx.NewContextResolver() },
+var contentProviders = map[string]func(ProviderConfig) ContentProvider{
+	"calendar":     func(cfg ProviderConfig) ContentProvider { return calendar.NewContentProvider(cfg.Calendar) },
+	"datetime":     func(ProviderConfig) ContentProvider { return datetime.New() },
+	"git":          func(ProviderConfig) ContentProvider { return gitctx.NewContentProvider() },
+	"git-activity": func(cfg ProviderConfig) ContentProvider { return gitctx.NewActivityProvider(cfg.GitActivity) },
 }
 
-// LookupContentProvider returns a content provider by name, or nil if unknown.
+var contextResolvers = map[string]func(ProviderConfig) ContextResolver{
+	"calendar": func(cfg ProviderConfig) ContextResolver { return calendar.NewContextResolver(cfg.Calendar) },
+	"git":      func(ProviderConfig) ContextResolver { return gitctx.NewContextResolver() },
+}
+
+// LookupContentProvider returns a content provider by name with default settings,
+// or nil if unknown.
 func LookupContentProvider(name string) ContentProvider {
+	return LookupContentProviderWithConfig(name, ProviderConfig{})
+}
+
+// LookupContentProviderWithConfig returns a content provider by name configured
+// from cfg, or nil if unknown.
+func LookupContentProviderWithConfig(name string, cfg ProviderConfig) ContentProvider {
 	factory, ok := contentProviders[name]
 	if !ok {
 		return nil
 	}
-	return factory()
+	return factory(cfg)
 }
 
-// LookupContextResolver returns a context resolver by name, or nil if unknown.
+// LookupContextResolver returns a context resolver by name with default settings,
+// or nil if unknown.
 func LookupContextResolver(name string) ContextResolver {
+	return LookupContextResolverWithConfig(name, ProviderConfig{})
+}
+
+// LookupContextResolverWithConfig returns a context resolver by name configured
+// from cfg, or nil if unknown.
+func LookupContextResolverWithConfig(name string, cfg ProviderConfig) ContextResolver {
 	factory, ok := contextResolvers[name]
 	if !ok {
 		return nil
 	}
-	return factory()
+	return factory(cfg)
 }
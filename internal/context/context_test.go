@@ -201,6 +201,27 @@ func TestLookupContentProvider_git(t *testing.T) {
 	}
 }
 
+func TestLookupContentProviderWithConfig_gitActivity(t *testing.T) {
+	p := LookupContentProviderWithConfig("git-activity", ProviderConfig{})
+	if p == nil {
+		t.Fatal("expected git-activity provider")
+	}
+	if p.Name() != "git-activity" {
+		t.Errorf("got name %q", p.Name())
+	}
+}
+
+func TestLookupCalendar(t *testing.T) {
+	cfg := ProviderConfig{}
+	cfg.Calendar.Paths = []string{"work.ics"}
+	if p := LookupContentProviderWithConfig("calendar", cfg); p == nil || p.Name() != "calendar" {
+		t.Errorf("expected calendar content provider, got %v", p)
+	}
+	if r := LookupContextResolverWithConfig("calendar", cfg); r == nil || r.Name() != "calendar" {
+		t.Errorf("expected calendar context resolver, got %v", r)
+	}
+}
+
 func TestLookupContentProvider_unknown(t *testing.T) {
 	p := LookupContentProvider("nonexistent")
 	if p != nil {
@@ -0,0 +1,114 @@
+package context
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/template"
+)
+
+// DirectProvider implements Provider and Enricher by reading the store
+// directly, without an MCP round trip.
+type DirectProvider struct {
+	store storage.Storage
+}
+
+// NewDirectProvider creates a provider backed by store.
+func NewDirectProvider(store storage.Storage) *DirectProvider {
+	return &DirectProvider{store: store}
+}
+
+// Search implements Provider.Search with a case-insensitive substring match
+// over all entries, newest first.
+func (p *DirectProvider) Search(ctx context.Context, query string, limit int) ([]EntryResult, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	entries, err := p.store.List(storage.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	q := strings.ToLower(query)
+	var results []EntryResult
+	for _, e := range entries {
+		if strings.Contains(strings.ToLower(e.Content), q) {
+			results = append(results, toEntryResult(e))
+			if len(results) >= limit {
+				break
+			}
+		}
+	}
+	return results, nil
+}
+
+// Filter implements Provider.Filter.
+func (p *DirectProvider) Filter(ctx context.Context, opts storage.ListOptions) ([]EntryResult, error) {
+	entries, err := p.store.List(opts)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]EntryResult, len(entries))
+	for i, e := range entries {
+		results[i] = toEntryResult(e)
+	}
+	return results, nil
+}
+
+var (
+	hashtagPattern = regexp.MustCompile(`(?:^|\s)#([\w][\w/:-]*)`)
+	moodPattern    = regexp.MustCompile(`(?im)^\s*mood:\s*(\S.*?)\s*$`)
+)
+
+// Enrich implements Enricher without a language model: existing contexts
+// named in the content and #hashtags are suggested as tags, a "Mood:" line
+// gives the mood, and open tasks become follow-up prompts.
+func (p *DirectProvider) Enrich(ctx context.Context, content string) (EnrichedContent, error) {
+	out := EnrichedContent{Content: content, SuggestedTags: []string{}, FollowUpPrompts: []string{}}
+
+	lower := strings.ToLower(content)
+	contexts, err := p.store.ListContexts()
+	if err != nil {
+		return out, err
+	}
+	for _, c := range contexts {
+		if strings.Contains(lower, strings.ToLower(c.Name)) {
+			out.SuggestedTags = append(out.SuggestedTags, c.Name)
+		}
+	}
+	for _, m := range hashtagPattern.FindAllStringSubmatch(content, -1) {
+		if tag := strings.ToLower(m[1]); !slices.Contains(out.SuggestedTags, tag) {
+			out.SuggestedTags = append(out.SuggestedTags, tag)
+		}
+	}
+
+	if m := moodPattern.FindStringSubmatch(content); m != nil {
+		out.Mood = m[1]
+	}
+	for _, task := range template.OpenTasks(content) {
+		task = strings.TrimSpace(task[len("- [ ]"):])
+		if task != "" {
+			out.FollowUpPrompts = append(out.FollowUpPrompts, "Follow up on: "+task)
+		}
+	}
+	return out, nil
+}
+
+func toEntryResult(e entry.Entry) EntryResult {
+	return EntryResult{
+		ID:      e.ID,
+		Preview: e.Preview(100),
+		Date:    day.OfZone(e.CreatedAt, e.TimeZone).Format("2006-01-02"),
+		Score:   1.0,
+	}
+}
+
+// Ensure DirectProvider implements Provider and Enricher.
+var (
+	_ Provider = (*DirectProvider)(nil)
+	_ Enricher = (*DirectProvider)(nil)
+)
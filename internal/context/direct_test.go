@@ -0,0 +1,120 @@
+package context_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	icontext "github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+)
+
+func newRecallStore(t *testing.T) storage.Storage {
+	t.Helper()
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	base := time.Date(2026, 1, 15, 12, 0, 0, 0, time.Local)
+	for i, e := range []entry.Entry{
+		{ID: "recall01", Content: "Kicked off the MCP work"},
+		{ID: "recall02", Content: "Groceries"},
+		{ID: "recall03", Content: "More mcp protocol notes"},
+	} {
+		e.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		e.UpdatedAt = e.CreatedAt
+		if err := store.Create(e); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+	}
+	return store
+}
+
+func TestDirectProvider_SearchAndFilter(t *testing.T) {
+	p := icontext.NewDirectProvider(newRecallStore(t))
+
+	results, err := p.Search(context.Background(), "mcp", 10)
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+	if len(results) != 2 || results[0].ID != "recall03" || results[1].ID != "recall01" {
+		t.Errorf("unexpected results %+v", results)
+	}
+
+	results, err = p.Filter(context.Background(), storage.ListOptions{Limit: 1})
+	if err != nil {
+		t.Fatalf("Filter: %v", err)
+	}
+	if len(results) != 1 || results[0].ID != "recall03" || results[0].Date != "2026-01-15" {
+		t.Errorf("unexpected results %+v", results)
+	}
+}
+
+func TestDirectProvider_Enrich(t *testing.T) {
+	store := newRecallStore(t)
+	if err := store.CreateContext(storage.Context{ID: "ctx00001", Name: "feature/auth", Source: "manual", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("CreateContext: %v", err)
+	}
+	content := "Worked on feature/auth today #security\nMood: tired but ok\n- [ ] write the migration\n- [x] review PR"
+
+	got, err := icontext.Enrich(context.Background(), icontext.NewDirectProvider(store), content)
+	if err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+	want := icontext.EnrichedContent{
+		Content:         content,
+		SuggestedTags:   []string{"feature/auth", "security"},
+		Mood:            "tired but ok",
+		FollowUpPrompts: []string{"Follow up on: write the migration"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Enrich = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	store := newRecallStore(t)
+	ts := httptest.NewServer(mcptools.NewHTTPHandler(mcptools.CreateMCPServer(store, ""), "tok"))
+	defer ts.Close()
+
+	for _, cfg := range []icontext.RecallConfig{
+		{},
+		{Provider: "mcp"},
+		{Provider: "external", URL: ts.URL, Token: "tok"},
+	} {
+		p, err := icontext.NewProvider(store, cfg)
+		if err != nil {
+			t.Fatalf("%q: NewProvider: %v", cfg.Provider, err)
+		}
+		results, err := p.Search(context.Background(), "groceries", 5)
+		if err != nil || len(results) != 1 || results[0].ID != "recall02" {
+			t.Errorf("%q: Search = %+v, %v", cfg.Provider, results, err)
+		}
+		// Only the direct provider enriches
+		enriched, err := icontext.Enrich(context.Background(), p, "- [ ] call back")
+		if err != nil {
+			t.Errorf("%q: Enrich: %v", cfg.Provider, err)
+		}
+		if _, direct := p.(*icontext.DirectProvider); direct != (len(enriched.FollowUpPrompts) == 1) {
+			t.Errorf("%q: unexpected enrichment %+v", cfg.Provider, enriched)
+		}
+		if c, ok := p.(io.Closer); ok {
+			c.Close()
+		}
+	}
+
+	if _, err := icontext.NewProvider(store, icontext.RecallConfig{Provider: "psychic"}); err == nil {
+		t.Error("expected error for unknown provider")
+	}
+	if _, err := icontext.NewProvider(store, icontext.RecallConfig{Provider: "external"}); err == nil {
+		t.Error("expected error for external provider without url or command")
+	}
+}
@@ -0,0 +1,196 @@
+package git
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/config"
+)
+
+// maxWorkspaceDepth bounds how deep the workspace scan looks for repositories.
+const maxWorkspaceDepth = 3
+
+// ActivityOptions configures the git activity content provider.
+type ActivityOptions struct {
+	Author    string    // git log --author pattern; empty = each repo's user.email
+	Workspace string    // root directory scanned for repos; empty = current repo only
+	Since     time.Time // lower bound for commits; zero = 24 hours ago
+}
+
+// ActivityProvider lists commits authored by the user, grouped by repository
+// with diffstat totals.
+type ActivityProvider struct {
+	opts ActivityOptions
+	dir  string           // working directory when no workspace is set; empty = current dir
+	now  func() time.Time // injectable for testing
+}
+
+// NewActivityProvider creates a git activity content provider.
+func NewActivityProvider(opts ActivityOptions) *ActivityProvider {
+	return &ActivityProvider{opts: opts, now: time.Now}
+}
+
+func (p *ActivityProvider) Name() string { return "git-activity" }
+
+// repoActivity holds the commits and diffstat totals for a single repository.
+type repoActivity struct {
+	dir        string
+	name       string
+	commits    []string
+	files      int
+	insertions int
+	deletions  int
+}
+
+func (p *ActivityProvider) Generate() (string, error) {
+	since := p.opts.Since
+	if since.IsZero() {
+		since = p.now().Add(-24 * time.Hour)
+	}
+
+	repos, err := p.repos()
+	if err != nil {
+		return "", err
+	}
+
+	var activity []repoActivity
+	for _, repo := range repos {
+		a := collectActivity(repo, p.opts.Author, since)
+		if len(a.commits) > 0 {
+			activity = append(activity, a)
+		}
+	}
+	if len(activity) == 0 {
+		return "", nil
+	}
+
+	// Workspace repos sharing a base name (work/api, oss/api) are told apart
+	// by their path under the workspace root
+	seen := map[string]int{}
+	for _, a := range activity {
+		seen[a.name]++
+	}
+	for i, a := range activity {
+		if seen[a.name] > 1 {
+			if rel, err := filepath.Rel(config.ExpandHome(p.opts.Workspace), a.dir); err == nil {
+				activity[i].name = filepath.ToSlash(rel)
+			}
+		}
+	}
+
+	sort.Slice(activity, func(i, j int) bool {
+		return activity[i].name < activity[j].name
+	})
+
+	var b strings.Builder
+	b.WriteString("## What I did\n")
+	for _, a := range activity {
+		label := "commits"
+		if len(a.commits) == 1 {
+			label = "commit"
+		}
+		fmt.Fprintf(&b, "\n### %s (%d %s, %d files, +%d -%d)\n",
+			a.name, len(a.commits), label, a.files, a.insertions, a.deletions)
+		for _, c := range a.commits {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+	}
+	return strings.TrimRight(b.String(), "\n"), nil
+}
+
+// repos returns the top-level directories of the repositories to inspect.
+func (p *ActivityProvider) repos() ([]string, error) {
+	if p.opts.Workspace == "" {
+		top := runGitCmd(p.dir, "rev-parse", "--show-toplevel")
+		if top == "" {
+			return nil, nil // not a git repo
+		}
+		return []string{top}, nil
+	}
+	return findRepos(config.ExpandHome(p.opts.Workspace))
+}
+
+// findRepos walks root looking for git repositories, without descending into them.
+func findRepos(root string) ([]string, error) {
+	var repos []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil // skip unreadable directories
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if path != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if _, statErr := os.Stat(filepath.Join(path, ".git")); statErr == nil {
+			repos = append(repos, path)
+			return filepath.SkipDir
+		}
+		rel, _ := filepath.Rel(root, path)
+		if rel != "." && strings.Count(rel, string(filepath.Separator))+1 >= maxWorkspaceDepth {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scanning workspace %s: %w", root, err)
+	}
+	return repos, nil
+}
+
+var shortstatPattern = regexp.MustCompile(`(\d+) (file|insertion|deletion)`)
+
+// commitMarker prefixes each commit line in git log output so it can be told
+// apart from --shortstat lines.
+const commitMarker = "\x1e"
+
+// collectActivity runs git log in repo and tallies commits and diffstats.
+func collectActivity(repo, author string, since time.Time) repoActivity {
+	a := repoActivity{dir: repo, name: filepath.Base(repo)}
+
+	if author == "" {
+		author = runGitCmd(repo, "config", "user.email")
+	}
+	args := []string{"log", "--no-merges", "--shortstat",
+		"--since=" + since.Format(time.RFC3339),
+		"--format=" + commitMarker + "%h %s"}
+	if author != "" {
+		args = append(args, "--author="+author)
+	}
+
+	out := runGitCmd(repo, args...)
+	if out == "" {
+		return a
+	}
+	for _, line := range strings.Split(out, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, commitMarker):
+			a.commits = append(a.commits, strings.TrimPrefix(line, commitMarker))
+		case line != "":
+			for _, m := range shortstatPattern.FindAllStringSubmatch(line, -1) {
+				n, _ := strconv.Atoi(m[1])
+				switch m[2] {
+				case "file":
+					a.files += n
+				case "insertion":
+					a.insertions += n
+				case "deletion":
+					a.deletions += n
+				}
+			}
+		}
+	}
+	return a
+}
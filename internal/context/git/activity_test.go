@@ -0,0 +1,159 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// commitFile writes a file in dir and commits it as the given author.
+func commitFile(t *testing.T, dir, name, content, email, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"add", name}, {"commit", "-m", message}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=someone",
+			"GIT_AUTHOR_EMAIL="+email,
+			"GIT_COMMITTER_NAME=someone",
+			"GIT_COMMITTER_EMAIL="+email,
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+}
+
+func TestActivityProvider_CurrentRepo(t *testing.T) {
+	dir := setupGitRepo(t)
+	commitFile(t, dir, "auth.go", "package auth\n\nfunc Login() {}\n", "test@test.com", "Add login handler")
+	commitFile(t, dir, "other.go", "package other\n", "other@test.com", "Someone else's work")
+
+	p := NewActivityProvider(ActivityOptions{
+		Author: "test@test.com",
+		Since:  time.Now().Add(-time.Hour),
+	})
+	p.dir = dir
+	out, err := p.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "## What I did") {
+		t.Errorf("expected heading, got %q", out)
+	}
+	if !strings.Contains(out, "Add login handler") || !strings.Contains(out, "initial commit") {
+		t.Errorf("expected own commits, got %q", out)
+	}
+	if strings.Contains(out, "Someone else's work") {
+		t.Errorf("expected other authors to be excluded, got %q", out)
+	}
+	if !strings.Contains(out, "2 commits, 2 files, +4 -0") {
+		t.Errorf("expected diffstat totals, got %q", out)
+	}
+}
+
+func TestActivityProvider_SinceExcludesOlder(t *testing.T) {
+	dir := setupGitRepo(t)
+	p := NewActivityProvider(ActivityOptions{
+		Author: "test@test.com",
+		Since:  time.Now().Add(time.Hour),
+	})
+	p.dir = dir
+	out, err := p.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "" {
+		t.Errorf("expected no activity, got %q", out)
+	}
+}
+
+func TestActivityProvider_Workspace(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"beta", "alpha"} {
+		dir := filepath.Join(root, "src", name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		cmd := exec.Command("git", "init", "-b", "main")
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git init: %v\n%s", err, out)
+		}
+		commitFile(t, dir, "README.md", "hello\n", "test@test.com", "Start "+name)
+	}
+
+	p := NewActivityProvider(ActivityOptions{
+		Author:    "test@test.com",
+		Workspace: root,
+		Since:     time.Now().Add(-time.Hour),
+	})
+	out, err := p.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	alpha := strings.Index(out, "### alpha (1 commit")
+	beta := strings.Index(out, "### beta (1 commit")
+	if alpha == -1 || beta == -1 {
+		t.Fatalf("expected both repos, got %q", out)
+	}
+	if alpha > beta {
+		t.Errorf("expected repos sorted by name, got %q", out)
+	}
+}
+
+func TestActivityProvider_WorkspaceSameBaseName(t *testing.T) {
+	root := t.TempDir()
+	for _, path := range []string{"work/api", "oss/api", "oss/cli"} {
+		dir := filepath.Join(root, path)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		cmd := exec.Command("git", "init", "-b", "main")
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git init: %v\n%s", err, out)
+		}
+		commitFile(t, dir, "README.md", "hello\n", "test@test.com", "Start "+path)
+	}
+
+	p := NewActivityProvider(ActivityOptions{
+		Author:    "test@test.com",
+		Workspace: root,
+		Since:     time.Now().Add(-time.Hour),
+	})
+	out, err := p.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, heading := range []string{"### oss/api (1 commit", "### work/api (1 commit", "### cli (1 commit"} {
+		if !strings.Contains(out, heading) {
+			t.Errorf("expected %q, got %q", heading, out)
+		}
+	}
+}
+
+func TestActivityProvider_NotARepo(t *testing.T) {
+	p := NewActivityProvider(ActivityOptions{})
+	p.dir = t.TempDir()
+	out, err := p.Generate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out != "" {
+		t.Errorf("expected empty string for non-repo, got %q", out)
+	}
+}
+
+func TestActivityProvider_Name(t *testing.T) {
+	p := NewActivityProvider(ActivityOptions{})
+	if p.Name() != "git-activity" {
+		t.Errorf("got name %q", p.Name())
+	}
+}
@@ -0,0 +1,169 @@
+package context
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+// maxLLMSuggestions caps the tags and follow-up prompts kept from a reply.
+const maxLLMSuggestions = 5
+
+const llmSystemPrompt = `You help tag diary entries. Reply with a single JSON object and nothing else:
+{"tags": ["..."], "mood": "...", "follow_ups": ["..."]}
+tags: up to 5 short lowercase topic names (letters, digits, "-", "_" or "/"), preferring the known contexts listed by the user when they fit.
+mood: one or two words describing the writer's mood, or "" if unclear.
+follow_ups: up to 3 short questions or reminders worth following up on.`
+
+// LLMOptions configures an OpenAI-compatible chat completions endpoint.
+type LLMOptions struct {
+	URL     string        // API base URL, e.g. http://localhost:8080/v1
+	Model   string        // model name sent with each request
+	APIKey  string        // bearer token (empty = none)
+	Timeout time.Duration // per-request timeout (0 = 30s)
+	// KnownContexts lists existing context names the model should prefer.
+	KnownContexts []string
+}
+
+// LLMEnricher implements Enricher by asking a language model served behind
+// an OpenAI-compatible API, such as a local llama.cpp or Ollama server.
+type LLMEnricher struct {
+	opts   LLMOptions
+	client *http.Client
+}
+
+// NewLLMEnricher creates an enricher for the endpoint in opts.
+func NewLLMEnricher(opts LLMOptions) (*LLMEnricher, error) {
+	if opts.URL == "" {
+		return nil, fmt.Errorf("enrichment needs an endpoint url")
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = 30 * time.Second
+	}
+	return &LLMEnricher{opts: opts, client: &http.Client{Timeout: opts.Timeout}}, nil
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatRequest struct {
+	Model          string            `json:"model,omitempty"`
+	Messages       []chatMessage     `json:"messages"`
+	Temperature    float64           `json:"temperature"`
+	ResponseFormat map[string]string `json:"response_format"`
+}
+
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+}
+
+// llmSuggestions is the JSON object the model is asked to reply with.
+type llmSuggestions struct {
+	Tags      []string `json:"tags"`
+	Mood      string   `json:"mood"`
+	FollowUps []string `json:"follow_ups"`
+}
+
+// Enrich implements Enricher.
+func (l *LLMEnricher) Enrich(ctx context.Context, content string) (EnrichedContent, error) {
+	user := content
+	if len(l.opts.KnownContexts) > 0 {
+		user = "Known contexts: " + strings.Join(l.opts.KnownContexts, ", ") + "\n\nEntry:\n" + content
+	}
+	body, err := json.Marshal(chatRequest{
+		Model: l.opts.Model,
+		Messages: []chatMessage{
+			{Role: "system", Content: llmSystemPrompt},
+			{Role: "user", Content: user},
+		},
+		Temperature:    0.2,
+		ResponseFormat: map[string]string{"type": "json_object"},
+	})
+	if err != nil {
+		return EnrichedContent{}, err
+	}
+
+	endpoint := strings.TrimSuffix(l.opts.URL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return EnrichedContent{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if l.opts.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+l.opts.APIKey)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return EnrichedContent{}, fmt.Errorf("enrichment request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return EnrichedContent{}, fmt.Errorf("enrichment request: %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var chat chatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chat); err != nil {
+		return EnrichedContent{}, fmt.Errorf("decoding enrichment response: %w", err)
+	}
+	if len(chat.Choices) == 0 {
+		return EnrichedContent{}, fmt.Errorf("enrichment response has no choices")
+	}
+	var s llmSuggestions
+	if err := json.Unmarshal([]byte(stripCodeFence(chat.Choices[0].Message.Content)), &s); err != nil {
+		return EnrichedContent{}, fmt.Errorf("decoding enrichment suggestions: %w", err)
+	}
+
+	out := EnrichedContent{Content: content, SuggestedTags: []string{}, FollowUpPrompts: []string{}}
+	for _, t := range s.Tags {
+		t = normalizeTag(t)
+		if t == "" || entry.ValidateContextName(t) != nil || slices.Contains(out.SuggestedTags, t) {
+			continue
+		}
+		out.SuggestedTags = append(out.SuggestedTags, t)
+		if len(out.SuggestedTags) == maxLLMSuggestions {
+			break
+		}
+	}
+	out.Mood = strings.TrimSpace(s.Mood)
+	for _, f := range s.FollowUps {
+		if f = strings.TrimSpace(f); f != "" && len(out.FollowUpPrompts) < maxLLMSuggestions {
+			out.FollowUpPrompts = append(out.FollowUpPrompts, f)
+		}
+	}
+	return out, nil
+}
+
+// stripCodeFence removes a markdown code fence some models wrap JSON in.
+func stripCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") {
+		return s
+	}
+	s = strings.TrimPrefix(s, "```")
+	s = strings.TrimPrefix(s, "json")
+	return strings.TrimSpace(strings.TrimSuffix(s, "```"))
+}
+
+// normalizeTag turns a suggested tag into a context name: no leading "#",
+// lowercase, spaces as hyphens.
+func normalizeTag(t string) string {
+	t = strings.TrimPrefix(strings.TrimSpace(t), "#")
+	return strings.ToLower(strings.Join(strings.Fields(t), "-"))
+}
+
+// Ensure LLMEnricher implements Enricher.
+var _ Enricher = (*LLMEnricher)(nil)
@@ -0,0 +1,101 @@
+package context_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	icontext "github.com/chris-regnier/diaryctl/internal/context"
+)
+
+// newLLMServer stands in for an OpenAI-compatible endpoint that replies with
+// reply as the assistant message and records the last request body.
+func newLLMServer(t *testing.T, reply string, got *map[string]any) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer sk-test" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if got != nil {
+			_ = json.NewDecoder(r.Body).Decode(got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": reply}}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestLLMEnricher_Enrich(t *testing.T) {
+	var req map[string]any
+	reply := "```json\n" + `{"tags": ["#Database", "feature/auth", "database", "not valid!"], "mood": " focused ", "follow_ups": ["Write the rollback plan", ""]}` + "\n```"
+	srv := newLLMServer(t, reply, &req)
+
+	enricher, err := icontext.NewLLMEnricher(icontext.LLMOptions{
+		URL: srv.URL + "/v1/", Model: "local", APIKey: "sk-test", KnownContexts: []string{"feature/auth"},
+	})
+	if err != nil {
+		t.Fatalf("NewLLMEnricher: %v", err)
+	}
+	got, err := enricher.Enrich(context.Background(), "Planned the database migration")
+	if err != nil {
+		t.Fatalf("Enrich: %v", err)
+	}
+
+	want := icontext.EnrichedContent{
+		Content:         "Planned the database migration",
+		SuggestedTags:   []string{"database", "feature/auth"},
+		Mood:            "focused",
+		FollowUpPrompts: []string{"Write the rollback plan"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Enrich() = %+v, want %+v", got, want)
+	}
+	if req["model"] != "local" {
+		t.Errorf("model = %v, want local", req["model"])
+	}
+	msgs, _ := req["messages"].([]any)
+	if len(msgs) != 2 || !strings.Contains(msgs[1].(map[string]any)["content"].(string), "Known contexts: feature/auth") {
+		t.Errorf("unexpected messages %v", msgs)
+	}
+}
+
+func TestLLMEnricher_Errors(t *testing.T) {
+	if _, err := icontext.NewLLMEnricher(icontext.LLMOptions{}); err == nil {
+		t.Error("expected error without a url")
+	}
+
+	srv := newLLMServer(t, "not json", nil)
+	tests := []struct {
+		name string
+		opts icontext.LLMOptions
+		want string
+	}{
+		{"unauthorized", icontext.LLMOptions{URL: srv.URL + "/v1"}, "401"},
+		{"not found", icontext.LLMOptions{URL: srv.URL, APIKey: "sk-test"}, "404"},
+		{"bad reply", icontext.LLMOptions{URL: srv.URL + "/v1", APIKey: "sk-test"}, "decoding enrichment suggestions"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enricher, err := icontext.NewLLMEnricher(tt.opts)
+			if err != nil {
+				t.Fatalf("NewLLMEnricher: %v", err)
+			}
+			_, err = enricher.Enrich(context.Background(), "hello")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Enrich() error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
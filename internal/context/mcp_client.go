@@ -29,6 +29,11 @@ func NewMCPClient(transport mcp.Transport) (*MCPClient, error) {
 	return &MCPClient{session: session}, nil
 }
 
+// Close ends the client session.
+func (c *MCPClient) Close() error {
+	return c.session.Close()
+}
+
 // CallTool invokes a tool by name with the given arguments.
 func (c *MCPClient) CallTool(ctx context.Context, name string, args any) (any, error) {
 	result, err := c.session.CallTool(ctx, &mcp.CallToolParams{
@@ -0,0 +1,184 @@
+package context
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+const pendingSuggestionsFile = "pending-suggestions.json"
+
+// PendingSuggestion holds enrichment suggestions for an entry that the user
+// has not accepted or dismissed yet.
+type PendingSuggestion struct {
+	EntryID   string    `json:"entry_id"`
+	Tags      []string  `json:"tags,omitempty"`     // new context names
+	Contexts  []string  `json:"contexts,omitempty"` // existing context names
+	Mood      string    `json:"mood,omitempty"`
+	FollowUps []string  `json:"follow_ups,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Names returns the pending context names, existing ones first.
+func (p PendingSuggestion) Names() []string {
+	return append(slices.Clone(p.Contexts), p.Tags...)
+}
+
+// PendingStore is the subset of storage.Storage needed to accept suggestions.
+type PendingStore interface {
+	ContextStore
+	ListContexts() ([]storage.Context, error)
+	AttachContext(entryID string, contextID string) error
+}
+
+// NewPendingSuggestion sorts the suggested tags for e into existing contexts
+// and new tags, leaving out contexts already attached to e.
+func NewPendingSuggestion(store PendingStore, e entry.Entry, enriched EnrichedContent) (PendingSuggestion, error) {
+	existing, err := store.ListContexts()
+	if err != nil {
+		return PendingSuggestion{}, err
+	}
+	attached := map[string]bool{}
+	for _, ref := range e.Contexts {
+		attached[strings.ToLower(ref.ContextName)] = true
+	}
+
+	p := PendingSuggestion{EntryID: e.ID, Mood: enriched.Mood, FollowUps: enriched.FollowUpPrompts, CreatedAt: now().UTC()}
+	for _, tag := range enriched.SuggestedTags {
+		if attached[strings.ToLower(tag)] {
+			continue
+		}
+		i := slices.IndexFunc(existing, func(c storage.Context) bool { return strings.EqualFold(c.Name, tag) })
+		switch {
+		case i >= 0 && !slices.Contains(p.Contexts, existing[i].Name):
+			p.Contexts = append(p.Contexts, existing[i].Name)
+		case i < 0 && !slices.Contains(p.Tags, tag):
+			p.Tags = append(p.Tags, tag)
+		}
+	}
+	return p, nil
+}
+
+// LoadPendingSuggestions reads all pending suggestions from the state file.
+func LoadPendingSuggestions(dataDir string) ([]PendingSuggestion, error) {
+	data, err := os.ReadFile(filepath.Join(dataDir, pendingSuggestionsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var pending []PendingSuggestion
+	if err := json.Unmarshal(data, &pending); err != nil {
+		return nil, err
+	}
+	return pending, nil
+}
+
+// PendingFor returns the pending suggestion for an entry, if any.
+func PendingFor(dataDir string, entryID string) (PendingSuggestion, bool, error) {
+	pending, err := LoadPendingSuggestions(dataDir)
+	if err != nil {
+		return PendingSuggestion{}, false, err
+	}
+	for _, p := range pending {
+		if p.EntryID == entryID {
+			return p, true, nil
+		}
+	}
+	return PendingSuggestion{}, false, nil
+}
+
+// SavePendingSuggestion stores p, merging its tags and contexts into any
+// suggestion already pending for the same entry. Mood and follow-ups are
+// replaced. Suggestions with nothing to accept or read are not stored.
+func SavePendingSuggestion(dataDir string, p PendingSuggestion) error {
+	pending, err := LoadPendingSuggestions(dataDir)
+	if err != nil {
+		return err
+	}
+	i := slices.IndexFunc(pending, func(q PendingSuggestion) bool { return q.EntryID == p.EntryID })
+	if i >= 0 {
+		old := pending[i]
+		for _, t := range p.Tags {
+			if !slices.Contains(old.Tags, t) {
+				old.Tags = append(old.Tags, t)
+			}
+		}
+		for _, c := range p.Contexts {
+			if !slices.Contains(old.Contexts, c) {
+				old.Contexts = append(old.Contexts, c)
+			}
+		}
+		old.Mood = p.Mood
+		old.FollowUps = p.FollowUps
+		pending[i] = old
+	} else {
+		if len(p.Tags) == 0 && len(p.Contexts) == 0 && p.Mood == "" && len(p.FollowUps) == 0 {
+			return nil
+		}
+		pending = append(pending, p)
+	}
+	return writePendingSuggestions(dataDir, pending)
+}
+
+// DismissPendingSuggestion drops the suggestion pending for an entry.
+func DismissPendingSuggestion(dataDir string, entryID string) error {
+	pending, err := LoadPendingSuggestions(dataDir)
+	if err != nil {
+		return err
+	}
+	filtered := slices.DeleteFunc(pending, func(p PendingSuggestion) bool { return p.EntryID == entryID })
+	return writePendingSuggestions(dataDir, filtered)
+}
+
+// AcceptPendingSuggestion attaches the pending tags and contexts to the
+// entry, creating contexts that don't exist yet, and clears the suggestion.
+// Returns the attached context names.
+func AcceptPendingSuggestion(dataDir string, store PendingStore, entryID string) ([]string, error) {
+	p, ok, err := PendingFor(dataDir, entryID)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var attached []string
+	for _, name := range p.Names() {
+		c, err := store.GetContextByName(name)
+		if errors.Is(err, storage.ErrNotFound) {
+			id, idErr := entry.NewID()
+			if idErr != nil {
+				return attached, idErr
+			}
+			t := now().UTC()
+			c = storage.Context{ID: id, Name: name, Source: "enrich", CreatedAt: t, UpdatedAt: t}
+			if err := store.CreateContext(c); err != nil {
+				return attached, fmt.Errorf("creating context %q: %w", name, err)
+			}
+		} else if err != nil {
+			return attached, fmt.Errorf("looking up context %q: %w", name, err)
+		}
+		if err := store.AttachContext(entryID, c.ID); err != nil {
+			return attached, fmt.Errorf("attaching context %q: %w", name, err)
+		}
+		attached = append(attached, name)
+	}
+	return attached, DismissPendingSuggestion(dataDir, entryID)
+}
+
+func writePendingSuggestions(dataDir string, pending []PendingSuggestion) error {
+	if pending == nil {
+		pending = []PendingSuggestion{}
+	}
+	data, err := json.MarshalIndent(pending, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dataDir, pendingSuggestionsFile), data, 0644)
+}
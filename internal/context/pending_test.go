@@ -0,0 +1,113 @@
+package context_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	icontext "github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func TestPendingSuggestions(t *testing.T) {
+	store := newRecallStore(t)
+	dir := t.TempDir()
+	now := time.Now().UTC()
+	for _, c := range []storage.Context{
+		{ID: "ctx00001", Name: "feature/auth", Source: "manual", CreatedAt: now, UpdatedAt: now},
+		{ID: "ctx00002", Name: "mcp", Source: "manual", CreatedAt: now, UpdatedAt: now},
+	} {
+		if err := store.CreateContext(c); err != nil {
+			t.Fatalf("CreateContext: %v", err)
+		}
+	}
+	if err := store.AttachContext("recall01", "ctx00002"); err != nil {
+		t.Fatalf("AttachContext: %v", err)
+	}
+	e, err := store.Get("recall01")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	p, err := icontext.NewPendingSuggestion(store, e, icontext.EnrichedContent{
+		SuggestedTags: []string{"MCP", "Feature/Auth", "protocols"},
+		Mood:          "curious",
+	})
+	if err != nil {
+		t.Fatalf("NewPendingSuggestion: %v", err)
+	}
+	if !reflect.DeepEqual(p.Contexts, []string{"feature/auth"}) || !reflect.DeepEqual(p.Tags, []string{"protocols"}) {
+		t.Fatalf("contexts = %v, tags = %v", p.Contexts, p.Tags)
+	}
+
+	if err := icontext.SavePendingSuggestion(dir, p); err != nil {
+		t.Fatalf("SavePendingSuggestion: %v", err)
+	}
+	if err := icontext.SavePendingSuggestion(dir, icontext.PendingSuggestion{EntryID: "recall01", Tags: []string{"protocols", "specs"}}); err != nil {
+		t.Fatalf("SavePendingSuggestion: %v", err)
+	}
+	if err := icontext.SavePendingSuggestion(dir, icontext.PendingSuggestion{EntryID: "recall02"}); err != nil {
+		t.Fatalf("SavePendingSuggestion: %v", err)
+	}
+	all, err := icontext.LoadPendingSuggestions(dir)
+	if err != nil {
+		t.Fatalf("LoadPendingSuggestions: %v", err)
+	}
+	if len(all) != 1 || !reflect.DeepEqual(all[0].Names(), []string{"feature/auth", "protocols", "specs"}) {
+		t.Fatalf("pending = %+v", all)
+	}
+
+	attached, err := icontext.AcceptPendingSuggestion(dir, store, "recall01")
+	if err != nil {
+		t.Fatalf("AcceptPendingSuggestion: %v", err)
+	}
+	if len(attached) != 3 {
+		t.Errorf("attached = %v", attached)
+	}
+	e, _ = store.Get("recall01")
+	if len(e.Contexts) != 4 {
+		t.Errorf("entry contexts = %+v, want 4", e.Contexts)
+	}
+	if c, err := store.GetContextByName("specs"); err != nil || c.Source != "enrich" {
+		t.Errorf("specs context = %+v, %v", c, err)
+	}
+	if _, ok, _ := icontext.PendingFor(dir, "recall01"); ok {
+		t.Error("accepted suggestion should no longer be pending")
+	}
+
+	_ = icontext.SavePendingSuggestion(dir, icontext.PendingSuggestion{EntryID: "recall03", Tags: []string{"notes"}})
+	if err := icontext.DismissPendingSuggestion(dir, "recall03"); err != nil {
+		t.Fatalf("DismissPendingSuggestion: %v", err)
+	}
+	if _, ok, _ := icontext.PendingFor(dir, "recall03"); ok {
+		t.Error("dismissed suggestion should no longer be pending")
+	}
+}
+
+// failingLookupStore fails every context lookup with a storage error.
+type failingLookupStore struct {
+	storage.Storage
+}
+
+func (failingLookupStore) GetContextByName(string) (storage.Context, error) {
+	return storage.Context{}, errors.New("database is locked")
+}
+
+func TestAcceptPendingSuggestion_LookupError(t *testing.T) {
+	store := newRecallStore(t)
+	dir := t.TempDir()
+	if err := icontext.SavePendingSuggestion(dir, icontext.PendingSuggestion{EntryID: "recall01", Tags: []string{"specs"}}); err != nil {
+		t.Fatalf("SavePendingSuggestion: %v", err)
+	}
+
+	if _, err := icontext.AcceptPendingSuggestion(dir, failingLookupStore{store}, "recall01"); err == nil {
+		t.Fatal("expected the lookup error to be returned")
+	}
+	if _, err := store.GetContextByName("specs"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("no context should be created on a lookup error, got %v", err)
+	}
+	if _, ok, _ := icontext.PendingFor(dir, "recall01"); !ok {
+		t.Error("suggestion should stay pending after a failed accept")
+	}
+}
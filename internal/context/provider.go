@@ -2,6 +2,8 @@ package context
 
 import (
 	"context"
+	"fmt"
+	"sort"
 
 	"github.com/chris-regnier/diaryctl/internal/storage"
 )
@@ -30,3 +32,68 @@ type Provider interface {
 	// Filter retrieves entries matching date/template criteria.
 	Filter(ctx context.Context, opts storage.ListOptions) ([]EntryResult, error)
 }
+
+// Enricher is implemented by providers that can suggest tags, a mood and
+// follow-up prompts for a piece of content.
+type Enricher interface {
+	Enrich(ctx context.Context, content string) (EnrichedContent, error)
+}
+
+// Enrich enriches content with p when it supports enrichment. Otherwise it
+// returns the content with no suggestions.
+func Enrich(ctx context.Context, p Provider, content string) (EnrichedContent, error) {
+	if e, ok := p.(Enricher); ok {
+		return e.Enrich(ctx, content)
+	}
+	return EnrichedContent{Content: content}, nil
+}
+
+// RecallConfig selects and configures the Provider used to recall entries.
+type RecallConfig struct {
+	Provider string   // "direct" (default), "mcp" or "external"
+	URL      string   // external: streamable HTTP endpoint
+	Token    string   // external: bearer token for URL
+	Command  []string // external: stdio server command, used when URL is empty
+}
+
+var recallProviders = map[string]func(storage.Storage, RecallConfig) (Provider, error){
+	"direct": func(store storage.Storage, _ RecallConfig) (Provider, error) { return NewDirectProvider(store), nil },
+	"mcp": func(store storage.Storage, _ RecallConfig) (Provider, error) {
+		p, err := NewCompositeProvider(store)
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	},
+	"external": func(_ storage.Storage, cfg RecallConfig) (Provider, error) {
+		p, err := NewExternalProvider(ExternalOptions{URL: cfg.URL, Token: cfg.Token, Command: cfg.Command})
+		if err != nil {
+			return nil, err
+		}
+		return p, nil
+	},
+}
+
+// NewProvider creates the provider named by cfg.Provider. Providers that hold
+// connections implement io.Closer.
+func NewProvider(store storage.Storage, cfg RecallConfig) (Provider, error) {
+	name := cfg.Provider
+	if name == "" {
+		name = "direct"
+	}
+	factory, ok := recallProviders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown recall provider %q (available: %v)", name, ProviderNames())
+	}
+	return factory(store, cfg)
+}
+
+// ProviderNames returns the registered recall provider names, sorted.
+func ProviderNames() []string {
+	names := make([]string, 0, len(recallProviders))
+	for name := range recallProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -4,12 +4,52 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 const activeContextsFile = "active-contexts.json"
 
+// now is the clock used for expiry checks; replaced in tests.
+var now = time.Now
+
+// ManualContext is a manually activated context, optionally bounded by an expiry time.
+type ManualContext struct {
+	Name      string     `json:"name"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Expired reports whether the context has an expiry at or before t.
+func (c ManualContext) Expired(t time.Time) bool {
+	return c.ExpiresAt != nil && !c.ExpiresAt.After(t)
+}
+
+// Remaining returns the time left before the context expires at t.
+// Returns 0 for contexts without an expiry.
+func (c ManualContext) Remaining(t time.Time) time.Duration {
+	if c.ExpiresAt == nil {
+		return 0
+	}
+	return c.ExpiresAt.Sub(t)
+}
+
 // LoadManualContexts reads the active manual context names from the state file.
+// Expired contexts are dropped.
 func LoadManualContexts(dataDir string) ([]string, error) {
+	contexts, err := LoadActiveManualContexts(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, c := range contexts {
+		names = append(names, c.Name)
+	}
+	return names, nil
+}
+
+// LoadActiveManualContexts reads the manual contexts with their expiry times
+// from the state file. Expired contexts are dropped.
+// The legacy format (a plain JSON array of names) is read as contexts without expiry.
+func LoadActiveManualContexts(dataDir string) ([]ManualContext, error) {
 	path := filepath.Join(dataDir, activeContextsFile)
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -18,48 +58,77 @@ func LoadManualContexts(dataDir string) ([]string, error) {
 		}
 		return nil, err
 	}
-	var names []string
-	if err := json.Unmarshal(data, &names); err != nil {
-		return nil, err
+
+	var contexts []ManualContext
+	if err := json.Unmarshal(data, &contexts); err != nil {
+		var names []string
+		if legacyErr := json.Unmarshal(data, &names); legacyErr != nil {
+			return nil, err
+		}
+		contexts = make([]ManualContext, len(names))
+		for i, n := range names {
+			contexts[i] = ManualContext{Name: n}
+		}
 	}
-	return names, nil
+
+	t := now()
+	var active []ManualContext
+	for _, c := range contexts {
+		if !c.Expired(t) {
+			active = append(active, c)
+		}
+	}
+	return active, nil
 }
 
 // SetManualContext adds a context name to the active list (idempotent).
+// Re-setting a time-boxed context makes it permanent.
 func SetManualContext(dataDir string, name string) error {
-	names, err := LoadManualContexts(dataDir)
+	return setManualContext(dataDir, ManualContext{Name: name})
+}
+
+// SetManualContextUntil adds a context name to the active list that expires at expiresAt.
+// Re-setting an active context replaces its expiry.
+func SetManualContextUntil(dataDir string, name string, expiresAt time.Time) error {
+	expiresAt = expiresAt.UTC()
+	return setManualContext(dataDir, ManualContext{Name: name, ExpiresAt: &expiresAt})
+}
+
+func setManualContext(dataDir string, mc ManualContext) error {
+	contexts, err := LoadActiveManualContexts(dataDir)
 	if err != nil {
 		return err
 	}
-	for _, n := range names {
-		if n == name {
-			return nil // already set
+	for i, c := range contexts {
+		if c.Name == mc.Name {
+			contexts[i] = mc
+			return writeManualContexts(dataDir, contexts)
 		}
 	}
-	names = append(names, name)
-	return writeManualContexts(dataDir, names)
+	contexts = append(contexts, mc)
+	return writeManualContexts(dataDir, contexts)
 }
 
 // UnsetManualContext removes a context name from the active list.
 func UnsetManualContext(dataDir string, name string) error {
-	names, err := LoadManualContexts(dataDir)
+	contexts, err := LoadActiveManualContexts(dataDir)
 	if err != nil {
 		return err
 	}
-	var filtered []string
-	for _, n := range names {
-		if n != name {
-			filtered = append(filtered, n)
+	var filtered []ManualContext
+	for _, c := range contexts {
+		if c.Name != name {
+			filtered = append(filtered, c)
 		}
 	}
 	return writeManualContexts(dataDir, filtered)
 }
 
-func writeManualContexts(dataDir string, names []string) error {
-	if names == nil {
-		names = []string{}
+func writeManualContexts(dataDir string, contexts []ManualContext) error {
+	if contexts == nil {
+		contexts = []ManualContext{}
 	}
-	data, err := json.MarshalIndent(names, "", "  ")
+	data, err := json.MarshalIndent(contexts, "", "  ")
 	if err != nil {
 		return err
 	}
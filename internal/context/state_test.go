@@ -1,6 +1,11 @@
 package context
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
 
 func TestLoadManualContexts_noFile(t *testing.T) {
 	dir := t.TempDir()
@@ -60,3 +65,71 @@ func TestUnsetManualContext_notSet(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestSetManualContextUntil_expiresOnLoad(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	now = func() time.Time { return base }
+	t.Cleanup(func() { now = time.Now })
+
+	if err := SetManualContextUntil(dir, "incident:123", base.Add(4*time.Hour)); err != nil {
+		t.Fatalf("SetManualContextUntil: %v", err)
+	}
+	_ = SetManualContext(dir, "sprint:23")
+
+	names, _ := LoadManualContexts(dir)
+	if len(names) != 2 {
+		t.Fatalf("expected 2 before expiry, got %v", names)
+	}
+
+	now = func() time.Time { return base.Add(4 * time.Hour) }
+	names, _ = LoadManualContexts(dir)
+	if len(names) != 1 || names[0] != "sprint:23" {
+		t.Errorf("expected [sprint:23] after expiry, got %v", names)
+	}
+}
+
+func TestLoadActiveManualContexts_remaining(t *testing.T) {
+	dir := t.TempDir()
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	now = func() time.Time { return base }
+	t.Cleanup(func() { now = time.Now })
+
+	_ = SetManualContextUntil(dir, "incident:123", base.Add(90*time.Minute))
+	contexts, err := LoadActiveManualContexts(dir)
+	if err != nil {
+		t.Fatalf("LoadActiveManualContexts: %v", err)
+	}
+	if len(contexts) != 1 {
+		t.Fatalf("expected 1, got %d", len(contexts))
+	}
+	if got := contexts[0].Remaining(base); got != 90*time.Minute {
+		t.Errorf("remaining = %v, want 90m", got)
+	}
+}
+
+func TestSetManualContext_clearsExpiry(t *testing.T) {
+	dir := t.TempDir()
+	_ = SetManualContextUntil(dir, "sprint:23", time.Now().Add(time.Hour))
+	_ = SetManualContext(dir, "sprint:23")
+
+	contexts, _ := LoadActiveManualContexts(dir)
+	if len(contexts) != 1 || contexts[0].ExpiresAt != nil {
+		t.Errorf("expected permanent context, got %+v", contexts)
+	}
+}
+
+func TestLoadManualContexts_legacyFormat(t *testing.T) {
+	dir := t.TempDir()
+	legacy := []byte(`["sprint:23", "project:auth"]`)
+	if err := os.WriteFile(filepath.Join(dir, activeContextsFile), legacy, 0644); err != nil {
+		t.Fatal(err)
+	}
+	names, err := LoadManualContexts(dir)
+	if err != nil {
+		t.Fatalf("LoadManualContexts: %v", err)
+	}
+	if len(names) != 2 {
+		t.Errorf("expected 2 legacy names, got %v", names)
+	}
+}
@@ -3,8 +3,10 @@ package daily
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/chris-regnier/diaryctl/internal/template"
@@ -16,8 +18,28 @@ import (
 // with default content is created.
 // Returns the entry, whether it was newly created, and any error.
 func GetOrCreateToday(store storage.Storage, defaultTemplate string) (entry.Entry, bool, error) {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	return GetOrCreateTodayWith(store, defaultTemplate, template.RenderOptions{})
+}
+
+// GetOrCreateTodayWith is like GetOrCreateToday but renders the default template
+// with opts. If opts.Yesterday is nil, yesterday's entries are read from store.
+// Render errors are reported as warnings and the unrendered template is used.
+func GetOrCreateTodayWith(store storage.Storage, defaultTemplate string, opts template.RenderOptions) (entry.Entry, bool, error) {
+	return GetOrCreateTodayFunc(store, func(time.Time) string { return defaultTemplate }, opts)
+}
+
+// GetOrCreateTodayFunc is like GetOrCreateTodayWith but chooses the default
+// template with selectTemplate, which is only called when today's entry has to
+// be created (e.g. to evaluate template rules against the current time).
+func GetOrCreateTodayFunc(store storage.Storage, selectTemplate func(now time.Time) string, opts template.RenderOptions) (entry.Entry, bool, error) {
+	return GetOrCreateOnFunc(store, time.Now(), selectTemplate, opts)
+}
+
+// GetOrCreateOnFunc is like GetOrCreateTodayFunc but finds or creates the
+// entry for the day containing now, which may be in the past. A new entry is
+// created at now so that it falls on that day.
+func GetOrCreateOnFunc(store storage.Storage, now time.Time, selectTemplate func(now time.Time) string, opts template.RenderOptions) (entry.Entry, bool, error) {
+	today := day.Of(now)
 
 	// Try to find today's entry
 	entries, err := store.List(storage.ListOptions{
@@ -32,10 +54,10 @@ func GetOrCreateToday(store storage.Storage, defaultTemplate string) (entry.Entr
 	}
 
 	// No entry for today — create one
-	content := fmt.Sprintf("# %s", now.Format("2006-01-02"))
+	content := fmt.Sprintf("# %s", today.Format("2006-01-02"))
 	var refs []entry.TemplateRef
 
-	if defaultTemplate != "" {
+	if defaultTemplate := selectTemplate(now); defaultTemplate != "" {
 		names := template.ParseNames(defaultTemplate)
 		c, r, err := template.Compose(store, names)
 		if err != nil {
@@ -44,6 +66,21 @@ func GetOrCreateToday(store storage.Storage, defaultTemplate string) (entry.Entr
 		} else {
 			content = c
 			refs = r
+
+			if opts.Now.IsZero() {
+				opts.Now = now
+			}
+			if opts.Yesterday == nil {
+				opts.Yesterday = func() string {
+					return ContentOn(store, today.AddDate(0, 0, -1))
+				}
+			}
+			rendered, err := template.RenderWith(content, opts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: rendering default template %q: %v\n", defaultTemplate, err)
+			} else {
+				content = rendered
+			}
 		}
 	}
 
@@ -65,3 +102,52 @@ func GetOrCreateToday(store storage.Storage, defaultTemplate string) (entry.Entr
 	}
 	return e, true, nil
 }
+
+// Jot appends a timestamped line ("- **15:04** content") to today's entry,
+// creating the entry first if needed as GetOrCreateTodayFunc does. Returns the
+// updated entry and the jotted line.
+func Jot(store storage.Storage, content string, selectTemplate func(now time.Time) string, opts template.RenderOptions) (entry.Entry, string, error) {
+	return JotAt(store, content, time.Now(), selectTemplate, opts)
+}
+
+// JotAt is like Jot for a note made at at: the line is stamped with at and
+// appended to the entry for the day containing it.
+func JotAt(store storage.Storage, content string, at time.Time, selectTemplate func(now time.Time) string, opts template.RenderOptions) (entry.Entry, string, error) {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return entry.Entry{}, "", fmt.Errorf("jot: empty content")
+	}
+
+	e, _, err := GetOrCreateOnFunc(store, at, selectTemplate, opts)
+	if err != nil {
+		return entry.Entry{}, "", fmt.Errorf("getting the day's entry: %w", err)
+	}
+
+	jotLine := fmt.Sprintf("- **%s** %s", at.Local().Format("15:04"), content)
+
+	newContent := jotLine
+	if strings.TrimSpace(e.Content) != "" {
+		newContent = e.Content + "\n" + jotLine
+	}
+
+	updated, err := store.Update(e.ID, newContent, nil)
+	if err != nil {
+		return entry.Entry{}, "", fmt.Errorf("updating entry: %w", err)
+	}
+	return updated, jotLine, nil
+}
+
+// ContentOn returns the content of the entries created on date, oldest first,
+// separated by blank lines. Returns "" if there are none or they cannot be read.
+func ContentOn(store storage.Storage, date time.Time) string {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	entries, err := store.List(storage.ListOptions{Date: &day})
+	if err != nil {
+		return ""
+	}
+	parts := make([]string, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		parts = append(parts, entries[i].Content)
+	}
+	return strings.Join(parts, "\n\n")
+}
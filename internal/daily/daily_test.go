@@ -6,9 +6,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+	"github.com/chris-regnier/diaryctl/internal/template"
 )
 
 func testStore(t *testing.T) storage.Storage {
@@ -110,6 +112,87 @@ func TestGetOrCreateToday_WithDefaultTemplate(t *testing.T) {
 	}
 }
 
+func TestGetOrCreateTodayWith_RendersTemplate(t *testing.T) {
+	s := testStore(t)
+	now := time.Now()
+
+	// Yesterday's entry with an open task to carry over
+	yID, _ := entry.NewID()
+	y := now.AddDate(0, 0, -1).UTC()
+	if err := s.Create(entry.Entry{ID: yID, Content: "- [ ] finish report\n- [x] email", CreatedAt: y, UpdatedAt: y}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	tmplID, _ := entry.NewID()
+	tmpl := storage.Template{
+		ID:        tmplID,
+		Name:      "daily",
+		Content:   "# Week {{isoWeek today}}\n{{join \",\" contexts}}\n{{openTasks yesterday}}",
+		CreatedAt: now.UTC(),
+		UpdatedAt: now.UTC(),
+	}
+	if err := s.CreateTemplate(tmpl); err != nil {
+		t.Fatalf("CreateTemplate: %v", err)
+	}
+
+	e, _, err := GetOrCreateTodayWith(s, "daily", template.RenderOptions{
+		Contexts: func() []string { return []string{"ops"} },
+	})
+	if err != nil {
+		t.Fatalf("GetOrCreateTodayWith: %v", err)
+	}
+	_, week := now.ISOWeek()
+	want := fmt.Sprintf("# Week %d\nops\n- [ ] finish report", week)
+	if e.Content != want {
+		t.Errorf("got %q, want %q", e.Content, want)
+	}
+}
+
+func TestContentOn(t *testing.T) {
+	s := testStore(t)
+	day := time.Date(2026, 3, 2, 0, 0, 0, 0, time.Local)
+	for i, c := range []string{"first", "second"} {
+		id, _ := entry.NewID()
+		at := day.Add(time.Duration(9+i) * time.Hour).UTC()
+		if err := s.Create(entry.Entry{ID: id, Content: c, CreatedAt: at, UpdatedAt: at}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+	if got := ContentOn(s, day.Add(15*time.Hour)); got != "first\n\nsecond" {
+		t.Errorf("got %q", got)
+	}
+	if got := ContentOn(s, day.AddDate(0, 0, 1)); got != "" {
+		t.Errorf("expected empty content for a day without entries, got %q", got)
+	}
+}
+
+func TestJot(t *testing.T) {
+	s := testStore(t)
+	noTemplate := func(time.Time) string { return "" }
+
+	first, line, err := Jot(s, "  first note ", noTemplate, template.RenderOptions{})
+	if err != nil {
+		t.Fatalf("Jot: %v", err)
+	}
+	if !strings.HasPrefix(line, "- **") || !strings.HasSuffix(line, "** first note") {
+		t.Errorf("unexpected jot line %q", line)
+	}
+	second, _, err := Jot(s, "second note", noTemplate, template.RenderOptions{})
+	if err != nil {
+		t.Fatalf("Jot: %v", err)
+	}
+	if second.ID != first.ID {
+		t.Errorf("expected jots to share today's entry, got %s and %s", first.ID, second.ID)
+	}
+	if !strings.Contains(second.Content, "first note\n- **") || !strings.HasSuffix(second.Content, "second note") {
+		t.Errorf("unexpected content %q", second.Content)
+	}
+
+	if _, _, err := Jot(s, "  ", noTemplate, template.RenderOptions{}); err == nil {
+		t.Error("expected error for empty content")
+	}
+}
+
 func TestGetOrCreateToday_BadDefaultTemplateWarns(t *testing.T) {
 	s := testStore(t)
 	e, created, err := GetOrCreateToday(s, "nonexistent")
@@ -166,3 +249,34 @@ func TestGetOrCreateToday_MultipleEntries_ReturnsNewest(t *testing.T) {
 		t.Errorf("expected newest entry %q, got %q", newer.ID, got.ID)
 	}
 }
+
+func TestGetOrCreateToday_DayBoundary(t *testing.T) {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if now.Sub(midnight) > 23*time.Hour {
+		t.Skip("too close to midnight")
+	}
+	// Move the boundary past the current time so that now still belongs to
+	// yesterday, as a 1am jot does with day_starts_at = "04:00"
+	day.SetStartsAt(now.Sub(midnight).Truncate(time.Minute) + time.Hour)
+	t.Cleanup(func() { day.SetStartsAt(0) })
+
+	s := testStore(t)
+	id, err := entry.NewID()
+	if err != nil {
+		t.Fatalf("NewID: %v", err)
+	}
+	lateNight := midnight.Add(-2 * time.Hour).UTC()
+	e := entry.Entry{ID: id, Content: "started late", CreatedAt: lateNight, UpdatedAt: lateNight}
+	if err := s.Create(e); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	got, created, err := GetOrCreateToday(s, "")
+	if err != nil {
+		t.Fatalf("GetOrCreateToday: %v", err)
+	}
+	if created || got.ID != e.ID {
+		t.Errorf("expected yesterday's entry %q before the day boundary, got %q (created=%v)", e.ID, got.ID, created)
+	}
+}
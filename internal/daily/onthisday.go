@@ -0,0 +1,70 @@
+package daily
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// Memory is a past day resurfaced by OnThisDay with the entries written on it.
+type Memory struct {
+	Label   string        `json:"label"` // e.g. "1 week ago", "2 years ago"
+	Date    time.Time     `json:"date"`
+	Entries []entry.Entry `json:"entries"` // newest first
+}
+
+// MemoryStore is the subset of storage.Storage needed by OnThisDay.
+type MemoryStore interface {
+	storage.DayLister
+	List(opts storage.ListOptions) ([]entry.Entry, error)
+}
+
+// OnThisDay returns the entries written one week and one month before today
+// and on the same calendar date in previous years, most recent day first.
+// Days without entries are left out. One month before the 31st of a month
+// is the last day of the previous month.
+func OnThisDay(store MemoryStore, today time.Time) ([]Memory, error) {
+	day := time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, time.Local)
+	type lookback struct {
+		label string
+		date  time.Time
+	}
+	lookbacks := []lookback{
+		{"1 week ago", day.AddDate(0, 0, -7)},
+		{"1 month ago", monthBefore(day)},
+	}
+	years, err := storage.AnniversaryDays(store, day.Month(), day.Day(), day)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range years {
+		n := day.Year() - d.Year()
+		label := fmt.Sprintf("%d years ago", n)
+		if n == 1 {
+			label = "1 year ago"
+		}
+		lookbacks = append(lookbacks, lookback{label, d})
+	}
+
+	var memories []Memory
+	for _, lb := range lookbacks {
+		entries, err := store.List(storage.ListOptions{Date: &lb.date})
+		if err != nil {
+			return nil, err
+		}
+		if len(entries) > 0 {
+			memories = append(memories, Memory{Label: lb.label, Date: lb.date, Entries: entries})
+		}
+	}
+	return memories, nil
+}
+
+// monthBefore returns the same day of the previous month, clamped to that
+// month's last day.
+func monthBefore(day time.Time) time.Time {
+	first := time.Date(day.Year(), day.Month()-1, 1, 0, 0, 0, 0, time.Local)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day.Day(), last)-1)
+}
@@ -0,0 +1,67 @@
+package daily
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+func TestOnThisDay(t *testing.T) {
+	s := testStore(t)
+	for i, at := range []time.Time{
+		time.Date(2023, 3, 31, 8, 0, 0, 0, time.Local),  // 3 years ago
+		time.Date(2025, 3, 31, 8, 0, 0, 0, time.Local),  // 1 year ago
+		time.Date(2025, 3, 31, 20, 0, 0, 0, time.Local), // 1 year ago, later
+		time.Date(2026, 2, 28, 9, 0, 0, 0, time.Local),  // 1 month ago (clamped)
+		time.Date(2026, 3, 24, 9, 0, 0, 0, time.Local),  // 1 week ago
+		time.Date(2026, 3, 30, 9, 0, 0, 0, time.Local),  // yesterday
+		time.Date(2026, 3, 31, 7, 0, 0, 0, time.Local),  // today
+	} {
+		id, err := entry.NewID()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Create(entry.Entry{ID: id, Content: at.Format("2006-01-02 15:04"), CreatedAt: at.UTC(), UpdatedAt: at.UTC()}); err != nil {
+			t.Fatalf("create %d: %v", i, err)
+		}
+	}
+
+	memories, err := OnThisDay(s, time.Date(2026, 3, 31, 12, 0, 0, 0, time.Local))
+	if err != nil {
+		t.Fatalf("OnThisDay: %v", err)
+	}
+	want := []struct {
+		label   string
+		date    string
+		entries int
+	}{
+		{"1 week ago", "2026-03-24", 1},
+		{"1 month ago", "2026-02-28", 1},
+		{"1 year ago", "2025-03-31", 2},
+		{"3 years ago", "2023-03-31", 1},
+	}
+	if len(memories) != len(want) {
+		t.Fatalf("got %d memories, want %d: %+v", len(memories), len(want), memories)
+	}
+	for i, w := range want {
+		m := memories[i]
+		if m.Label != w.label || m.Date.Format("2006-01-02") != w.date || len(m.Entries) != w.entries {
+			t.Errorf("memory %d = %s %s (%d entries), want %s %s (%d entries)",
+				i, m.Label, m.Date.Format("2006-01-02"), len(m.Entries), w.label, w.date, w.entries)
+		}
+	}
+	if memories[2].Entries[0].Content != "2025-03-31 20:00" {
+		t.Errorf("entries should be newest first, got %q", memories[2].Entries[0].Content)
+	}
+}
+
+func TestOnThisDay_None(t *testing.T) {
+	memories, err := OnThisDay(testStore(t), time.Now())
+	if err != nil {
+		t.Fatalf("OnThisDay: %v", err)
+	}
+	if len(memories) != 0 {
+		t.Errorf("expected no memories, got %+v", memories)
+	}
+}
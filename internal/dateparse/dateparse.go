@@ -0,0 +1,267 @@
+// Package dateparse resolves the dates users type into flags, the TUI and MCP
+// tools: ISO dates, relative days such as "yesterday", "last monday", "-3d"
+// or "2w ago", and periods such as "2026-W41", "march" or "last week".
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+)
+
+// Examples lists accepted forms for help text and error messages.
+const Examples = "2026-03-05, today, yesterday, last monday, -3d, 2w ago, 2026-W41, march, last week"
+
+// Range is an inclusive span of calendar days. Start and End are local
+// midnights; End is the last day in the range, not the day after.
+type Range struct {
+	Start time.Time
+	End   time.Time
+}
+
+// SingleDay reports whether the range covers exactly one day.
+func (r Range) SingleDay() bool {
+	return r.Start.Equal(r.End)
+}
+
+// Parser resolves date expressions relative to the current time.
+type Parser struct {
+	// Now returns the current time (nil = time.Now). Tests pin it.
+	Now func() time.Time
+	// Forward resolves bare weekday and month names to their next
+	// occurrence instead of the most recent one, for deadlines.
+	Forward bool
+}
+
+// ParseDay resolves s to a single day relative to the current time.
+func ParseDay(s string) (time.Time, error) {
+	return Parser{}.Day(s)
+}
+
+// ParseRange resolves s to a range of days relative to the current time.
+func ParseRange(s string) (Range, error) {
+	return Parser{}.Range(s)
+}
+
+// Day resolves s to a single day. Periods such as "march" are rejected.
+func (p Parser) Day(s string) (time.Time, error) {
+	r, err := p.Range(s)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !r.SingleDay() {
+		return time.Time{}, fmt.Errorf("%q is a range of days (%s to %s), not a single day",
+			strings.TrimSpace(s), r.Start.Format("2006-01-02"), r.End.Format("2006-01-02"))
+	}
+	return r.Start, nil
+}
+
+var (
+	isoWeekPattern   = regexp.MustCompile(`^(\d{4})-w(\d{1,2})$`)
+	monthPattern     = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	yearPattern      = regexp.MustCompile(`^\d{4}$`)
+	offsetPattern    = regexp.MustCompile(`^([+-])\s*(\d+)\s*([a-z]+)$`)
+	agoPattern       = regexp.MustCompile(`^(\d+|an?|one)\s*([a-z]+) ago$`)
+	inPattern        = regexp.MustCompile(`^in (\d+|an?|one)\s*([a-z]+)$`)
+	relativePattern  = regexp.MustCompile(`^(last|this|next) ([a-z]+)$`)
+	monthYearPattern = regexp.MustCompile(`^([a-z]+) (\d{4})$`)
+)
+
+// Range resolves s to a range of days. Single days resolve to a range of one.
+func (p Parser) Range(s string) (Range, error) {
+	in := strings.ToLower(strings.Join(strings.Fields(s), " "))
+	if in == "" {
+		return Range{}, fmt.Errorf("empty date")
+	}
+	now := time.Now
+	if p.Now != nil {
+		now = p.Now
+	}
+	today := day.Of(now())
+
+	switch in {
+	case "today":
+		return oneDay(today), nil
+	case "yesterday":
+		return oneDay(today.AddDate(0, 0, -1)), nil
+	case "tomorrow":
+		return oneDay(today.AddDate(0, 0, 1)), nil
+	}
+
+	if d, err := time.ParseInLocation("2006-01-02", in, time.Local); err == nil {
+		return oneDay(d), nil
+	}
+	if m := isoWeekPattern.FindStringSubmatch(in); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		start := isoWeekStart(year, week)
+		if y, w := start.ISOWeek(); y != year || w != week {
+			return Range{}, fmt.Errorf("%d has no ISO week %d", year, week)
+		}
+		return weekOf(start), nil
+	}
+	if m := monthPattern.FindStringSubmatch(in); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		if month < 1 || month > 12 {
+			return Range{}, fmt.Errorf("invalid month in %q", s)
+		}
+		return monthOf(year, time.Month(month)), nil
+	}
+	if yearPattern.MatchString(in) {
+		year, _ := strconv.Atoi(in)
+		return yearOf(year), nil
+	}
+
+	if m := offsetPattern.FindStringSubmatch(in); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[1] == "-" {
+			n = -n
+		}
+		if d, ok := shift(today, n, m[3]); ok {
+			return oneDay(d), nil
+		}
+	}
+	if m := agoPattern.FindStringSubmatch(in); m != nil {
+		if d, ok := shift(today, -count(m[1]), m[2]); ok {
+			return oneDay(d), nil
+		}
+	}
+	if m := inPattern.FindStringSubmatch(in); m != nil {
+		if d, ok := shift(today, count(m[1]), m[2]); ok {
+			return oneDay(d), nil
+		}
+	}
+
+	if m := relativePattern.FindStringSubmatch(in); m != nil {
+		step := map[string]int{"last": -1, "this": 0, "next": 1}[m[1]]
+		switch m[2] {
+		case "week":
+			return weekOf(today.AddDate(0, 0, 7*step)), nil
+		case "month":
+			first := time.Date(today.Year(), today.Month()+time.Month(step), 1, 0, 0, 0, 0, time.Local)
+			return monthOf(first.Year(), first.Month()), nil
+		case "year":
+			return yearOf(today.Year() + step), nil
+		}
+		if wd, ok := weekday(m[2]); ok {
+			switch step {
+			case -1: // the most recent one before today
+				return oneDay(today.AddDate(0, 0, -((int(today.Weekday())-int(wd)+6)%7 + 1))), nil
+			case 1: // the first one after today
+				return oneDay(today.AddDate(0, 0, (int(wd)-int(today.Weekday())+6)%7+1)), nil
+			default: // the one in the current ISO week
+				return oneDay(weekOf(today).Start.AddDate(0, 0, (int(wd)+6)%7)), nil
+			}
+		}
+	}
+
+	if wd, ok := weekday(in); ok {
+		if p.Forward {
+			return oneDay(today.AddDate(0, 0, (int(wd)-int(today.Weekday())+7)%7)), nil
+		}
+		return oneDay(today.AddDate(0, 0, -((int(today.Weekday()) - int(wd) + 7) % 7))), nil
+	}
+	if m, ok := month(in); ok {
+		year := today.Year()
+		if p.Forward && m < today.Month() {
+			year++
+		} else if !p.Forward && m > today.Month() {
+			year--
+		}
+		return monthOf(year, m), nil
+	}
+	if m := monthYearPattern.FindStringSubmatch(in); m != nil {
+		if mo, ok := month(m[1]); ok {
+			year, _ := strconv.Atoi(m[2])
+			return monthOf(year, mo), nil
+		}
+	}
+
+	return Range{}, fmt.Errorf("unrecognized date %q (e.g. %s)", strings.TrimSpace(s), Examples)
+}
+
+func oneDay(d time.Time) Range {
+	return Range{Start: d, End: d}
+}
+
+// weekOf returns the ISO week (Monday to Sunday) containing d.
+func weekOf(d time.Time) Range {
+	start := d.AddDate(0, 0, -((int(d.Weekday()) + 6) % 7))
+	return Range{Start: start, End: start.AddDate(0, 0, 6)}
+}
+
+// isoWeekStart returns the Monday of ISO week w in year. Week 1 is the week
+// containing January 4th.
+func isoWeekStart(year, w int) time.Time {
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.Local)
+	return weekOf(jan4).Start.AddDate(0, 0, 7*(w-1))
+}
+
+func monthOf(year int, m time.Month) Range {
+	start := time.Date(year, m, 1, 0, 0, 0, 0, time.Local)
+	return Range{Start: start, End: start.AddDate(0, 1, -1)}
+}
+
+func yearOf(year int) Range {
+	return Range{
+		Start: time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local),
+		End:   time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local),
+	}
+}
+
+// count parses the number in "2 weeks ago" or "a week ago".
+func count(s string) int {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n
+	}
+	return 1
+}
+
+// shift moves d by n units of days, weeks, months or years. Month and year
+// steps land on the last day of a shorter month rather than overflowing.
+func shift(d time.Time, n int, unit string) (time.Time, bool) {
+	switch unit {
+	case "d", "day", "days":
+		return d.AddDate(0, 0, n), true
+	case "w", "wk", "wks", "week", "weeks":
+		return d.AddDate(0, 0, 7*n), true
+	case "m", "mo", "month", "months":
+		return addMonths(d, n), true
+	case "y", "yr", "yrs", "year", "years":
+		return addMonths(d, 12*n), true
+	}
+	return time.Time{}, false
+}
+
+func addMonths(d time.Time, n int) time.Time {
+	first := time.Date(d.Year(), d.Month()+time.Month(n), 1, 0, 0, 0, 0, time.Local)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(d.Day(), last)-1)
+}
+
+// weekday matches a full or three-letter weekday name.
+func weekday(s string) (time.Weekday, bool) {
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if s == name || s == name[:3] {
+			return wd, true
+		}
+	}
+	return 0, false
+}
+
+// month matches a full or three-letter month name.
+func month(s string) (time.Month, bool) {
+	for m := time.January; m <= time.December; m++ {
+		name := strings.ToLower(m.String())
+		if s == name || s == name[:3] {
+			return m, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,151 @@
+package dateparse
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+)
+
+// fixed pins the clock to Thursday 2026-10-15 14:30 local time.
+func fixed() time.Time {
+	return time.Date(2026, 10, 15, 14, 30, 0, 0, time.Local)
+}
+
+func TestRange(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string // "start" or "start..end"
+	}{
+		{"today", "2026-10-15"},
+		{" Yesterday ", "2026-10-14"},
+		{"tomorrow", "2026-10-16"},
+		{"2026-03-05", "2026-03-05"},
+		{"-3d", "2026-10-12"},
+		{"+2d", "2026-10-17"},
+		{"-1w", "2026-10-08"},
+		{"-1m", "2026-09-15"},
+		{"-1y", "2025-10-15"},
+		{"3 days ago", "2026-10-12"},
+		{"2w ago", "2026-10-01"},
+		{"a week ago", "2026-10-08"},
+		{"1 month ago", "2026-09-15"},
+		{"2 years ago", "2024-10-15"},
+		{"in 3 days", "2026-10-18"},
+		{"monday", "2026-10-12"},
+		{"thu", "2026-10-15"},
+		{"friday", "2026-10-09"},
+		{"last monday", "2026-10-12"},
+		{"last thursday", "2026-10-08"},
+		{"next thursday", "2026-10-22"},
+		{"next monday", "2026-10-19"},
+		{"this sunday", "2026-10-18"},
+		{"2026-W41", "2026-10-05..2026-10-11"},
+		{"2026-w1", "2025-12-29..2026-01-04"},
+		{"2020-W53", "2020-12-28..2021-01-03"},
+		{"this week", "2026-10-12..2026-10-18"},
+		{"last week", "2026-10-05..2026-10-11"},
+		{"next week", "2026-10-19..2026-10-25"},
+		{"march", "2026-03-01..2026-03-31"},
+		{"Oct", "2026-10-01..2026-10-31"},
+		{"december", "2025-12-01..2025-12-31"},
+		{"feb 2024", "2024-02-01..2024-02-29"},
+		{"2026-02", "2026-02-01..2026-02-28"},
+		{"last month", "2026-09-01..2026-09-30"},
+		{"this month", "2026-10-01..2026-10-31"},
+		{"last year", "2025-01-01..2025-12-31"},
+		{"2024", "2024-01-01..2024-12-31"},
+	}
+	p := Parser{Now: fixed}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			r, err := p.Range(tt.in)
+			if err != nil {
+				t.Fatalf("Range(%q): %v", tt.in, err)
+			}
+			got := r.Start.Format("2006-01-02")
+			if !r.SingleDay() {
+				got += ".." + r.End.Format("2006-01-02")
+			}
+			if got != tt.want {
+				t.Errorf("Range(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+			if r.Start.Hour() != 0 || r.Start.Location() != time.Local {
+				t.Errorf("Range(%q) start %v is not a local midnight", tt.in, r.Start)
+			}
+		})
+	}
+}
+
+func TestRange_MonthEnds(t *testing.T) {
+	p := Parser{Now: func() time.Time { return time.Date(2026, 3, 31, 9, 0, 0, 0, time.Local) }}
+	for in, want := range map[string]string{
+		"-1m":         "2026-02-28",
+		"1 month ago": "2026-02-28",
+		"+1m":         "2026-04-30",
+	} {
+		d, err := p.Day(in)
+		if err != nil || d.Format("2006-01-02") != want {
+			t.Errorf("Day(%q) = %s, %v; want %s", in, d.Format("2006-01-02"), err, want)
+		}
+	}
+}
+
+func TestRange_Forward(t *testing.T) {
+	p := Parser{Now: fixed, Forward: true}
+	for in, want := range map[string]string{
+		"friday":   "2026-10-16",
+		"thursday": "2026-10-15",
+		"monday":   "2026-10-19",
+		"march":    "2027-03-01",
+		"today":    "2026-10-15",
+	} {
+		r, err := p.Range(in)
+		if err != nil || r.Start.Format("2006-01-02") != want {
+			t.Errorf("Range(%q) = %s, %v; want %s", in, r.Start.Format("2006-01-02"), err, want)
+		}
+	}
+}
+
+func TestRange_DayBoundary(t *testing.T) {
+	day.SetStartsAt(4 * time.Hour)
+	t.Cleanup(func() { day.SetStartsAt(0) })
+
+	// 01:30 on Friday is still Thursday
+	p := Parser{Now: func() time.Time { return time.Date(2026, 10, 16, 1, 30, 0, 0, time.Local) }}
+	for in, want := range map[string]string{
+		"today":     "2026-10-15",
+		"yesterday": "2026-10-14",
+		"friday":    "2026-10-09",
+	} {
+		d, err := p.Day(in)
+		if err != nil || d.Format("2006-01-02") != want {
+			t.Errorf("Day(%q) = %s, %v; want %s", in, d.Format("2006-01-02"), err, want)
+		}
+	}
+}
+
+func TestRange_Invalid(t *testing.T) {
+	p := Parser{Now: fixed}
+	for _, in := range []string{"", "someday", "2026-13-01", "2026-02-30", "2026-W54", "2021-W53", "-3x", "last fortnight", "2026-00"} {
+		if r, err := p.Range(in); err == nil {
+			t.Errorf("Range(%q) = %v, expected an error", in, r)
+		}
+	}
+	_, err := p.Range("someday")
+	if err == nil || !strings.Contains(err.Error(), "last week") {
+		t.Errorf("error should list examples, got %v", err)
+	}
+}
+
+func TestDay(t *testing.T) {
+	p := Parser{Now: fixed}
+	d, err := p.Day("last monday")
+	if err != nil || !d.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("Day(last monday) = %v, %v", d, err)
+	}
+	if _, err := p.Day("last week"); err == nil || !strings.Contains(err.Error(), "range") {
+		t.Errorf("Day(last week) should reject a range, got %v", err)
+	}
+}
@@ -3,7 +3,9 @@
 package day
 
 import (
+	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/chris-regnier/diaryctl/internal/block"
@@ -40,6 +42,67 @@ func NormalizeDate(t time.Time) time.Time {
 	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
 }
 
+// startsAt is how long after local midnight each day begins. Times before it
+// belong to the previous day, so late-night writing stays on the day it
+// started.
+var startsAt time.Duration
+
+// SetStartsAt sets the time after local midnight at which days begin (0 =
+// midnight). It is set once at startup from the day_starts_at config option.
+func SetStartsAt(d time.Duration) {
+	startsAt = d
+}
+
+// StartsAt returns the time after local midnight at which days begin.
+func StartsAt() time.Duration {
+	return startsAt
+}
+
+// ParseStartsAt parses a day boundary in HH:MM form, e.g. "04:00".
+func ParseStartsAt(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", strings.TrimSpace(s))
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a time of day (HH:MM)", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Of returns the day the instant t belongs to, as midnight local time. It
+// is the local date of t, or the date before when t falls before the day
+// boundary set by SetStartsAt.
+//
+// Example with the boundary at 04:00:
+//
+//	input:  2024-01-16 01:30
+//	output: 2024-01-15 00:00:00.0
+func Of(t time.Time) time.Time {
+	return dayOfClock(t.Local())
+}
+
+// OfZone is like Of, but reads the date and time of t in the time zone named
+// zone, such as the zone an entry was written in. An empty or unknown zone
+// means the local one.
+func OfZone(t time.Time, zone string) time.Time {
+	return dayOfClock(In(t, zone))
+}
+
+// dayOfClock returns the day of the wall clock reading t, as midnight local
+// time.
+func dayOfClock(t time.Time) time.Time {
+	d := NormalizeDate(t)
+	clock := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute +
+		time.Duration(t.Second())*time.Second
+	if clock < startsAt {
+		return d.AddDate(0, 0, -1)
+	}
+	return d
+}
+
+// Today returns the current day, honouring the day boundary.
+func Today() time.Time {
+	return Of(time.Now())
+}
+
 // AddBlock adds a block to the day, maintains sort order by Block.CreatedAt,
 // and updates the day's timestamps.
 //
@@ -63,6 +63,60 @@ func TestNormalizeDate(t *testing.T) {
 	}
 }
 
+// TestOf verifies that times before the day boundary belong to the
+// previous day
+func TestOf(t *testing.T) {
+	boundary, err := day.ParseStartsAt("04:00")
+	if err != nil {
+		t.Fatalf("ParseStartsAt() error = %v", err)
+	}
+	day.SetStartsAt(boundary)
+	t.Cleanup(func() { day.SetStartsAt(0) })
+
+	tests := []struct {
+		name  string
+		input time.Time
+		want  string
+	}{
+		{"after midnight", time.Date(2024, 1, 16, 1, 30, 0, 0, time.Local), "2024-01-15"},
+		{"just before boundary", time.Date(2024, 1, 16, 3, 59, 59, 0, time.Local), "2024-01-15"},
+		{"at boundary", time.Date(2024, 1, 16, 4, 0, 0, 0, time.Local), "2024-01-16"},
+		{"late evening", time.Date(2024, 1, 16, 23, 0, 0, 0, time.Local), "2024-01-16"},
+		{"across year", time.Date(2024, 1, 1, 2, 0, 0, 0, time.Local), "2023-12-31"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := day.Of(tt.input).Format("2006-01-02"); got != tt.want {
+				t.Errorf("Of() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	day.SetStartsAt(0)
+	if got := day.Of(time.Date(2024, 1, 16, 1, 30, 0, 0, time.Local)).Format("2006-01-02"); got != "2024-01-16" {
+		t.Errorf("Of() with midnight boundary = %s, want 2024-01-16", got)
+	}
+}
+
+// TestParseStartsAt verifies HH:MM parsing of the day boundary
+func TestParseStartsAt(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"00:00": 0,
+		"04:00": 4 * time.Hour,
+		"5:30":  5*time.Hour + 30*time.Minute,
+	} {
+		got, err := day.ParseStartsAt(in)
+		if err != nil || got != want {
+			t.Errorf("ParseStartsAt(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "4am", "24:00", "04:60"} {
+		if _, err := day.ParseStartsAt(in); err == nil {
+			t.Errorf("ParseStartsAt(%q) expected error", in)
+		}
+	}
+}
+
 // TestDay_AddBlock verifies that AddBlock correctly adds blocks,
 // maintains sorting, and updates timestamps
 func TestDay_AddBlock(t *testing.T) {
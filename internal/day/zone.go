@@ -0,0 +1,61 @@
+package day
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// zones caches loaded time zones by name.
+var zones sync.Map
+
+// Zone returns the IANA name of the local time zone (e.g. "Europe/Paris"),
+// recorded on new entries so that their day doesn't change when the
+// machine's zone does. It is empty when the name can't be determined.
+func Zone() string {
+	if name := time.Local.String(); name != "Local" {
+		return name
+	}
+	// The zone comes from /etc/localtime, usually a link into zoneinfo
+	target, err := filepath.EvalSymlinks("/etc/localtime")
+	if err != nil {
+		return ""
+	}
+	if _, name, ok := strings.Cut(target, "zoneinfo/"); ok {
+		if _, err := time.LoadLocation(name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+// SetZone makes name the local time zone for the rest of the process, as
+// the --tz flag does: "today", new entries and displayed times all use it.
+func SetZone(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return err
+	}
+	time.Local = loc
+	// Keep SQLite's 'localtime' in step for entries without a zone
+	return os.Setenv("TZ", name)
+}
+
+// In returns t in the time zone named zone, or in the local zone when zone
+// is empty or unknown.
+func In(t time.Time, zone string) time.Time {
+	if zone == "" {
+		return t.Local()
+	}
+	if loc, ok := zones.Load(zone); ok {
+		return t.In(loc.(*time.Location))
+	}
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		return t.Local()
+	}
+	zones.Store(zone, loc)
+	return t.In(loc)
+}
@@ -0,0 +1,256 @@
+// Package digest summarizes the entries of a period as markdown.
+package digest
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+// TemplateName is the template saved digests are attributed to. Entries
+// using it are left out of later digests.
+const TemplateName = "digest"
+
+// Ref points at a source entry.
+type Ref struct {
+	ID      string    `json:"id"`
+	Created time.Time `json:"created_at"`
+	Preview string    `json:"preview"`
+}
+
+// Jot is a timestamped line jotted into an entry.
+type Jot struct {
+	EntryID string    `json:"entry_id"`
+	At      time.Time `json:"at"`
+	Text    string    `json:"text"`
+}
+
+// ContextGroup lists the entries tagged with one context.
+type ContextGroup struct {
+	Name    string `json:"name"`
+	Entries []Ref  `json:"entries"`
+}
+
+// SectionItem is the body under a heading in one entry.
+type SectionItem struct {
+	EntryID string    `json:"entry_id"`
+	Created time.Time `json:"created_at"`
+	Body    string    `json:"body"`
+}
+
+// Section gathers the bodies under the same heading across entries that
+// used a template, e.g. every "Blockers" heading from standups.
+type Section struct {
+	Template string        `json:"template"`
+	Heading  string        `json:"heading"`
+	Items    []SectionItem `json:"items"`
+}
+
+// Digest summarizes the entries created between From and To.
+type Digest struct {
+	Title    string         `json:"title"`
+	From     time.Time      `json:"from"`
+	To       time.Time      `json:"to"`
+	Days     int            `json:"days"` // days with entries
+	Entries  []Ref          `json:"entries"`
+	Jots     []Jot          `json:"jots"`
+	Contexts []ContextGroup `json:"contexts"`
+	Sections []Section      `json:"sections"`
+}
+
+var (
+	jotPattern     = regexp.MustCompile(`^- \*\*([0-2][0-9]):([0-5][0-9])\*\* (.*)$`)
+	headingPattern = regexp.MustCompile(`^#{1,6}\s+(.+?)\s*#*\s*$`)
+)
+
+// Build summarizes entries, which may be in any order, over from to to.
+// Saved digests among them are skipped.
+func Build(title string, from, to time.Time, entries []entry.Entry) Digest {
+	sorted := append([]entry.Entry(nil), entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].CreatedAt.Before(sorted[j].CreatedAt) })
+
+	d := Digest{Title: title, From: from, To: to, Entries: []Ref{}, Jots: []Jot{}, Contexts: []ContextGroup{}, Sections: []Section{}}
+	days := map[string]bool{}
+	contexts := map[string]*ContextGroup{}
+	sections := map[string]*Section{}
+	var sectionOrder []string
+	for _, e := range sorted {
+		if isDigest(e) {
+			continue
+		}
+		created := day.In(e.CreatedAt, e.TimeZone)
+		days[day.OfZone(e.CreatedAt, e.TimeZone).Format("2006-01-02")] = true
+		ref := Ref{ID: e.ID, Created: created, Preview: e.Preview(80)}
+		d.Entries = append(d.Entries, ref)
+		d.Jots = append(d.Jots, jots(e)...)
+
+		for _, c := range e.Contexts {
+			g := contexts[c.ContextName]
+			if g == nil {
+				g = &ContextGroup{Name: c.ContextName}
+				contexts[c.ContextName] = g
+			}
+			g.Entries = append(g.Entries, ref)
+		}
+
+		if len(e.Templates) == 0 {
+			continue
+		}
+		tmpl := e.Templates[0].TemplateName
+		for _, h := range headings(e.Content) {
+			key := tmpl + "\x00" + h.heading
+			s := sections[key]
+			if s == nil {
+				s = &Section{Template: tmpl, Heading: h.heading}
+				sections[key] = s
+				sectionOrder = append(sectionOrder, key)
+			}
+			s.Items = append(s.Items, SectionItem{EntryID: e.ID, Created: created, Body: h.body})
+		}
+	}
+	d.Days = len(days)
+
+	sort.SliceStable(d.Jots, func(i, j int) bool { return d.Jots[i].At.Before(d.Jots[j].At) })
+	for _, g := range contexts {
+		d.Contexts = append(d.Contexts, *g)
+	}
+	sort.Slice(d.Contexts, func(i, j int) bool { return d.Contexts[i].Name < d.Contexts[j].Name })
+	for _, key := range sectionOrder {
+		d.Sections = append(d.Sections, *sections[key])
+	}
+	return d
+}
+
+func isDigest(e entry.Entry) bool {
+	for _, t := range e.Templates {
+		if t.TemplateName == TemplateName {
+			return true
+		}
+	}
+	return false
+}
+
+// jots returns the timestamped lines in e, dated on the diary day e was
+// created, in the zone it was written in. Times before the day boundary fall
+// on the following calendar day.
+func jots(e entry.Entry) []Jot {
+	created := day.In(e.CreatedAt, e.TimeZone)
+	diaryDay := day.OfZone(e.CreatedAt, e.TimeZone)
+	var out []Jot
+	for _, line := range strings.Split(e.Content, "\n") {
+		m := jotPattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+		if m == nil {
+			continue
+		}
+		hour, _ := strconv.Atoi(m[1])
+		minute, _ := strconv.Atoi(m[2])
+		at := time.Date(diaryDay.Year(), diaryDay.Month(), diaryDay.Day(), hour, minute, 0, 0, created.Location())
+		// Jots before the day boundary were written after midnight
+		if time.Duration(hour)*time.Hour+time.Duration(minute)*time.Minute < day.StartsAt() {
+			at = at.AddDate(0, 0, 1)
+		}
+		out = append(out, Jot{EntryID: e.ID, At: at, Text: m[3]})
+	}
+	return out
+}
+
+type headingBody struct {
+	heading string
+	body    string
+}
+
+// headings splits markdown content at its headings, returning each heading
+// with the text up to the next one. Headings with no text are left out.
+func headings(content string) []headingBody {
+	var out []headingBody
+	var current *headingBody
+	var body []string
+	flush := func() {
+		if current != nil {
+			current.body = strings.TrimSpace(strings.Join(body, "\n"))
+			if current.body != "" {
+				out = append(out, *current)
+			}
+		}
+		body = nil
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if m := headingPattern.FindStringSubmatch(line); m != nil {
+			flush()
+			current = &headingBody{heading: m[1]}
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+	return out
+}
+
+// link references an entry with the [[id]] syntax.
+func link(id string) string {
+	return "[[" + id + "]]"
+}
+
+// Markdown renders the digest as a markdown document.
+func (d Digest) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", d.Title)
+	fmt.Fprintf(&b, "%s to %s: ", d.From.Format("2006-01-02"), d.To.Format("2006-01-02"))
+	switch len(d.Entries) {
+	case 0:
+		b.WriteString("no entries.\n")
+		return b.String()
+	case 1:
+		b.WriteString("1 entry")
+	default:
+		fmt.Fprintf(&b, "%d entries", len(d.Entries))
+	}
+	if d.Days == 1 {
+		b.WriteString(" on 1 day.\n")
+	} else {
+		fmt.Fprintf(&b, " on %d days.\n", d.Days)
+	}
+
+	if len(d.Jots) > 0 {
+		b.WriteString("\n## Jots\n\n")
+		for _, j := range d.Jots {
+			fmt.Fprintf(&b, "- **%s** %s %s\n", j.At.Format("Mon 01-02 15:04"), j.Text, link(j.EntryID))
+		}
+	}
+
+	if len(d.Contexts) > 0 {
+		b.WriteString("\n## By Context\n")
+		for _, g := range d.Contexts {
+			fmt.Fprintf(&b, "\n### %s\n\n", g.Name)
+			for _, r := range g.Entries {
+				fmt.Fprintf(&b, "- %s %s %s\n", r.Created.Format("2006-01-02"), r.Preview, link(r.ID))
+			}
+		}
+	}
+
+	for _, s := range d.Sections {
+		fmt.Fprintf(&b, "\n## %s (%s)\n\n", s.Heading, s.Template)
+		for _, item := range s.Items {
+			fmt.Fprintf(&b, "- %s %s\n", item.Created.Format("2006-01-02"), link(item.EntryID))
+			for _, line := range strings.Split(item.Body, "\n") {
+				if strings.TrimSpace(line) == "" {
+					b.WriteString("\n")
+					continue
+				}
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		}
+	}
+
+	b.WriteString("\n## Entries\n\n")
+	for _, r := range d.Entries {
+		fmt.Fprintf(&b, "- %s %s %s\n", r.Created.Format("2006-01-02 15:04"), link(r.ID), r.Preview)
+	}
+	return b.String()
+}
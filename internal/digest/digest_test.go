@@ -0,0 +1,116 @@
+package digest
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+func at(day, hour, minute int) time.Time {
+	return time.Date(2026, 3, day, hour, minute, 0, 0, time.Local)
+}
+
+func TestBuild(t *testing.T) {
+	standup := []entry.TemplateRef{{TemplateID: "tmpl0001", TemplateName: "standup"}}
+	entries := []entry.Entry{
+		{ID: "dig00003", CreatedAt: at(3, 9, 0), Templates: standup,
+			Content: "## Standup — Tue Mar 3\n\n### Today\nship auth\n\n### Blockers\nwaiting on review"},
+		{ID: "dig00001", CreatedAt: at(2, 8, 0), Content: "- **08:00** coffee\n- **17:45** wrapped up",
+			Contexts: []entry.ContextRef{{ContextID: "ctx1", ContextName: "work"}}},
+		{ID: "dig00002", CreatedAt: at(2, 9, 0), Templates: standup,
+			Content:  "## Standup — Mon Mar 2\n\n### Today\nauth\n\n### Blockers\nnone",
+			Contexts: []entry.ContextRef{{ContextID: "ctx1", ContextName: "work"}, {ContextID: "ctx2", ContextName: "auth"}}},
+		{ID: "dig00004", CreatedAt: at(3, 10, 0), Content: "- **10:00** lunch plans"},
+		{ID: "dig00005", CreatedAt: at(4, 9, 0), Content: "# Old digest\n\nsummary",
+			Templates: []entry.TemplateRef{{TemplateID: "tmpl0002", TemplateName: TemplateName}}},
+	}
+
+	d := Build("Weekly Digest", at(2, 0, 0), at(8, 0, 0), entries)
+	if len(d.Entries) != 4 || d.Entries[0].ID != "dig00001" || d.Days != 2 {
+		t.Fatalf("entries = %+v, days = %d", d.Entries, d.Days)
+	}
+	var jots []string
+	for _, j := range d.Jots {
+		jots = append(jots, j.At.Format("01-02 15:04")+" "+j.Text)
+	}
+	if strings.Join(jots, "|") != "03-02 08:00 coffee|03-02 17:45 wrapped up|03-03 10:00 lunch plans" {
+		t.Errorf("jots = %v", jots)
+	}
+	if len(d.Contexts) != 2 || d.Contexts[0].Name != "auth" || len(d.Contexts[1].Entries) != 2 {
+		t.Errorf("contexts = %+v", d.Contexts)
+	}
+	if len(d.Sections) != 2 || d.Sections[1].Heading != "Blockers" || len(d.Sections[1].Items) != 2 ||
+		d.Sections[1].Items[1].Body != "waiting on review" {
+		t.Errorf("sections = %+v", d.Sections)
+	}
+
+	md := d.Markdown()
+	for _, want := range []string{
+		"# Weekly Digest\n\n2026-03-02 to 2026-03-08: 4 entries on 2 days.\n",
+		"## Jots\n\n- **Mon 03-02 08:00** coffee [[dig00001]]\n",
+		"### work\n\n- 2026-03-02 - **08:00** coffee - **17:45** wrapped up [[dig00001]]\n",
+		"## Blockers (standup)\n\n- 2026-03-02 [[dig00002]]\n  none\n- 2026-03-03 [[dig00003]]\n  waiting on review\n",
+		"## Entries\n\n- 2026-03-02 08:00 [[dig00001]]",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "(standup)\n\n- 2026-03-02 [[dig00002]]\n  ### Today") || strings.Contains(md, "dig00005") {
+		t.Errorf("markdown should leave out empty headings and saved digests:\n%s", md)
+	}
+}
+
+func TestBuild_Empty(t *testing.T) {
+	md := Build("Monthly Digest", at(1, 0, 0), at(31, 0, 0), nil).Markdown()
+	if md != "# Monthly Digest\n\n2026-03-01 to 2026-03-31: no entries.\n" {
+		t.Errorf("markdown = %q", md)
+	}
+}
+
+func TestBuild_JotsAfterMidnight(t *testing.T) {
+	day.SetStartsAt(4 * time.Hour)
+	t.Cleanup(func() { day.SetStartsAt(0) })
+
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("Asia/Tokyo zone data unavailable")
+	}
+	entries := []entry.Entry{
+		// Created at 01:00 on Mar 3, which is still the diary day of Mar 2
+		{ID: "late0001", CreatedAt: at(3, 1, 0), Content: "- **22:30** reading\n- **01:00** still up"},
+		{ID: "tokyo001", CreatedAt: time.Date(2026, 3, 4, 9, 0, 0, 0, tokyo), TimeZone: "Asia/Tokyo",
+			Content: "- **09:00** landed"},
+	}
+
+	d := Build("Weekly Digest", at(2, 0, 0), at(8, 0, 0), entries)
+	var jots []string
+	for _, j := range d.Jots {
+		jots = append(jots, j.At.Format("01-02 15:04 MST")+" "+j.Text)
+	}
+	want := at(2, 22, 30).Format("01-02 15:04 MST") + " reading|" +
+		at(3, 1, 0).Format("01-02 15:04 MST") + " still up|" +
+		"03-04 09:00 JST landed"
+	if strings.Join(jots, "|") != want {
+		t.Errorf("jots = %v, want %s", jots, want)
+	}
+}
+
+func TestBuild_DaysFollowDayStart(t *testing.T) {
+	day.SetStartsAt(4 * time.Hour)
+	t.Cleanup(func() { day.SetStartsAt(0) })
+
+	entries := []entry.Entry{
+		{ID: "eve00001", CreatedAt: at(2, 22, 0), Content: "evening"},
+		// Still the diary day of Mar 2
+		{ID: "late0001", CreatedAt: at(3, 1, 0), Content: "after midnight"},
+	}
+
+	d := Build("Weekly Digest", at(2, 0, 0), at(8, 0, 0), entries)
+	if d.Days != 1 {
+		t.Errorf("Days = %d, want 1", d.Days)
+	}
+}
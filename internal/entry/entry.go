@@ -20,8 +20,9 @@ var contextNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_/-]*$`)
 
 // TemplateRef is a lightweight reference to a template, stored on entries for attribution.
 type TemplateRef struct {
-	TemplateID   string `json:"template_id"`
-	TemplateName string `json:"template_name"`
+	TemplateID      string `json:"template_id"`
+	TemplateName    string `json:"template_name"`
+	TemplateVersion int    `json:"template_version,omitempty"` // 0 when the version is unknown
 }
 
 // ContextRef is a lightweight reference to a context, stored on entries for grouping.
@@ -36,6 +37,7 @@ type Entry struct {
 	Content   string        `json:"content"`
 	CreatedAt time.Time     `json:"created_at"`
 	UpdatedAt time.Time     `json:"updated_at"`
+	TimeZone  string        `json:"timezone,omitempty"` // IANA zone it was written in; empty = unknown
 	Templates []TemplateRef `json:"templates,omitempty"`
 	Contexts  []ContextRef  `json:"contexts,omitempty"`
 }
@@ -109,3 +111,8 @@ func (e *Entry) Preview(maxLen int) string {
 	}
 	return content[:maxLen-3] + "..."
 }
+
+// WordCount returns the number of whitespace-separated words in content.
+func WordCount(content string) int {
+	return len(strings.Fields(content))
+}
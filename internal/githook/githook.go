@@ -0,0 +1,308 @@
+package githook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Hook names managed by diaryctl.
+const (
+	PostCommit   = "post-commit"
+	PostCheckout = "post-checkout"
+)
+
+// marker identifies hook scripts written by diaryctl.
+const marker = "# diaryctl-hook"
+
+// chainedSuffix is appended to a pre-existing hook that diaryctl wraps.
+const chainedSuffix = ".pre-diaryctl"
+
+// optOutKey is the git config key that disables auto-jotting for a repo.
+const optOutKey = "diaryctl.autojot"
+
+const stateFile = "hook-state.json"
+
+// HooksDir returns the hooks directory for the repository containing dir,
+// honouring core.hooksPath.
+func HooksDir(dir string) (string, error) {
+	out, err := git(dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %s", dir)
+	}
+	if !filepath.IsAbs(out) {
+		out = filepath.Join(dir, out)
+	}
+	return out, nil
+}
+
+// Script returns the hook script for name. The script first runs any hook it
+// replaced, then invokes diaryctl in the background so git is never blocked.
+func Script(name, diaryctlPath string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s: managed by "diaryctl hook install"; remove with "diaryctl hook uninstall"
+chained="$(dirname "$0")/%s%s"
+if [ -x "$chained" ]; then
+  "$chained" "$@" || exit $?
+fi
+%s hook run %s "$@" >/dev/null 2>&1 </dev/null &
+exit 0
+`, marker, name, chainedSuffix, shellQuote(diaryctlPath), name)
+}
+
+// IsManaged reports whether the hook file at path was written by diaryctl.
+func IsManaged(path string) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), marker)
+}
+
+// Install writes the named hook into hooksDir. An existing hook not written by
+// diaryctl is preserved as <name>.pre-diaryctl and run before diaryctl.
+// Reinstalling over a managed hook rewrites it in place.
+func Install(hooksDir, name, diaryctlPath string) error {
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return fmt.Errorf("creating hooks directory: %w", err)
+	}
+	path := filepath.Join(hooksDir, name)
+	if _, err := os.Stat(path); err == nil && !IsManaged(path) {
+		chained := path + chainedSuffix
+		if _, err := os.Stat(chained); err == nil {
+			return fmt.Errorf("cannot chain %s: %s already exists", name, chained)
+		}
+		if err := os.Rename(path, chained); err != nil {
+			return fmt.Errorf("preserving existing %s hook: %w", name, err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(Script(name, diaryctlPath)), 0755); err != nil {
+		return fmt.Errorf("writing %s hook: %w", name, err)
+	}
+	return nil
+}
+
+// Uninstall removes the named diaryctl hook from hooksDir and restores any
+// hook it had chained. Returns false if no diaryctl hook was installed.
+func Uninstall(hooksDir, name string) (bool, error) {
+	path := filepath.Join(hooksDir, name)
+	if !IsManaged(path) {
+		return false, nil
+	}
+	if err := os.Remove(path); err != nil {
+		return false, fmt.Errorf("removing %s hook: %w", name, err)
+	}
+	chained := path + chainedSuffix
+	if _, err := os.Stat(chained); err == nil {
+		if err := os.Rename(chained, path); err != nil {
+			return true, fmt.Errorf("restoring original %s hook: %w", name, err)
+		}
+	}
+	return true, nil
+}
+
+// Disabled reports whether auto-jotting is turned off for the repository
+// containing dir via "git config diaryctl.autojot false".
+func Disabled(dir string) bool {
+	out, err := git(dir, "config", "--bool", optOutKey)
+	return err == nil && out == "false"
+}
+
+// SetDisabled turns auto-jotting off (or back on) for the repository containing dir.
+func SetDisabled(dir string, disabled bool) error {
+	var err error
+	if disabled {
+		_, err = git(dir, "config", "--bool", optOutKey, "false")
+	} else {
+		_, err = git(dir, "config", "--unset", optOutKey)
+		if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 5 {
+			err = nil // key was not set
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("updating %s: %w", optOutKey, err)
+	}
+	return nil
+}
+
+// Rewriting reports whether a rebase, cherry-pick or similar history rewrite is
+// in progress in the repository containing dir. Commits made while rewriting
+// are not jotted.
+func Rewriting(dir string) bool {
+	if action := os.Getenv("GIT_REFLOG_ACTION"); strings.HasPrefix(action, "rebase") {
+		return true
+	}
+	for _, p := range []string{"rebase-merge", "rebase-apply", "CHERRY_PICK_HEAD", "REVERT_HEAD"} {
+		out, err := git(dir, "rev-parse", "--git-path", p)
+		if err != nil {
+			continue
+		}
+		if !filepath.IsAbs(out) {
+			out = filepath.Join(dir, out)
+		}
+		if _, err := os.Stat(out); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Line is a hook event to jot: its text, when it happened and the context
+// names it was made under.
+type Line struct {
+	Text     string    `json:"text"`
+	At       time.Time `json:"at"`
+	Contexts []string  `json:"contexts,omitempty"`
+}
+
+// repoState is the rate limiting state of one repository.
+type repoState struct {
+	Last    time.Time `json:"last"`              // time of the last jot
+	Pending []Line    `json:"pending,omitempty"` // held back lines, oldest first
+}
+
+// Batch rate limits jots for repo to one per minInterval without dropping
+// any lines. Within the interval after the last jot, line is held back as
+// pending; otherwise jot is called with the pending lines followed by line.
+// Lines keep their own time and contexts, so jot can write each one on the
+// day it was made. jot returns how many lines it wrote; the rest stay pending
+// for the next run. line.At is recorded as the last jot time once every line
+// is written. Batch reports whether jot was called.
+//
+// State is kept per repository in dataDir. Concurrent hook runs are
+// serialized with a lock on the state file, held until jot returns.
+func Batch(dataDir, repo string, minInterval time.Duration, line Line, jot func(lines []Line) (int, error)) (bool, error) {
+	path := filepath.Join(dataDir, stateFile)
+	lock, err := os.OpenFile(path+".lock", os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return false, fmt.Errorf("opening hook state lock: %w", err)
+	}
+	defer lock.Close()
+	if err := syscall.Flock(int(lock.Fd()), syscall.LOCK_EX); err != nil {
+		return false, fmt.Errorf("locking hook state: %w", err)
+	}
+	defer syscall.Flock(int(lock.Fd()), syscall.LOCK_UN)
+
+	state := map[string]repoState{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &state); err != nil {
+			state = map[string]repoState{} // reset corrupt state
+		}
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+
+	rs := state[repo]
+	lines := append(rs.Pending, line)
+	if !rs.Last.IsZero() && minInterval > 0 && line.At.Sub(rs.Last) < minInterval {
+		rs.Pending = lines
+		state[repo] = rs
+		return false, writeState(path, state)
+	}
+
+	written, jotErr := jot(lines)
+	if jotErr != nil {
+		rs.Pending = lines[min(max(written, 0), len(lines)):]
+		state[repo] = rs
+		if err := writeState(path, state); err != nil {
+			return true, err
+		}
+		return true, jotErr
+	}
+	state[repo] = repoState{Last: line.At.UTC()}
+	return true, writeState(path, state)
+}
+
+// writeState replaces the state file at path via a temp file and rename.
+func writeState(path string, state map[string]repoState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-hook-state-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// Commit describes the commit a post-commit hook fired for.
+type Commit struct {
+	Root    string // repository top-level directory
+	Repo    string // repository directory name
+	Branch  string // current branch; empty when detached
+	Hash    string // abbreviated commit hash
+	Subject string // first line of the commit message
+}
+
+// HeadCommit reads the HEAD commit of the repository containing dir.
+func HeadCommit(dir string) (Commit, error) {
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return Commit{}, fmt.Errorf("not a git repository: %s", dir)
+	}
+	out, err := git(dir, "log", "-1", "--format=%h%x00%s")
+	if err != nil {
+		return Commit{}, fmt.Errorf("reading HEAD commit: %w", err)
+	}
+	hash, subject, _ := strings.Cut(out, "\x00")
+	return Commit{
+		Root:    top,
+		Repo:    filepath.Base(top),
+		Branch:  CurrentBranch(dir),
+		Hash:    hash,
+		Subject: subject,
+	}, nil
+}
+
+// CurrentBranch returns the checked-out branch, or "" when HEAD is detached.
+func CurrentBranch(dir string) string {
+	branch, err := git(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil || branch == "HEAD" {
+		return ""
+	}
+	return branch
+}
+
+// Toplevel returns the top-level directory of the repository containing dir,
+// or "" outside a repository.
+func Toplevel(dir string) string {
+	top, err := git(dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return ""
+	}
+	return top
+}
+
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// shellQuote single-quotes s for safe use in a POSIX shell script.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
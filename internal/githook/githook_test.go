@@ -0,0 +1,331 @@
+package githook
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func setupGitRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=test",
+			"GIT_AUTHOR_EMAIL=test@test.com",
+			"GIT_COMMITTER_NAME=test",
+			"GIT_COMMITTER_EMAIL=test@test.com",
+		)
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+	}
+	run("init", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", ".")
+	run("commit", "-m", "initial commit")
+	return dir
+}
+
+func TestInstallUninstall(t *testing.T) {
+	hooksDir := filepath.Join(t.TempDir(), "hooks")
+
+	if err := Install(hooksDir, PostCommit, "/usr/bin/diaryctl"); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	path := filepath.Join(hooksDir, PostCommit)
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("hook not written: %v", err)
+	}
+	if info.Mode()&0111 == 0 {
+		t.Error("hook is not executable")
+	}
+	if !IsManaged(path) {
+		t.Error("expected hook to be managed")
+	}
+
+	// Reinstall is idempotent and does not chain itself
+	if err := Install(hooksDir, PostCommit, "/usr/bin/diaryctl"); err != nil {
+		t.Fatalf("reinstall: %v", err)
+	}
+	if _, err := os.Stat(path + chainedSuffix); !os.IsNotExist(err) {
+		t.Error("reinstall should not chain the managed hook")
+	}
+
+	removed, err := Uninstall(hooksDir, PostCommit)
+	if err != nil || !removed {
+		t.Fatalf("Uninstall: removed=%v err=%v", removed, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("hook should be removed")
+	}
+}
+
+func TestInstallChainsExistingHook(t *testing.T) {
+	hooksDir := t.TempDir()
+	path := filepath.Join(hooksDir, PostCommit)
+	original := "#!/bin/sh\necho original\n"
+	if err := os.WriteFile(path, []byte(original), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Install(hooksDir, PostCommit, "diaryctl"); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	chained, err := os.ReadFile(path + chainedSuffix)
+	if err != nil {
+		t.Fatalf("original hook not preserved: %v", err)
+	}
+	if string(chained) != original {
+		t.Errorf("chained hook changed: %q", chained)
+	}
+
+	if _, err := Uninstall(hooksDir, PostCommit); err != nil {
+		t.Fatalf("Uninstall: %v", err)
+	}
+	restored, err := os.ReadFile(path)
+	if err != nil || string(restored) != original {
+		t.Errorf("original hook not restored: %q, %v", restored, err)
+	}
+}
+
+func TestUninstallLeavesForeignHook(t *testing.T) {
+	hooksDir := t.TempDir()
+	path := filepath.Join(hooksDir, PostCommit)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	removed, err := Uninstall(hooksDir, PostCommit)
+	if err != nil || removed {
+		t.Fatalf("expected no-op, got removed=%v err=%v", removed, err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Error("foreign hook should be untouched")
+	}
+}
+
+func TestScriptQuotesPath(t *testing.T) {
+	s := Script(PostCommit, "/opt/it's here/diaryctl")
+	if !strings.Contains(s, `'/opt/it'\''s here/diaryctl' hook run post-commit`) {
+		t.Errorf("path not quoted:\n%s", s)
+	}
+	if !strings.HasPrefix(s, "#!/bin/sh\n"+marker) {
+		t.Errorf("missing shebang or marker:\n%s", s)
+	}
+}
+
+func TestHooksDirAndHeadCommit(t *testing.T) {
+	dir := setupGitRepo(t)
+
+	hooksDir, err := HooksDir(dir)
+	if err != nil {
+		t.Fatalf("HooksDir: %v", err)
+	}
+	if hooksDir != filepath.Join(dir, ".git", "hooks") {
+		t.Errorf("got hooks dir %q", hooksDir)
+	}
+
+	c, err := HeadCommit(dir)
+	if err != nil {
+		t.Fatalf("HeadCommit: %v", err)
+	}
+	if c.Subject != "initial commit" || c.Branch != "main" || c.Repo != filepath.Base(dir) || c.Hash == "" {
+		t.Errorf("unexpected commit: %+v", c)
+	}
+
+	if _, err := HooksDir(t.TempDir()); err == nil {
+		t.Error("expected error outside a repo")
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	dir := setupGitRepo(t)
+	if Disabled(dir) {
+		t.Fatal("expected enabled by default")
+	}
+	if err := SetDisabled(dir, true); err != nil {
+		t.Fatalf("SetDisabled(true): %v", err)
+	}
+	if !Disabled(dir) {
+		t.Error("expected disabled")
+	}
+	if err := SetDisabled(dir, false); err != nil {
+		t.Fatalf("SetDisabled(false): %v", err)
+	}
+	if Disabled(dir) {
+		t.Error("expected enabled again")
+	}
+	if err := SetDisabled(dir, false); err != nil {
+		t.Errorf("re-enabling should be a no-op: %v", err)
+	}
+}
+
+// texts returns the text of each line.
+func texts(lines []Line) []string {
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		out[i] = l.Text
+	}
+	return out
+}
+
+// jotAll is a jot func that records the lines and writes them all.
+func jotAll(jotted *[][]string) func([]Line) (int, error) {
+	return func(lines []Line) (int, error) {
+		*jotted = append(*jotted, texts(lines))
+		return len(lines), nil
+	}
+}
+
+func TestBatch(t *testing.T) {
+	dataDir := t.TempDir()
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var jotted [][]string
+
+	tests := []struct {
+		name string
+		repo string
+		line string
+		at   time.Time
+		want []string // lines jotted; nil = held back
+	}{
+		{"first jot", "app", "a", base, []string{"a"}},
+		{"within interval", "app", "b", base.Add(30 * time.Second), nil},
+		{"other repo", "lib", "x", base.Add(30 * time.Second), []string{"x"}},
+		{"still within interval", "app", "c", base.Add(50 * time.Second), nil},
+		{"after interval flushes pending", "app", "d", base.Add(2 * time.Minute), []string{"b", "c", "d"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jotted = nil
+			got, err := Batch(dataDir, tt.repo, time.Minute, Line{Text: tt.line, At: tt.at}, jotAll(&jotted))
+			if err != nil {
+				t.Fatalf("Batch: %v", err)
+			}
+			if got != (tt.want != nil) {
+				t.Fatalf("jotted = %v, want %v", got, tt.want != nil)
+			}
+			if tt.want != nil && (len(jotted) != 1 || strings.Join(jotted[0], ",") != strings.Join(tt.want, ",")) {
+				t.Errorf("jotted %v, want %v", jotted, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatch_PendingKeepsTimeAndContexts(t *testing.T) {
+	dataDir := t.TempDir()
+	friday := time.Date(2026, 3, 6, 18, 0, 0, 0, time.UTC)
+	noop := func(lines []Line) (int, error) { return len(lines), nil }
+	if _, err := Batch(dataDir, "app", time.Minute, Line{Text: "a", At: friday}, noop); err != nil {
+		t.Fatal(err)
+	}
+	held := Line{Text: "b", At: friday.Add(20 * time.Second), Contexts: []string{"app", "feature/x"}}
+	if _, err := Batch(dataDir, "app", time.Minute, held, noop); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Line
+	monday := Line{Text: "c", At: friday.AddDate(0, 0, 3), Contexts: []string{"app", "main"}}
+	if _, err := Batch(dataDir, "app", time.Minute, monday, func(lines []Line) (int, error) {
+		got = lines
+		return len(lines), nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || !got[0].At.Equal(held.At) || strings.Join(got[0].Contexts, ",") != "app,feature/x" {
+		t.Errorf("held-back line lost its time or contexts: %+v", got)
+	}
+}
+
+func TestBatch_FailedJotStaysPending(t *testing.T) {
+	dataDir := t.TempDir()
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	_, err := Batch(dataDir, "app", time.Minute, Line{Text: "a", At: base}, func([]Line) (int, error) {
+		return 0, errors.New("disk full")
+	})
+	if err == nil {
+		t.Fatal("expected the jot error")
+	}
+
+	// The failed jot neither stamps the interval nor loses the line
+	var jotted [][]string
+	_, err = Batch(dataDir, "app", time.Minute, Line{Text: "b", At: base.Add(10 * time.Second)}, func(lines []Line) (int, error) {
+		jotted = append(jotted, texts(lines))
+		return 1, errors.New("disk full again")
+	})
+	if err == nil {
+		t.Fatal("expected the jot error")
+	}
+
+	// Only the lines that were not written are retried
+	ok, err := Batch(dataDir, "app", time.Minute, Line{Text: "c", At: base.Add(20 * time.Second)}, jotAll(&jotted))
+	if err != nil || !ok {
+		t.Fatalf("Batch = %v, %v", ok, err)
+	}
+	if len(jotted) != 2 || strings.Join(jotted[0], ",") != "a,b" || strings.Join(jotted[1], ",") != "b,c" {
+		t.Errorf("jotted %v, want [[a b] [b c]]", jotted)
+	}
+}
+
+func TestBatch_Concurrent(t *testing.T) {
+	dataDir := t.TempDir()
+	now := time.Now()
+	var mu sync.Mutex
+	var jotted []string
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := Batch(dataDir, "app", time.Minute, Line{Text: fmt.Sprint(i), At: now}, func(lines []Line) (int, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				jotted = append(jotted, texts(lines)...)
+				return len(lines), nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// Exactly one run jots; the rest are held back for the next one
+	ok, err := Batch(dataDir, "app", time.Minute, Line{Text: "last", At: now.Add(time.Hour)}, func(lines []Line) (int, error) {
+		jotted = append(jotted, texts(lines)...)
+		return len(lines), nil
+	})
+	if err != nil || !ok {
+		t.Fatalf("Batch = %v, %v", ok, err)
+	}
+	if len(jotted) != 9 {
+		t.Errorf("expected every line jotted once, got %v", jotted)
+	}
+}
+
+func TestRewriting(t *testing.T) {
+	dir := setupGitRepo(t)
+	if Rewriting(dir) {
+		t.Fatal("expected no rewrite in progress")
+	}
+	if err := os.Mkdir(filepath.Join(dir, ".git", "rebase-merge"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if !Rewriting(dir) {
+		t.Error("expected rebase to be detected")
+	}
+}
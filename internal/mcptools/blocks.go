@@ -0,0 +1,177 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/block"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetDayHandler returns the handler function for the get_day MCP tool.
+func GetDayHandler(store storage.StorageV2) func(ctx context.Context, req *mcp.CallToolRequest, input GetDayInput) (*mcp.CallToolResult, GetDayOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetDayInput) (*mcp.CallToolResult, GetDayOutput, error) {
+		date, err := dayDate("date", input.Date)
+		if err != nil {
+			return nil, GetDayOutput{}, err
+		}
+		d, err := store.GetDay(date)
+		if err != nil {
+			return nil, GetDayOutput{}, err
+		}
+		out := GetDayOutput{Date: date.Format("2006-01-02"), Blocks: []BlockDetail{}}
+		for _, b := range d.Blocks {
+			out.Blocks = append(out.Blocks, toBlockDetail(b, date))
+		}
+		return nil, out, nil
+	}
+}
+
+// AddBlockHandler returns the handler function for the add_block MCP tool.
+func AddBlockHandler(store storage.StorageV2) func(ctx context.Context, req *mcp.CallToolRequest, input AddBlockInput) (*mcp.CallToolResult, AddBlockOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AddBlockInput) (*mcp.CallToolResult, AddBlockOutput, error) {
+		if strings.TrimSpace(input.Content) == "" {
+			return nil, AddBlockOutput{}, fmt.Errorf("content is required")
+		}
+		date, err := dayDate("date", input.Date)
+		if err != nil {
+			return nil, AddBlockOutput{}, err
+		}
+		if err := validateAttributeKeys(input.Attributes); err != nil {
+			return nil, AddBlockOutput{}, err
+		}
+
+		now := time.Now()
+		b := block.Block{
+			ID:         block.NewID(),
+			Content:    input.Content,
+			CreatedAt:  now,
+			UpdatedAt:  now,
+			Attributes: input.Attributes,
+		}
+		if b.Attributes == nil {
+			b.Attributes = map[string]string{}
+		}
+		if err := store.CreateBlock(date, b); err != nil {
+			return nil, AddBlockOutput{}, fmt.Errorf("creating block: %w", err)
+		}
+		return nil, AddBlockOutput{Block: toBlockDetail(b, date)}, nil
+	}
+}
+
+// UpdateBlockAttributesHandler returns the handler function for the
+// update_block_attributes MCP tool. Content is left unchanged.
+func UpdateBlockAttributesHandler(store storage.StorageV2) func(ctx context.Context, req *mcp.CallToolRequest, input UpdateBlockAttributesInput) (*mcp.CallToolResult, UpdateBlockAttributesOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateBlockAttributesInput) (*mcp.CallToolResult, UpdateBlockAttributesOutput, error) {
+		if len(input.Set) == 0 && len(input.Remove) == 0 {
+			return nil, UpdateBlockAttributesOutput{}, fmt.Errorf("nothing to update: provide set or remove")
+		}
+		if err := validateAttributeKeys(input.Set); err != nil {
+			return nil, UpdateBlockAttributesOutput{}, err
+		}
+		b, date, err := store.GetBlock(input.ID)
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, UpdateBlockAttributesOutput{}, fmt.Errorf("block %q not found", input.ID)
+		}
+		if err != nil {
+			return nil, UpdateBlockAttributesOutput{}, err
+		}
+
+		attrs := make(map[string]string, len(b.Attributes)+len(input.Set))
+		for k, v := range b.Attributes {
+			attrs[k] = v
+		}
+		for _, k := range input.Remove {
+			delete(attrs, k)
+		}
+		for k, v := range input.Set {
+			attrs[k] = v
+		}
+		if err := store.UpdateBlock(b.ID, b.Content, attrs); err != nil {
+			return nil, UpdateBlockAttributesOutput{}, fmt.Errorf("updating block: %w", err)
+		}
+		if b, date, err = store.GetBlock(b.ID); err != nil {
+			return nil, UpdateBlockAttributesOutput{}, err
+		}
+		return nil, UpdateBlockAttributesOutput{Block: toBlockDetail(b, date)}, nil
+	}
+}
+
+// SearchBlocksHandler returns the handler function for the search_blocks MCP tool.
+func SearchBlocksHandler(store storage.StorageV2) func(ctx context.Context, req *mcp.CallToolRequest, input SearchBlocksInput) (*mcp.CallToolResult, SearchBlocksOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SearchBlocksInput) (*mcp.CallToolResult, SearchBlocksOutput, error) {
+		if input.Limit < 0 || input.Offset < 0 {
+			return nil, SearchBlocksOutput{}, fmt.Errorf("limit and offset must not be negative")
+		}
+		opts := storage.SearchOptions{
+			ContentQuery: input.Query,
+			Attributes:   input.Attributes,
+			Limit:        input.Limit,
+			Offset:       input.Offset,
+		}
+		if input.StartDate != "" {
+			t, err := parseStartDate(input.StartDate)
+			if err != nil {
+				err = fmt.Errorf("invalid start_date: %w", err)
+				return nil, SearchBlocksOutput{}, err
+			}
+			opts.StartDate = &t
+		}
+		if input.EndDate != "" {
+			t, err := parseEndDate(input.EndDate)
+			if err != nil {
+				err = fmt.Errorf("invalid end_date: %w", err)
+				return nil, SearchBlocksOutput{}, err
+			}
+			opts.EndDate = &t
+		}
+
+		results, err := store.SearchBlocks(opts)
+		if err != nil {
+			return nil, SearchBlocksOutput{}, err
+		}
+		out := SearchBlocksOutput{Blocks: []BlockDetail{}}
+		for _, r := range results {
+			out.Blocks = append(out.Blocks, toBlockDetail(r.Block, r.Day))
+		}
+		return nil, out, nil
+	}
+}
+
+// dayDate parses an optional day argument, defaulting to today.
+func dayDate(name, s string) (time.Time, error) {
+	if s == "" {
+		return day.Today(), nil
+	}
+	t, err := parseDate(s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return t, nil
+}
+
+func validateAttributeKeys(attrs map[string]string) error {
+	for k := range attrs {
+		if strings.TrimSpace(k) == "" {
+			return fmt.Errorf("attribute keys must not be empty")
+		}
+	}
+	return nil
+}
+
+// toBlockDetail converts a block on the given day to its MCP representation.
+func toBlockDetail(b block.Block, date time.Time) BlockDetail {
+	return BlockDetail{
+		ID:         b.ID,
+		Date:       date.Format("2006-01-02"),
+		CreatedAt:  b.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  b.UpdatedAt.Format(time.RFC3339),
+		Content:    b.Content,
+		Attributes: b.Attributes,
+	}
+}
@@ -0,0 +1,109 @@
+package mcptools_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newTestSessionV2 connects a client to a V2 server over a fresh block store.
+func newTestSessionV2(t *testing.T, opts mcptools.Options) *mcp.ClientSession {
+	t.Helper()
+	store, err := markdown.NewV2(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	server := mcptools.CreateMCPServerV2(store, opts)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(context.Background(), serverTransport, nil); err != nil {
+		t.Fatalf("failed to connect server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return session
+}
+
+func TestMCPServerV2_Blocks(t *testing.T) {
+	session := newTestSessionV2(t, mcptools.Options{})
+
+	var added mcptools.AddBlockOutput
+	if res := callTool(t, session, "add_block", mcptools.AddBlockInput{
+		Content:    "Chose Postgres for the queue",
+		Date:       "2026-03-02",
+		Attributes: map[string]string{"type": "decision", "status": "draft"},
+	}, &added); res.IsError {
+		t.Fatalf("add_block failed: %v", res.Content)
+	}
+	callTool(t, session, "add_block", mcptools.AddBlockInput{Content: "Lunch", Date: "2026-03-02"}, nil)
+	callTool(t, session, "add_block", mcptools.AddBlockInput{Content: "Older decision", Date: "2026-02-20", Attributes: map[string]string{"type": "decision"}}, nil)
+
+	var dayOut mcptools.GetDayOutput
+	callTool(t, session, "get_day", mcptools.GetDayInput{Date: "2026-03-02"}, &dayOut)
+	if len(dayOut.Blocks) != 2 || dayOut.Blocks[0].ID != added.Block.ID {
+		t.Fatalf("unexpected day %+v", dayOut)
+	}
+
+	var updated mcptools.UpdateBlockAttributesOutput
+	callTool(t, session, "update_block_attributes", mcptools.UpdateBlockAttributesInput{
+		ID: added.Block.ID, Set: map[string]string{"project": "queue"}, Remove: []string{"status"},
+	}, &updated)
+	if got := updated.Block.Attributes; len(got) != 2 || got["type"] != "decision" || got["project"] != "queue" {
+		t.Errorf("attributes = %v", got)
+	}
+	if updated.Block.Content != "Chose Postgres for the queue" {
+		t.Errorf("content changed to %q", updated.Block.Content)
+	}
+
+	var found mcptools.SearchBlocksOutput
+	callTool(t, session, "search_blocks", mcptools.SearchBlocksInput{
+		Attributes: map[string]string{"type": "decision"},
+		StartDate:  "2026-03-01",
+		EndDate:    "2026-03-31",
+	}, &found)
+	if len(found.Blocks) != 1 || found.Blocks[0].ID != added.Block.ID {
+		t.Errorf("expected only this month's decision, got %+v", found.Blocks)
+	}
+	callTool(t, session, "search_blocks", mcptools.SearchBlocksInput{Query: "decision"}, &found)
+	if len(found.Blocks) != 1 || found.Blocks[0].Date != "2026-02-20" {
+		t.Errorf("expected content match, got %+v", found.Blocks)
+	}
+
+	for name, args := range map[string]any{
+		"add_block":               mcptools.AddBlockInput{Content: " "},
+		"get_day":                 mcptools.GetDayInput{Date: "March"},
+		"update_block_attributes": mcptools.UpdateBlockAttributesInput{ID: "zzzzzzzz", Set: map[string]string{"a": "b"}},
+		"search_blocks":           mcptools.SearchBlocksInput{EndDate: "2026-02-30"},
+	} {
+		if res := callTool(t, session, name, args, nil); !res.IsError {
+			t.Errorf("%s: expected IsError", name)
+		}
+	}
+}
+
+func TestMCPServerV2_GetDayDefaultsToToday(t *testing.T) {
+	session := newTestSessionV2(t, mcptools.Options{})
+	callTool(t, session, "add_block", mcptools.AddBlockInput{Content: "now"}, nil)
+
+	var out mcptools.GetDayOutput
+	callTool(t, session, "get_day", mcptools.GetDayInput{}, &out)
+	if out.Date != time.Now().Format("2006-01-02") || len(out.Blocks) != 1 {
+		t.Errorf("unexpected day %+v", out)
+	}
+}
+
+func TestMCPServerV2_ReadOnly(t *testing.T) {
+	session := newTestSessionV2(t, mcptools.Options{ReadOnly: true})
+	if got, want := toolNames(t, session), []string{"get_day", "search_blocks"}; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("read-only tools = %v, want %v", got, want)
+	}
+}
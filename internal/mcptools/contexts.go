@@ -0,0 +1,83 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListContextsHandler returns the handler function for the list_contexts MCP tool.
+func ListContextsHandler(store storage.Storage) func(ctx context.Context, req *mcp.CallToolRequest, input ListContextsInput) (*mcp.CallToolResult, ListContextsOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListContextsInput) (*mcp.CallToolResult, ListContextsOutput, error) {
+		limit := input.Limit
+		if limit <= 0 {
+			limit = 50
+		}
+
+		contexts, err := store.ListContexts()
+		if err != nil {
+			return nil, ListContextsOutput{}, err
+		}
+		if len(contexts) > limit {
+			contexts = contexts[:limit]
+		}
+
+		results := []ContextResult{}
+		for _, c := range contexts {
+			results = append(results, ContextResult{ID: c.ID, Name: c.Name, Source: c.Source})
+		}
+		return nil, ListContextsOutput{Contexts: results}, nil
+	}
+}
+
+// AttachContextHandler returns the handler function for the attach_context MCP tool.
+func AttachContextHandler(store storage.Storage, dataDir string) func(ctx context.Context, req *mcp.CallToolRequest, input AttachContextInput) (*mcp.CallToolResult, AttachContextOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input AttachContextInput) (*mcp.CallToolResult, AttachContextOutput, error) {
+		e, err := getEntry(store, input.EntryID)
+		if err != nil {
+			return nil, AttachContextOutput{}, err
+		}
+
+		c, err := getOrCreateContext(store, input.ContextName)
+		if err != nil {
+			return nil, AttachContextOutput{}, err
+		}
+		if err := store.AttachContext(e.ID, c.ID); err != nil {
+			return nil, AttachContextOutput{}, err
+		}
+		invalidateCache(dataDir)
+
+		updated, err := store.Get(e.ID)
+		if err != nil {
+			return nil, AttachContextOutput{}, err
+		}
+		return nil, AttachContextOutput{EntryID: e.ID, Contexts: toEntryDetail(updated).Contexts}, nil
+	}
+}
+
+// getOrCreateContext looks up a context by name, creating it as a manual
+// context if it does not exist.
+func getOrCreateContext(store storage.Storage, name string) (storage.Context, error) {
+	if err := entry.ValidateContextName(name); err != nil {
+		return storage.Context{}, err
+	}
+	c, err := store.GetContextByName(name)
+	if err == nil || !errors.Is(err, storage.ErrNotFound) {
+		return c, err
+	}
+	id, err := entry.NewID()
+	if err != nil {
+		return storage.Context{}, err
+	}
+	now := time.Now().UTC()
+	c = storage.Context{ID: id, Name: name, Source: "manual", CreatedAt: now, UpdatedAt: now}
+	if err := store.CreateContext(c); err != nil {
+		return storage.Context{}, fmt.Errorf("creating context %q: %w", name, err)
+	}
+	return c, nil
+}
@@ -0,0 +1,38 @@
+package mcptools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+)
+
+func TestMCPServer_AttachAndListContexts(t *testing.T) {
+	store, session := newTestSession(t)
+	now := time.Now()
+	if err := store.Create(entry.Entry{ID: "attach01", Content: "work notes", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+
+	var attached mcptools.AttachContextOutput
+	callTool(t, session, "attach_context", mcptools.AttachContextInput{EntryID: "attach01", ContextName: "feature/auth"}, &attached)
+	if len(attached.Contexts) != 1 || attached.Contexts[0] != "feature/auth" {
+		t.Errorf("contexts = %v, want [feature/auth]", attached.Contexts)
+	}
+
+	var list mcptools.ListContextsOutput
+	callTool(t, session, "list_contexts", mcptools.ListContextsInput{}, &list)
+	if len(list.Contexts) != 1 || list.Contexts[0].Name != "feature/auth" || list.Contexts[0].Source != "manual" {
+		t.Errorf("unexpected contexts %+v", list.Contexts)
+	}
+
+	result := callTool(t, session, "attach_context", mcptools.AttachContextInput{EntryID: "attach01", ContextName: "bad name"}, nil)
+	if !result.IsError {
+		t.Error("expected IsError for invalid context name")
+	}
+	result = callTool(t, session, "attach_context", mcptools.AttachContextInput{EntryID: "missing1", ContextName: "ok"}, nil)
+	if !result.IsError {
+		t.Error("expected IsError for unknown entry")
+	}
+}
@@ -2,7 +2,10 @@ package mcptools
 
 import (
 	"context"
+	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/shell"
 	"github.com/chris-regnier/diaryctl/internal/storage"
@@ -11,7 +14,9 @@ import (
 )
 
 // CreateEntryHandler returns the handler function for the create_entry MCP tool.
-func CreateEntryHandler(store storage.Storage, dataDir string) func(ctx context.Context, req *mcp.CallToolRequest, input CreateEntryInput) (*mcp.CallToolResult, CreateEntryOutput, error) {
+// Templates are rendered with their variable defaults filled in and, with
+// opts.StrictTemplates, fail on variables that have no value.
+func CreateEntryHandler(store storage.Storage, opts Options) func(ctx context.Context, req *mcp.CallToolRequest, input CreateEntryInput) (*mcp.CallToolResult, CreateEntryOutput, error) {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input CreateEntryInput) (*mcp.CallToolResult, CreateEntryOutput, error) {
 		var content string
 		var refs []entry.TemplateRef
@@ -24,14 +29,34 @@ func CreateEntryHandler(store storage.Storage, dataDir string) func(ctx context.
 			}
 			refs = templateRefs
 
-			// Render variables if specified
-			if len(input.TemplateVariables) > 0 {
-				composed, err = template.Render(composed, input.TemplateVariables)
-				if err != nil {
-					return nil, CreateEntryOutput{}, err
+			vars, err := template.Variables(store, input.TemplateNames)
+			if err != nil {
+				return nil, CreateEntryOutput{}, err
+			}
+			answers := map[string]string{}
+			for k, v := range input.TemplateVariables {
+				answers[k] = v
+			}
+			for _, v := range vars {
+				if _, ok := answers[v.Name]; !ok && v.Default != "" {
+					answers[v.Name] = v.Default
 				}
 			}
 
+			// Render variables and template functions
+			now := time.Now()
+			composed, err = template.RenderWith(composed, template.RenderOptions{
+				Vars: answers,
+				Now:  now,
+				Yesterday: func() string {
+					return daily.ContentOn(store, day.Of(now).AddDate(0, 0, -1))
+				},
+				Strict: opts.StrictTemplates,
+			})
+			if err != nil {
+				return nil, CreateEntryOutput{}, err
+			}
+
 			content = composed + "\n\n" + input.Content
 		} else {
 			content = input.Content
@@ -48,13 +73,13 @@ func CreateEntryHandler(store storage.Storage, dataDir string) func(ctx context.
 		}
 
 		// Invalidate shell prompt cache (best-effort)
-		if dataDir != "" {
-			_ = shell.InvalidateCache(dataDir)
+		if opts.DataDir != "" {
+			_ = shell.InvalidateCache(opts.DataDir)
 		}
 
 		return nil, CreateEntryOutput{
 			ID:      e.ID,
-			Date:    e.CreatedAt.Format("2006-01-02"),
+			Date:    day.OfZone(e.CreatedAt, e.TimeZone).Format("2006-01-02"),
 			Preview: e.Preview(200),
 		}, nil
 	}
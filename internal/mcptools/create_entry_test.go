@@ -138,3 +138,52 @@ func TestMCPServer_CreateEntry(t *testing.T) {
 		}
 	})
 }
+
+func TestMCPServer_CreateEntryStrictTemplates(t *testing.T) {
+	store, session := newTestSessionWithOptions(t, mcptools.Options{StrictTemplates: true})
+	tmpl := storage.Template{
+		ID:        "tmpl0002",
+		Name:      "checkin",
+		Content:   "---\nvariables:\n  - name: focus\n    default: deep work\n  - name: mood\n---\nFocus: {{.focus}}\nMood: {{.mood}}\n",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := store.CreateTemplate(tmpl); err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+
+	call := func(vars map[string]string) *mcp.CallToolResult {
+		t.Helper()
+		result, err := session.CallTool(context.Background(), &mcp.CallToolParams{
+			Name:      "create_entry",
+			Arguments: mcptools.CreateEntryInput{Content: "notes", TemplateNames: []string{"checkin"}, TemplateVariables: vars},
+		})
+		if err != nil {
+			t.Fatalf("CallTool failed: %v", err)
+		}
+		return result
+	}
+
+	if result := call(nil); !result.IsError {
+		t.Error("expected IsError for a variable without a value")
+	}
+	entries, _ := store.List(storage.ListOptions{})
+	if len(entries) != 0 {
+		t.Errorf("expected no entry to be written, got %d", len(entries))
+	}
+
+	result := call(map[string]string{"mood": "good"})
+	if result.IsError {
+		t.Fatalf("unexpected error: %+v", result.Content)
+	}
+	var output mcptools.CreateEntryOutput
+	outputJSON, _ := json.Marshal(result.StructuredContent)
+	_ = json.Unmarshal(outputJSON, &output)
+	e, err := store.Get(output.ID)
+	if err != nil {
+		t.Fatalf("entry not found: %v", err)
+	}
+	if !strings.Contains(e.Content, "Focus: deep work") || !strings.Contains(e.Content, "Mood: good") {
+		t.Errorf("expected defaults and answers rendered, got:\n%s", e.Content)
+	}
+}
@@ -0,0 +1,73 @@
+package mcptools
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// DeleteEntryHandler returns the handler function for the delete_entry MCP
+// tool. Deleting takes two calls: the first returns a preview and a
+// confirmation token, and only a second call with that token deletes.
+func DeleteEntryHandler(store storage.Storage, opts Options) func(ctx context.Context, req *mcp.CallToolRequest, input DeleteEntryInput) (*mcp.CallToolResult, DeleteEntryOutput, error) {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+
+	return func(ctx context.Context, req *mcp.CallToolRequest, input DeleteEntryInput) (*mcp.CallToolResult, DeleteEntryOutput, error) {
+		e, err := getEntry(store, input.ID)
+		if err != nil {
+			return nil, DeleteEntryOutput{}, err
+		}
+		token := deleteToken(key, e)
+
+		if input.ConfirmToken == "" {
+			return nil, DeleteEntryOutput{
+				ConfirmToken: token,
+				Preview:      e.Preview(200),
+				Message:      "Not deleted yet. Call delete_entry again with this confirm_token to delete the entry.",
+			}, nil
+		}
+		if !hmac.Equal([]byte(input.ConfirmToken), []byte(token)) {
+			return nil, DeleteEntryOutput{}, fmt.Errorf("invalid or stale confirm_token for entry %q; call delete_entry without one to get a new token", e.ID)
+		}
+
+		if err := store.Delete(e.ID); err != nil {
+			return nil, DeleteEntryOutput{}, err
+		}
+		invalidateCache(opts.DataDir)
+
+		message := fmt.Sprintf("Deleted entry %s.", e.ID)
+		if opts.AfterDelete != nil {
+			if err := opts.AfterDelete(e.ID); err != nil {
+				message += fmt.Sprintf(" Warning: %v", err)
+			}
+		}
+		return nil, DeleteEntryOutput{
+			Deleted: true,
+			Date:    day.OfZone(e.CreatedAt, e.TimeZone).Format("2006-01-02"),
+			Preview: e.Preview(200),
+			Message: message,
+		}, nil
+	}
+}
+
+// deleteToken derives the confirmation token for deleting e. It covers the
+// entry's content as well as its update time, so it changes with any edit,
+// even one made within the same second, and a confirmation cannot delete
+// content that was not previewed.
+func deleteToken(key []byte, e entry.Entry) string {
+	content := sha256.Sum256([]byte(e.Content))
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(e.ID + "|" + e.UpdatedAt.UTC().Format(time.RFC3339Nano) + "|"))
+	mac.Write(content[:])
+	return hex.EncodeToString(mac.Sum(nil))[:16]
+}
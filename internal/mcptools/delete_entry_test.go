@@ -0,0 +1,135 @@
+package mcptools_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+)
+
+func TestMCPServer_DeleteEntry(t *testing.T) {
+	store, session := newTestSession(t)
+	now := time.Now()
+	if err := store.Create(entry.Entry{ID: "delete01", Content: "to be removed", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+
+	var preview mcptools.DeleteEntryOutput
+	callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "delete01"}, &preview)
+	if preview.Deleted || preview.ConfirmToken == "" {
+		t.Fatalf("expected a confirmation token without deleting, got %+v", preview)
+	}
+	if preview.Preview != "to be removed" {
+		t.Errorf("preview = %q", preview.Preview)
+	}
+	if _, err := store.Get("delete01"); err != nil {
+		t.Fatalf("entry should still exist: %v", err)
+	}
+
+	result := callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "delete01", ConfirmToken: "bogus"}, nil)
+	if !result.IsError {
+		t.Error("expected IsError for a wrong token")
+	}
+
+	var deleted mcptools.DeleteEntryOutput
+	callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "delete01", ConfirmToken: preview.ConfirmToken}, &deleted)
+	if !deleted.Deleted {
+		t.Errorf("expected entry to be deleted, got %+v", deleted)
+	}
+	if _, err := store.Get("delete01"); !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+}
+
+func TestMCPServer_DeleteEntryStaleToken(t *testing.T) {
+	store, session := newTestSession(t)
+	now := time.Now().Add(-time.Hour)
+	if err := store.Create(entry.Entry{ID: "delete02", Content: "v1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+
+	var preview mcptools.DeleteEntryOutput
+	callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "delete02"}, &preview)
+
+	// Editing the entry after the preview invalidates the token
+	if _, err := store.Update("delete02", "v2", nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	result := callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "delete02", ConfirmToken: preview.ConfirmToken}, nil)
+	if !result.IsError {
+		t.Error("expected IsError for a stale token")
+	}
+	if _, err := store.Get("delete02"); err != nil {
+		t.Errorf("entry should not be deleted: %v", err)
+	}
+}
+
+// sameSecondStore reports every entry as last updated at the same instant,
+// as a store keeping updated_at to the second does for edits within it.
+type sameSecondStore struct {
+	storage.Storage
+	at time.Time
+}
+
+func (s sameSecondStore) Get(id string) (entry.Entry, error) {
+	e, err := s.Storage.Get(id)
+	e.UpdatedAt = s.at
+	return e, err
+}
+
+func TestDeleteEntryHandler_SameSecondEdit(t *testing.T) {
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	now := time.Now().UTC().Truncate(time.Second)
+	if err := store.Create(entry.Entry{ID: "delete04", Content: "v1", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+	handler := mcptools.DeleteEntryHandler(sameSecondStore{Storage: store, at: now}, mcptools.Options{})
+
+	_, preview, err := handler(context.Background(), nil, mcptools.DeleteEntryInput{ID: "delete04"})
+	if err != nil {
+		t.Fatalf("preview: %v", err)
+	}
+	if _, err := store.Update("delete04", "v2 with more to lose", nil); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if _, _, err := handler(context.Background(), nil, mcptools.DeleteEntryInput{ID: "delete04", ConfirmToken: preview.ConfirmToken}); err == nil {
+		t.Error("expected a stale token error after an edit within the same second")
+	}
+	if _, err := store.Get("delete04"); err != nil {
+		t.Errorf("entry should not be deleted: %v", err)
+	}
+}
+
+func TestMCPServer_DeleteEntryAfterDelete(t *testing.T) {
+	var dropped []string
+	store, session := newTestSessionWithOptions(t, mcptools.Options{
+		AfterDelete: func(entryID string) error {
+			dropped = append(dropped, entryID)
+			return nil
+		},
+	})
+	now := time.Now()
+	if err := store.Create(entry.Entry{ID: "delete03", Content: "with suggestions", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+
+	var preview mcptools.DeleteEntryOutput
+	callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "delete03"}, &preview)
+	if len(dropped) != 0 {
+		t.Fatalf("AfterDelete ran before confirmation: %v", dropped)
+	}
+	var deleted mcptools.DeleteEntryOutput
+	callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "delete03", ConfirmToken: preview.ConfirmToken}, &deleted)
+	if !deleted.Deleted || len(dropped) != 1 || dropped[0] != "delete03" {
+		t.Errorf("expected AfterDelete for delete03, got %v (output %+v)", dropped, deleted)
+	}
+}
@@ -2,50 +2,168 @@ package mcptools
 
 import (
 	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
 
 // FilterHandler returns the handler function for the filter_entries MCP tool.
+// Entries match when they use any of the given templates and are tagged with
+// any of the given contexts. Results are ordered newest first, with ties
+// broken by ID, and paged with an opaque cursor that stays stable when
+// entries are added. Later pages pass the cursor down to storage as an upper
+// bound and carry the total counted on the first page.
 func FilterHandler(store storage.Storage) func(ctx context.Context, req *mcp.CallToolRequest, input FilterInput) (*mcp.CallToolResult, FilterOutput, error) {
 	return func(ctx context.Context, req *mcp.CallToolRequest, input FilterInput) (*mcp.CallToolResult, FilterOutput, error) {
-		opts := storage.ListOptions{
-			Limit: input.Limit,
+		if input.Limit < 0 {
+			return nil, FilterOutput{}, fmt.Errorf("invalid limit %d: must not be negative", input.Limit)
 		}
-
+		opts := storage.ListOptions{}
 		if input.StartDate != "" {
-			t, err := parseDate(input.StartDate)
-			if err == nil {
-				opts.StartDate = &t
+			t, err := parseStartDate(input.StartDate)
+			if err != nil {
+				return nil, FilterOutput{}, fmt.Errorf("invalid start_date: %w", err)
 			}
+			opts.StartDate = &t
 		}
 		if input.EndDate != "" {
-			t, err := parseDate(input.EndDate)
-			if err == nil {
-				opts.EndDate = &t
+			t, err := parseEndDate(input.EndDate)
+			if err != nil {
+				return nil, FilterOutput{}, fmt.Errorf("invalid end_date: %w", err)
 			}
+			opts.EndDate = &t
+		}
+		if opts.StartDate != nil && opts.EndDate != nil && opts.EndDate.Before(*opts.StartDate) {
+			return nil, FilterOutput{}, fmt.Errorf("end_date %s is before start_date %s", input.EndDate, input.StartDate)
+		}
+		var after *filterCursor
+		if input.Cursor != "" {
+			c, err := decodeFilterCursor(input.Cursor)
+			if err != nil {
+				return nil, FilterOutput{}, err
+			}
+			after = &c
+			opts.Until = &c.createdAt
 		}
 
-		if len(input.TemplateNames) > 0 {
+		// A single template or context can be filtered by storage directly
+		if len(input.TemplateNames) == 1 {
 			opts.TemplateName = input.TemplateNames[0]
 		}
-
+		if len(input.ContextNames) == 1 {
+			opts.ContextName = input.ContextNames[0]
+		}
 		entries, err := store.List(opts)
 		if err != nil {
 			return nil, FilterOutput{}, err
 		}
 
-		var results []EntryResult
+		var matched []entry.Entry
 		for _, e := range entries {
-			results = append(results, EntryResult{
+			if matchesAny(input.TemplateNames, e.Templates, func(r entry.TemplateRef) string { return r.TemplateName }) &&
+				matchesAny(input.ContextNames, e.Contexts, func(r entry.ContextRef) string { return r.ContextName }) {
+				matched = append(matched, e)
+			}
+		}
+		sort.SliceStable(matched, func(i, j int) bool {
+			return filterCursorOf(matched[i]).before(filterCursorOf(matched[j]))
+		})
+
+		out := FilterOutput{Entries: []EntryResult{}, Total: len(matched)}
+		start := 0
+		if after != nil {
+			out.Total = after.total
+			start = sort.Search(len(matched), func(i int) bool {
+				return after.before(filterCursorOf(matched[i]))
+			})
+		}
+		page := matched[start:]
+		if input.Limit > 0 && len(page) > input.Limit {
+			page = page[:input.Limit]
+			next := filterCursorOf(page[len(page)-1])
+			next.total = out.Total
+			out.NextCursor = next.encode()
+		}
+		for _, e := range page {
+			out.Entries = append(out.Entries, EntryResult{
 				ID:      e.ID,
 				Preview: e.Preview(100),
-				Date:    e.CreatedAt.Format("2006-01-02"),
+				Date:    day.OfZone(e.CreatedAt, e.TimeZone).Format("2006-01-02"),
 				Score:   1.0,
 			})
 		}
 
-		return nil, FilterOutput{Entries: results}, nil
+		return nil, out, nil
+	}
+}
+
+// matchesAny reports whether any ref's name is in names; an empty names
+// matches everything.
+func matchesAny[R any](names []string, refs []R, name func(R) string) bool {
+	if len(names) == 0 {
+		return true
+	}
+	for _, r := range refs {
+		for _, n := range names {
+			if name(r) == n {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// filterCursor is the position of the last entry on a page, plus the total
+// number of matches counted on the first page.
+type filterCursor struct {
+	createdAt time.Time
+	id        string
+	total     int
+}
+
+func filterCursorOf(e entry.Entry) filterCursor {
+	return filterCursor{createdAt: e.CreatedAt, id: e.ID}
+}
+
+// before reports whether c sorts ahead of other: newest first, then by ID
+// descending.
+func (c filterCursor) before(other filterCursor) bool {
+	if !c.createdAt.Equal(other.createdAt) {
+		return c.createdAt.After(other.createdAt)
+	}
+	return c.id > other.id
+}
+
+func (c filterCursor) encode() string {
+	raw := c.createdAt.UTC().Format(time.RFC3339Nano) + "|" + c.id + "|" + strconv.Itoa(c.total)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFilterCursor(s string) (filterCursor, error) {
+	invalid := fmt.Errorf("invalid cursor %q: use next_cursor from a previous call", s)
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return filterCursor{}, invalid
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 || parts[1] == "" {
+		return filterCursor{}, invalid
+	}
+	t, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return filterCursor{}, invalid
+	}
+	total, err := strconv.Atoi(parts[2])
+	if err != nil || total < 0 {
+		return filterCursor{}, invalid
 	}
+	return filterCursor{createdAt: t, id: parts[1], total: total}, nil
 }
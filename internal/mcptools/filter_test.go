@@ -0,0 +1,138 @@
+package mcptools_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+)
+
+func TestMCPServer_FilterEntries_TemplatesAndContexts(t *testing.T) {
+	store, session := newTestSession(t)
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	ref := func(name string) []entry.TemplateRef {
+		return []entry.TemplateRef{{TemplateID: "t-" + name, TemplateName: name}}
+	}
+	entries := []entry.Entry{
+		{ID: "filt0001", Content: "standup", Templates: ref("standup")},
+		{ID: "filt0002", Content: "retro", Templates: ref("retro")},
+		{ID: "filt0003", Content: "plain"},
+	}
+	for i, e := range entries {
+		e.CreatedAt = day.Add(time.Duration(i) * time.Hour)
+		e.UpdatedAt = e.CreatedAt
+		if err := store.Create(e); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+	}
+	callTool(t, session, "attach_context", mcptools.AttachContextInput{EntryID: "filt0001", ContextName: "work"}, nil)
+	callTool(t, session, "attach_context", mcptools.AttachContextInput{EntryID: "filt0003", ContextName: "home"}, nil)
+
+	ids := func(out mcptools.FilterOutput) string {
+		var s []string
+		for _, e := range out.Entries {
+			s = append(s, e.ID)
+		}
+		return fmt.Sprint(s)
+	}
+	tests := []struct {
+		name  string
+		input mcptools.FilterInput
+		want  string
+	}{
+		{"templates are ORed", mcptools.FilterInput{TemplateNames: []string{"standup", "retro"}}, "[filt0002 filt0001]"},
+		{"contexts are ORed", mcptools.FilterInput{ContextNames: []string{"work", "home"}}, "[filt0003 filt0001]"},
+		{"templates and contexts combine", mcptools.FilterInput{TemplateNames: []string{"standup", "retro"}, ContextNames: []string{"work"}}, "[filt0001]"},
+		{"single context", mcptools.FilterInput{ContextNames: []string{"home"}}, "[filt0003]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out mcptools.FilterOutput
+			if res := callTool(t, session, "filter_entries", tt.input, &out); res.IsError {
+				t.Fatalf("filter_entries failed: %v", res.Content)
+			}
+			if got := ids(out); got != tt.want {
+				t.Errorf("entries = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMCPServer_FilterEntries_Pagination(t *testing.T) {
+	store, session := newTestSession(t)
+	// Entries sharing a timestamp are ordered by ID
+	at := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	for i := 0; i < 5; i++ {
+		created := at
+		if i >= 3 {
+			created = at.Add(time.Hour)
+		}
+		e := entry.Entry{ID: fmt.Sprintf("page%04d", i), Content: "x", CreatedAt: created, UpdatedAt: created}
+		if err := store.Create(e); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+	}
+
+	var got []string
+	input := mcptools.FilterInput{Limit: 2}
+	for pages := 0; ; pages++ {
+		if pages > 5 {
+			t.Fatal("pagination did not terminate")
+		}
+		var out mcptools.FilterOutput
+		if res := callTool(t, session, "filter_entries", input, &out); res.IsError {
+			t.Fatalf("filter_entries failed: %v", res.Content)
+		}
+		if out.Total != 5 {
+			t.Errorf("total = %d, want 5", out.Total)
+		}
+		for _, e := range out.Entries {
+			got = append(got, e.ID)
+		}
+		if out.NextCursor == "" {
+			break
+		}
+		input.Cursor = out.NextCursor
+	}
+	if want := "[page0004 page0003 page0002 page0001 page0000]"; fmt.Sprint(got) != want {
+		t.Errorf("paged entries = %v, want %s", got, want)
+	}
+}
+
+func TestMCPServer_FilterEntries_Invalid(t *testing.T) {
+	_, session := newTestSession(t)
+	for name, input := range map[string]mcptools.FilterInput{
+		"start date": {StartDate: "someday"},
+		"end date":   {EndDate: "2026-13-01"},
+		"range":      {StartDate: "2026-03-02", EndDate: "2026-03-01"},
+		"cursor":     {Cursor: "not-a-cursor"},
+		"limit":      {Limit: -1},
+	} {
+		if res := callTool(t, session, "filter_entries", input, nil); !res.IsError {
+			t.Errorf("%s: expected IsError", name)
+		}
+	}
+}
+
+func TestMCPServer_EntryDatesUseEntryZone(t *testing.T) {
+	store, session := newTestSession(t)
+	// 23:30 UTC on March 2 is 12:30 on March 3 in Auckland.
+	at := time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC)
+	if err := store.Create(entry.Entry{ID: "nz000001", Content: "written in Auckland", CreatedAt: at, UpdatedAt: at, TimeZone: "Pacific/Auckland"}); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+
+	var filtered mcptools.FilterOutput
+	callTool(t, session, "filter_entries", mcptools.FilterInput{}, &filtered)
+	if len(filtered.Entries) != 1 || filtered.Entries[0].Date != "2026-03-03" {
+		t.Errorf("filter_entries = %+v, want one entry dated 2026-03-03", filtered.Entries)
+	}
+
+	var found mcptools.SearchOutput
+	callTool(t, session, "search_entries", mcptools.SearchInput{Query: "Auckland", Limit: 5}, &found)
+	if len(found.Entries) != 1 || found.Entries[0].Date != "2026-03-03" {
+		t.Errorf("search_entries = %+v, want one entry dated 2026-03-03", found.Entries)
+	}
+}
@@ -0,0 +1,31 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// GetEntryHandler returns the handler function for the get_entry MCP tool.
+func GetEntryHandler(store storage.Storage) func(ctx context.Context, req *mcp.CallToolRequest, input GetEntryInput) (*mcp.CallToolResult, GetEntryOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input GetEntryInput) (*mcp.CallToolResult, GetEntryOutput, error) {
+		e, err := getEntry(store, input.ID)
+		if err != nil {
+			return nil, GetEntryOutput{}, err
+		}
+		return nil, GetEntryOutput{Entry: toEntryDetail(e)}, nil
+	}
+}
+
+// getEntry loads an entry, reporting unknown IDs in terms an assistant can act on.
+func getEntry(store storage.Storage, id string) (entry.Entry, error) {
+	e, err := store.Get(id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return entry.Entry{}, fmt.Errorf("entry %q not found", id)
+	}
+	return e, err
+}
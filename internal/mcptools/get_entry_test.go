@@ -0,0 +1,41 @@
+package mcptools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+)
+
+func TestMCPServer_GetEntry(t *testing.T) {
+	store, session := newTestSession(t)
+	now := time.Now()
+	e := entry.Entry{
+		ID:        "getent01",
+		Content:   "# Today\n\nA long entry with more than a preview's worth of text.",
+		CreatedAt: now,
+		UpdatedAt: now,
+		Templates: []entry.TemplateRef{{TemplateID: "t1", TemplateName: "daily"}},
+	}
+	if err := store.Create(e); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+
+	var output mcptools.GetEntryOutput
+	callTool(t, session, "get_entry", mcptools.GetEntryInput{ID: "getent01"}, &output)
+	if output.Entry.Content != e.Content {
+		t.Errorf("content = %q, want %q", output.Entry.Content, e.Content)
+	}
+	if len(output.Entry.Templates) != 1 || output.Entry.Templates[0] != "daily" {
+		t.Errorf("templates = %v, want [daily]", output.Entry.Templates)
+	}
+	if output.Entry.Date != now.Format("2006-01-02") {
+		t.Errorf("date = %q", output.Entry.Date)
+	}
+
+	result := callTool(t, session, "get_entry", mcptools.GetEntryInput{ID: "missing1"}, nil)
+	if !result.IsError {
+		t.Error("expected IsError for unknown entry")
+	}
+}
@@ -1,13 +1,31 @@
 package mcptools
 
-import "time"
+import (
+	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/dateparse"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/shell"
+)
+
+// parseDate resolves a day such as "2026-03-05", "yesterday" or "last monday".
 func parseDate(s string) (time.Time, error) {
-	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
-	if err != nil {
-		return time.Time{}, err
-	}
-	return t, nil
+	return dateparse.ParseDay(s)
+}
+
+// parseStartDate resolves a lower date bound; periods such as "last week"
+// start on their first day.
+func parseStartDate(s string) (time.Time, error) {
+	r, err := dateparse.ParseRange(s)
+	return r.Start, err
+}
+
+// parseEndDate resolves an upper date bound; periods such as "last week" end
+// on their last day.
+func parseEndDate(s string) (time.Time, error) {
+	r, err := dateparse.ParseRange(s)
+	return r.End, err
 }
 
 func truncate(s string, maxLen int) string {
@@ -16,3 +34,28 @@ func truncate(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// toEntryDetail converts an entry to its full MCP representation.
+func toEntryDetail(e entry.Entry) EntryDetail {
+	d := EntryDetail{
+		ID:        e.ID,
+		Date:      day.OfZone(e.CreatedAt, e.TimeZone).Format("2006-01-02"),
+		CreatedAt: e.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: e.UpdatedAt.Format(time.RFC3339),
+		Content:   e.Content,
+	}
+	for _, ref := range e.Templates {
+		d.Templates = append(d.Templates, ref.TemplateName)
+	}
+	for _, ref := range e.Contexts {
+		d.Contexts = append(d.Contexts, ref.ContextName)
+	}
+	return d
+}
+
+// invalidateCache refreshes the shell prompt cache after a write (best-effort).
+func invalidateCache(dataDir string) {
+	if dataDir != "" {
+		_ = shell.InvalidateCache(dataDir)
+	}
+}
@@ -0,0 +1,72 @@
+package mcptools_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// newTestSession starts an in-memory server over a fresh markdown store and
+// connects a client to it.
+func newTestSession(t *testing.T) (storage.Storage, *mcp.ClientSession) {
+	t.Helper()
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	_, clientTransport := mcptools.NewDiaryMCPServer(store)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return store, session
+}
+
+// newTestSessionWithOptions is newTestSession for a server configured by opts.
+func newTestSessionWithOptions(t *testing.T, opts mcptools.Options) (storage.Storage, *mcp.ClientSession) {
+	t.Helper()
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	server := mcptools.CreateMCPServerWithOptions(store, opts)
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(context.Background(), serverTransport, nil); err != nil {
+		t.Fatalf("failed to connect server: %v", err)
+	}
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	t.Cleanup(func() { session.Close() })
+	return store, session
+}
+
+// callTool calls a tool and decodes its structured output into out. It
+// returns the raw result so callers can check IsError.
+func callTool(t *testing.T, session *mcp.ClientSession, name string, args any, out any) *mcp.CallToolResult {
+	t.Helper()
+	result, err := session.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	if err != nil {
+		t.Fatalf("CallTool(%s) failed: %v", name, err)
+	}
+	if !result.IsError && out != nil {
+		outputJSON, _ := json.Marshal(result.StructuredContent)
+		if err := json.Unmarshal(outputJSON, out); err != nil {
+			t.Fatalf("failed to unmarshal %s output: %v", name, err)
+		}
+	}
+	return result
+}
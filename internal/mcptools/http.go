@@ -0,0 +1,36 @@
+package mcptools
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/auth"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// tokenLifetime is reported as the expiry of a valid static token; the SDK
+// rejects tokens without one.
+const tokenLifetime = 24 * time.Hour
+
+// NewHTTPHandler serves server over the streamable HTTP transport. Every
+// session shares the server, so resource notifications reach all clients.
+// Requests must carry "Authorization: Bearer <token>"; token must not be
+// empty.
+func NewHTTPHandler(server *mcp.Server, token string) http.Handler {
+	handler := mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil)
+	return auth.RequireBearerToken(staticTokenVerifier(token), nil)(handler)
+}
+
+// staticTokenVerifier accepts only the configured token, compared in
+// constant time.
+func staticTokenVerifier(token string) auth.TokenVerifier {
+	return func(_ context.Context, got string, _ *http.Request) (*auth.TokenInfo, error) {
+		if token == "" || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return nil, fmt.Errorf("%w: unknown token", auth.ErrInvalidToken)
+		}
+		return &auth.TokenInfo{Expiration: time.Now().Add(tokenLifetime)}, nil
+	}
+}
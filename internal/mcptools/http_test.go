@@ -0,0 +1,124 @@
+package mcptools_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+type bearerTransport struct {
+	token string
+}
+
+func (b bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+b.token)
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// newHTTPSession serves a fresh store over HTTP and connects a client that
+// authenticates with token.
+func newHTTPSession(t *testing.T, opts mcptools.Options, token string) (*mcp.ClientSession, error) {
+	t.Helper()
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	server := mcptools.CreateMCPServerWithOptions(store, opts)
+	ts := httptest.NewServer(mcptools.NewHTTPHandler(server, "s3cret"))
+	t.Cleanup(ts.Close)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), &mcp.StreamableClientTransport{
+		Endpoint:   ts.URL,
+		HTTPClient: &http.Client{Transport: bearerTransport{token: token}},
+		MaxRetries: -1,
+	}, nil)
+	if err == nil {
+		t.Cleanup(func() { session.Close() })
+	}
+	return session, err
+}
+
+func toolNames(t *testing.T, session *mcp.ClientSession) []string {
+	t.Helper()
+	res, err := session.ListTools(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListTools: %v", err)
+	}
+	var names []string
+	for _, tool := range res.Tools {
+		names = append(names, tool.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestHTTPHandler(t *testing.T) {
+	session, err := newHTTPSession(t, mcptools.Options{}, "s3cret")
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	var jot mcptools.JotOutput
+	if res := callTool(t, session, "jot", mcptools.JotInput{Content: "over http"}, &jot); res.IsError {
+		t.Fatalf("jot failed: %v", res.Content)
+	}
+	var got mcptools.GetEntryOutput
+	callTool(t, session, "get_entry", mcptools.GetEntryInput{ID: jot.EntryID}, &got)
+	if !strings.Contains(got.Entry.Content, "over http") {
+		t.Errorf("expected jotted content, got %q", got.Entry.Content)
+	}
+}
+
+func TestHTTPHandler_RejectsBadToken(t *testing.T) {
+	for _, token := range []string{"", "wrong"} {
+		if _, err := newHTTPSession(t, mcptools.Options{}, token); err == nil {
+			t.Errorf("token %q: expected connect to fail", token)
+		}
+	}
+}
+
+func TestHTTPHandler_Unauthorized(t *testing.T) {
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+	ts := httptest.NewServer(mcptools.NewHTTPHandler(mcptools.CreateMCPServer(store, ""), "s3cret"))
+	defer ts.Close()
+
+	resp, err := http.Post(ts.URL, "application/json", strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatalf("POST: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusUnauthorized)
+	}
+}
+
+func TestHTTPHandler_ReadOnly(t *testing.T) {
+	session, err := newHTTPSession(t, mcptools.Options{ReadOnly: true}, "s3cret")
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	want := []string{"filter_entries", "get_entry", "list_contexts", "list_days", "list_templates", "search_entries"}
+	if got := toolNames(t, session); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("read-only tools = %v, want %v", got, want)
+	}
+	_, err = session.CallTool(context.Background(), &mcp.CallToolParams{Name: "jot", Arguments: mcptools.JotInput{Content: "x"}})
+	if err == nil {
+		t.Error("expected jot to be unavailable in read-only mode")
+	}
+}
@@ -0,0 +1,47 @@
+package mcptools
+
+import (
+	"context"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/template"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// JotHandler returns the handler function for the jot MCP tool. When today's
+// entry has to be created, input.Template is used, falling back to
+// opts.SelectTemplate.
+func JotHandler(store storage.Storage, opts Options) func(ctx context.Context, req *mcp.CallToolRequest, input JotInput) (*mcp.CallToolResult, JotOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input JotInput) (*mcp.CallToolResult, JotOutput, error) {
+		selectTemplate := func(now time.Time) string {
+			if input.Template == "" && opts.SelectTemplate != nil {
+				return opts.SelectTemplate(now)
+			}
+			return input.Template
+		}
+		updated, line, err := daily.Jot(store, input.Content, selectTemplate, template.RenderOptions{})
+		if err != nil {
+			return nil, JotOutput{}, err
+		}
+
+		for _, name := range input.Contexts {
+			c, err := getOrCreateContext(store, name)
+			if err != nil {
+				return nil, JotOutput{}, err
+			}
+			if err := store.AttachContext(updated.ID, c.ID); err != nil {
+				return nil, JotOutput{}, err
+			}
+		}
+		invalidateCache(opts.DataDir)
+
+		return nil, JotOutput{
+			EntryID: updated.ID,
+			Date:    day.OfZone(updated.CreatedAt, updated.TimeZone).Format("2006-01-02"),
+			Line:    line,
+		}, nil
+	}
+}
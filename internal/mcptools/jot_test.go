@@ -0,0 +1,65 @@
+package mcptools_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func TestMCPServer_Jot(t *testing.T) {
+	store, session := newTestSession(t)
+
+	var first mcptools.JotOutput
+	callTool(t, session, "jot", mcptools.JotInput{Content: "shipped the fix", Contexts: []string{"project/auth"}}, &first)
+	if !strings.HasSuffix(first.Line, "shipped the fix") {
+		t.Errorf("line = %q", first.Line)
+	}
+
+	var second mcptools.JotOutput
+	callTool(t, session, "jot", mcptools.JotInput{Content: "reviewed PRs"}, &second)
+	if second.EntryID != first.EntryID {
+		t.Errorf("expected both jots in today's entry, got %s and %s", first.EntryID, second.EntryID)
+	}
+
+	e, err := store.Get(first.EntryID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !strings.Contains(e.Content, "shipped the fix") || !strings.Contains(e.Content, "reviewed PRs") {
+		t.Errorf("expected both jots in content, got %q", e.Content)
+	}
+	if len(e.Contexts) != 1 || e.Contexts[0].ContextName != "project/auth" {
+		t.Errorf("expected project/auth context, got %v", e.Contexts)
+	}
+
+	result := callTool(t, session, "jot", mcptools.JotInput{Content: ""}, nil)
+	if !result.IsError {
+		t.Error("expected IsError for empty jot")
+	}
+}
+
+func TestMCPServer_JotDefaultTemplate(t *testing.T) {
+	store, session := newTestSessionWithOptions(t, mcptools.Options{
+		SelectTemplate: func(time.Time) string { return "daily" },
+	})
+	now := time.Now()
+	if err := store.CreateTemplate(storage.Template{ID: "tmpldaly", Name: "daily", Content: "# Daily", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatal(err)
+	}
+
+	var out mcptools.JotOutput
+	callTool(t, session, "jot", mcptools.JotInput{Content: "first note"}, &out)
+	e, err := store.Get(out.EntryID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !strings.HasPrefix(e.Content, "# Daily") {
+		t.Errorf("expected today's entry to start from the default template, got %q", e.Content)
+	}
+	if len(e.Templates) != 1 || e.Templates[0].TemplateName != "daily" {
+		t.Errorf("expected daily template attribution, got %v", e.Templates)
+	}
+}
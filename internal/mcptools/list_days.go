@@ -0,0 +1,48 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// ListDaysHandler returns the handler function for the list_days MCP tool.
+func ListDaysHandler(store storage.Storage) func(ctx context.Context, req *mcp.CallToolRequest, input ListDaysInput) (*mcp.CallToolResult, ListDaysOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input ListDaysInput) (*mcp.CallToolResult, ListDaysOutput, error) {
+		opts := storage.ListDaysOptions{TemplateName: input.TemplateName}
+		if input.StartDate != "" {
+			t, err := parseStartDate(input.StartDate)
+			if err != nil {
+				return nil, ListDaysOutput{}, fmt.Errorf("invalid start_date: %w", err)
+			}
+			opts.StartDate = &t
+		}
+		if input.EndDate != "" {
+			t, err := parseEndDate(input.EndDate)
+			if err != nil {
+				return nil, ListDaysOutput{}, fmt.Errorf("invalid end_date: %w", err)
+			}
+			opts.EndDate = &t
+		}
+
+		days, err := store.ListDays(opts)
+		if err != nil {
+			return nil, ListDaysOutput{}, err
+		}
+		if input.Limit > 0 && len(days) > input.Limit {
+			days = days[:input.Limit]
+		}
+
+		results := []DayResult{}
+		for _, d := range days {
+			results = append(results, DayResult{
+				Date:    d.Date.Format("2006-01-02"),
+				Count:   d.Count,
+				Preview: d.Preview,
+			})
+		}
+		return nil, ListDaysOutput{Days: results}, nil
+	}
+}
@@ -0,0 +1,48 @@
+package mcptools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+)
+
+func TestMCPServer_ListDays(t *testing.T) {
+	store, session := newTestSession(t)
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	entries := []entry.Entry{
+		{ID: "days0001", Content: "monday one", CreatedAt: day, UpdatedAt: day},
+		{ID: "days0002", Content: "monday two", CreatedAt: day.Add(time.Hour), UpdatedAt: day.Add(time.Hour)},
+		{ID: "days0003", Content: "tuesday", CreatedAt: day.AddDate(0, 0, 1), UpdatedAt: day.AddDate(0, 0, 1)},
+	}
+	for _, e := range entries {
+		if err := store.Create(e); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+	}
+
+	var output mcptools.ListDaysOutput
+	callTool(t, session, "list_days", mcptools.ListDaysInput{}, &output)
+	if len(output.Days) != 2 {
+		t.Fatalf("expected 2 days, got %+v", output.Days)
+	}
+	if output.Days[0].Date != "2026-03-03" || output.Days[1].Date != "2026-03-02" || output.Days[1].Count != 2 {
+		t.Errorf("unexpected days %+v", output.Days)
+	}
+
+	callTool(t, session, "list_days", mcptools.ListDaysInput{StartDate: "2026-03-03"}, &output)
+	if len(output.Days) != 1 || output.Days[0].Date != "2026-03-03" {
+		t.Errorf("expected only 2026-03-03, got %+v", output.Days)
+	}
+
+	callTool(t, session, "list_days", mcptools.ListDaysInput{StartDate: "2026-03", EndDate: "march 2026"}, &output)
+	if len(output.Days) != 2 {
+		t.Errorf("expected both days in March 2026, got %+v", output.Days)
+	}
+
+	result := callTool(t, session, "list_days", mcptools.ListDaysInput{StartDate: "someday"}, nil)
+	if !result.IsError {
+		t.Error("expected IsError for invalid date")
+	}
+}
@@ -0,0 +1,233 @@
+package mcptools
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/dateparse"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// registerPrompts publishes the reflection prompts. Each one gathers the
+// relevant entries from storage and embeds them in a ready-to-run message.
+func registerPrompts(server *mcp.Server, store storage.Storage) {
+	p := diaryPrompts{store: store, now: time.Now}
+
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "weekly_review",
+		Title:       "Weekly review",
+		Description: "Review a week of entries: highlights, progress, open threads and next week's focus",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "week_of", Description: "Any date in the ISO week to review, e.g. 2026-03-05 or last week; defaults to the current week"},
+		},
+	}, p.weeklyReview)
+
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "standup_from_yesterday",
+		Title:       "Standup from yesterday",
+		Description: "Draft a standup update from the previous day's entries and today's so far",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "date", Description: "Day of the standup, e.g. 2026-03-05 or last monday; defaults to today"},
+		},
+	}, p.standup)
+
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "summarize_context",
+		Title:       "Summarize context",
+		Description: "Summarize the entries tagged with a context, such as a project or branch",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "context", Description: "Context name, e.g. feature/auth", Required: true},
+			{Name: "limit", Description: "Maximum number of recent entries to include (default 20)"},
+		},
+	}, p.summarizeContext)
+
+	server.AddPrompt(&mcp.Prompt{
+		Name:        "mood_check",
+		Title:       "Mood check",
+		Description: "Reflect on mood and energy across recent entries",
+		Arguments: []*mcp.PromptArgument{
+			{Name: "days", Description: "Number of days to look back, including today (default 7)"},
+		},
+	}, p.moodCheck)
+}
+
+type diaryPrompts struct {
+	store storage.Storage
+	now   func() time.Time
+}
+
+func (p diaryPrompts) today() time.Time {
+	return day.Of(p.now())
+}
+
+// dateArg parses an optional date argument, defaulting to today. Periods
+// such as "last week" resolve to their first day.
+func (p diaryPrompts) dateArg(args map[string]string, name string) (time.Time, error) {
+	v := strings.TrimSpace(args[name])
+	if v == "" {
+		return p.today(), nil
+	}
+	r, err := dateparse.Parser{Now: p.now}.Range(v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid %s: %w", name, err)
+	}
+	return r.Start, nil
+}
+
+// intArg parses an optional positive integer argument.
+func intArg(args map[string]string, name string, def int) (int, error) {
+	v := strings.TrimSpace(args[name])
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid %s %q: expected a positive number", name, v)
+	}
+	return n, nil
+}
+
+// between returns the entries created from start through end (both
+// inclusive days), oldest first.
+func (p diaryPrompts) between(start, end time.Time) ([]entry.Entry, error) {
+	entries, err := p.store.List(storage.ListOptions{StartDate: &start, EndDate: &end})
+	if err != nil {
+		return nil, err
+	}
+	reverse(entries)
+	return entries, nil
+}
+
+func reverse(entries []entry.Entry) {
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+}
+
+// promptResult wraps instructions and the gathered entries in a single user
+// message.
+func promptResult(description, instructions string, entries []entry.Entry, empty string) *mcp.GetPromptResult {
+	body := empty
+	if len(entries) > 0 {
+		body = formatEntries(entries)
+	}
+	text := instructions + "\n\n---\n\n" + body
+	return &mcp.GetPromptResult{
+		Description: description,
+		Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: text}},
+		},
+	}
+}
+
+func (p diaryPrompts) weeklyReview(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	day, err := p.dateArg(req.Params.Arguments, "week_of")
+	if err != nil {
+		return nil, err
+	}
+	// ISO weeks start on Monday
+	start := day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	end := start.AddDate(0, 0, 6)
+	entries, err := p.between(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	_, week := start.ISOWeek()
+	span := fmt.Sprintf("%s to %s", start.Format("Mon Jan 2"), end.Format("Mon Jan 2, 2006"))
+	instructions := fmt.Sprintf(`Here are my diary entries for week %d (%s). Write a weekly review with these sections:
+
+## Highlights
+## Progress
+## Open threads
+## Focus for next week
+
+Quote or cite entries by date where it helps, and keep it concise.`, week, span)
+	return promptResult("Weekly review for "+span, instructions, entries, "There are no diary entries for this week."), nil
+}
+
+func (p diaryPrompts) standup(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	day, err := p.dateArg(req.Params.Arguments, "date")
+	if err != nil {
+		return nil, err
+	}
+
+	// "Yesterday" is the most recent earlier day with entries, so Monday
+	// standups pick up Friday's work.
+	before := day.AddDate(0, 0, -1)
+	days, err := p.store.ListDays(storage.ListDaysOptions{EndDate: &before})
+	if err != nil {
+		return nil, err
+	}
+	var previous []entry.Entry
+	prevLabel := "the previous day"
+	if len(days) > 0 {
+		prev := days[0].Date
+		prevLabel = prev.Format("Mon Jan 2")
+		if previous, err = p.between(prev, prev); err != nil {
+			return nil, err
+		}
+	}
+	today, err := p.between(day, day)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions := fmt.Sprintf(`Draft my standup update for %s from the diary entries below: first those from %s, then any from today so far.
+
+Use three short bullet lists:
+- **Yesterday:** what I did
+- **Today:** what I plan to do (infer from open tasks and unfinished work)
+- **Blockers:** anything blocking me, or "None"`, day.Format("Mon Jan 2"), prevLabel)
+	entries := append(previous, today...)
+	return promptResult("Standup for "+day.Format("2006-01-02"), instructions, entries, "There are no recent diary entries."), nil
+}
+
+func (p diaryPrompts) summarizeContext(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	name := strings.TrimSpace(req.Params.Arguments["context"])
+	if name == "" {
+		return nil, fmt.Errorf("argument \"context\" is required")
+	}
+	limit, err := intArg(req.Params.Arguments, "limit", contextEntryLimit)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := p.store.GetContextByName(name); err != nil {
+		return nil, fmt.Errorf("context %q: %w", name, err)
+	}
+	entries, err := p.store.List(storage.ListOptions{ContextName: name, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	reverse(entries)
+
+	instructions := fmt.Sprintf(`Summarize my diary entries tagged %q (oldest first below). Cover what the work is about, key decisions and their reasons, the current state, and open questions or next steps.`, name)
+	return promptResult("Summary of "+name, instructions, entries, "There are no entries tagged with this context."), nil
+}
+
+func (p diaryPrompts) moodCheck(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	n, err := intArg(req.Params.Arguments, "days", 7)
+	if err != nil {
+		return nil, err
+	}
+	end := p.today()
+	start := end.AddDate(0, 0, -(n - 1))
+	entries, err := p.between(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	instructions := fmt.Sprintf(`Here are my diary entries from the last %d days. Gently reflect on my mood and energy:
+- How do I seem to be feeling overall, and how has it changed day to day?
+- What seems to lift or drain my energy?
+- One or two small, practical suggestions for the coming days.
+
+Base this only on what the entries say, and note when there is too little to go on.`, n)
+	return promptResult(fmt.Sprintf("Mood check for the last %d days", n), instructions, entries, "There are no diary entries in this period."), nil
+}
@@ -0,0 +1,118 @@
+package mcptools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func getPromptText(t *testing.T, session *mcp.ClientSession, name string, args map[string]string) (string, error) {
+	t.Helper()
+	res, err := session.GetPrompt(context.Background(), &mcp.GetPromptParams{Name: name, Arguments: args})
+	if err != nil {
+		return "", err
+	}
+	if len(res.Messages) != 1 {
+		t.Fatalf("expected 1 message, got %d", len(res.Messages))
+	}
+	text, ok := res.Messages[0].Content.(*mcp.TextContent)
+	if !ok {
+		t.Fatalf("expected text content, got %T", res.Messages[0].Content)
+	}
+	return text.Text, nil
+}
+
+func TestMCPServer_Prompts(t *testing.T) {
+	store, session := newTestSession(t)
+	at := func(date string, hour int) time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", date, time.Local)
+		return d.Add(time.Duration(hour) * time.Hour)
+	}
+	// Thursday 2026-03-05 through Monday 2026-03-09
+	for _, e := range []entry.Entry{
+		{ID: "thu00001", Content: "thursday work", CreatedAt: at("2026-03-05", 9)},
+		{ID: "fri00001", Content: "friday: fixed the login bug, feeling great", CreatedAt: at("2026-03-06", 9)},
+		{ID: "mon00001", Content: "monday so far", CreatedAt: at("2026-03-09", 9)},
+	} {
+		e.UpdatedAt = e.CreatedAt
+		if err := store.Create(e); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+	}
+	now := time.Now()
+	if err := store.CreateContext(storage.Context{ID: "ctx00001", Name: "feature/auth", Source: "manual", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create context: %v", err)
+	}
+	_ = store.AttachContext("fri00001", "ctx00001")
+
+	list, err := session.ListPrompts(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListPrompts: %v", err)
+	}
+	if len(list.Prompts) != 4 {
+		t.Errorf("expected 4 prompts, got %d", len(list.Prompts))
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]string
+		want    []string
+		notWant []string
+	}{
+		{"weekly_review", map[string]string{"week_of": "2026-03-04"}, []string{"week 10", "Mon Mar 2 to Sun Mar 8, 2026", "thursday work", "friday: fixed"}, []string{"monday so far"}},
+		{"standup_from_yesterday", map[string]string{"date": "2026-03-09"}, []string{"from Fri Mar 6", "friday: fixed", "monday so far", "**Blockers:**"}, []string{"thursday work"}},
+		{"summarize_context", map[string]string{"context": "feature/auth"}, []string{`tagged "feature/auth"`, "friday: fixed"}, []string{"thursday work"}},
+		{"weekly_review", map[string]string{"week_of": "2026-01-05"}, []string{"There are no diary entries for this week."}, nil},
+		{"weekly_review", map[string]string{"week_of": "2026-W10"}, []string{"week 10", "thursday work"}, []string{"monday so far"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := getPromptText(t, session, tt.name, tt.args)
+			if err != nil {
+				t.Fatalf("GetPrompt: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("expected %q in:\n%s", want, text)
+				}
+			}
+			for _, nw := range tt.notWant {
+				if strings.Contains(text, nw) {
+					t.Errorf("did not expect %q in:\n%s", nw, text)
+				}
+			}
+		})
+	}
+
+	t.Run("mood_check", func(t *testing.T) {
+		if err := store.Create(entry.Entry{ID: "now00001", Content: "tired but ok", CreatedAt: now, UpdatedAt: now}); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+		text, err := getPromptText(t, session, "mood_check", map[string]string{"days": "3"})
+		if err != nil {
+			t.Fatalf("GetPrompt: %v", err)
+		}
+		if !strings.Contains(text, "last 3 days") || !strings.Contains(text, "tired but ok") {
+			t.Errorf("unexpected mood_check prompt:\n%s", text)
+		}
+	})
+
+	for _, bad := range []struct {
+		name string
+		args map[string]string
+	}{
+		{"summarize_context", nil},
+		{"summarize_context", map[string]string{"context": "nope"}},
+		{"weekly_review", map[string]string{"week_of": "someday"}},
+		{"mood_check", map[string]string{"days": "-1"}},
+	} {
+		if _, err := getPromptText(t, session, bad.name, bad.args); err == nil {
+			t.Errorf("expected error for %s %v", bad.name, bad.args)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package mcptools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+const (
+	resourceScheme = "diary://"
+	todayURI       = resourceScheme + "today"
+	markdownMIME   = "text/markdown"
+
+	// recentDays is how many days with entries are listed as resources.
+	recentDays = 14
+	// contextEntryLimit caps the entries returned for a context resource.
+	contextEntryLimit = 20
+)
+
+// diaryResources serves diary content as MCP resources. Besides the
+// resource templates, recent days, templates and contexts are listed as
+// concrete resources so clients can offer them for attachment; the list is
+// refreshed, and subscribers notified, after writes made through the server.
+type diaryResources struct {
+	server *mcp.Server
+	store  storage.Storage
+
+	mu     sync.Mutex
+	listed map[string]bool
+}
+
+func registerResources(server *mcp.Server, store storage.Storage) *diaryResources {
+	r := &diaryResources{server: server, store: store, listed: map[string]bool{}}
+
+	server.AddResource(todayResource(), r.read)
+	for _, t := range []*mcp.ResourceTemplate{
+		{Name: "day", URITemplate: resourceScheme + "day/{date}", Description: "All entries from a day (YYYY-MM-DD, or e.g. yesterday), oldest first", MIMEType: markdownMIME},
+		{Name: "entry", URITemplate: resourceScheme + "entry/{id}", Description: "A single diary entry", MIMEType: markdownMIME},
+		{Name: "template", URITemplate: resourceScheme + "template/{name}", Description: "A template's raw content", MIMEType: markdownMIME},
+		{Name: "context", URITemplate: resourceScheme + "context/{name}", Description: "Recent entries tagged with a context (URL-escape slashes)", MIMEType: markdownMIME},
+	} {
+		server.AddResourceTemplate(t, r.read)
+	}
+	r.sync()
+	return r
+}
+
+func todayResource() *mcp.Resource {
+	return &mcp.Resource{
+		URI:         todayURI,
+		Name:        "today",
+		Description: "Today's diary entries",
+		MIMEType:    markdownMIME,
+	}
+}
+
+func dayURI(date time.Time) string {
+	return resourceScheme + "day/" + date.Format("2006-01-02")
+}
+
+func entryURI(id string) string {
+	return resourceScheme + "entry/" + id
+}
+
+// sync lists recent days, templates and contexts as resources, adding new
+// ones and removing those that no longer exist.
+func (r *diaryResources) sync() {
+	want := map[string]*mcp.Resource{}
+	if days, err := r.store.ListDays(storage.ListDaysOptions{}); err == nil {
+		for i, d := range days {
+			if i >= recentDays {
+				break
+			}
+			uri := dayURI(d.Date)
+			want[uri] = &mcp.Resource{
+				URI:         uri,
+				Name:        "day-" + d.Date.Format("2006-01-02"),
+				Title:       d.Date.Format("Mon Jan 2, 2006"),
+				Description: fmt.Sprintf("%d entries: %s", d.Count, d.Preview),
+				MIMEType:    markdownMIME,
+			}
+		}
+	}
+	if templates, err := r.store.ListTemplates(); err == nil {
+		for _, t := range templates {
+			uri := resourceScheme + "template/" + url.PathEscape(t.Name)
+			want[uri] = &mcp.Resource{URI: uri, Name: "template-" + t.Name, Description: "Template " + t.Name, MIMEType: markdownMIME}
+		}
+	}
+	if contexts, err := r.store.ListContexts(); err == nil {
+		for _, c := range contexts {
+			uri := resourceScheme + "context/" + url.PathEscape(c.Name)
+			want[uri] = &mcp.Resource{URI: uri, Name: "context-" + c.Name, Description: "Entries tagged " + c.Name, MIMEType: markdownMIME}
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var stale []string
+	for uri := range r.listed {
+		if want[uri] == nil {
+			stale = append(stale, uri)
+			delete(r.listed, uri)
+		}
+	}
+	if len(stale) > 0 {
+		r.server.RemoveResources(stale...)
+	}
+	for uri, res := range want {
+		if !r.listed[uri] {
+			r.server.AddResource(res, r.read)
+			r.listed[uri] = true
+		}
+	}
+}
+
+// changed refreshes the resource list after a write, which always sends a
+// list-changed notification, and notifies subscribers of the given URIs and
+// today's resource.
+func (r *diaryResources) changed(ctx context.Context, uris ...string) {
+	r.sync()
+	// Re-adding today's resource marks the list as changed even when no
+	// resources were added or removed, since listed content did change.
+	r.server.AddResource(todayResource(), r.read)
+	for _, uri := range append([]string{todayURI}, uris...) {
+		_ = r.server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri})
+	}
+}
+
+// notifying wraps a write tool handler so that resources are refreshed after
+// it succeeds. touched returns the URIs the call changed; nil means nothing
+// was written.
+func notifying[In, Out any](r *diaryResources, h mcp.ToolHandlerFor[In, Out], touched func(In, Out) []string) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		res, out, err := h(ctx, req, input)
+		if err == nil {
+			if uris := touched(input, out); uris != nil {
+				r.changed(ctx, uris...)
+			}
+		}
+		return res, out, err
+	}
+}
+
+// read serves every diary:// resource.
+func (r *diaryResources) read(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+	uri := req.Params.URI
+	kind, arg, _ := strings.Cut(strings.TrimPrefix(uri, resourceScheme), "/")
+	arg, err := url.PathUnescape(arg)
+	if err != nil {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+
+	var text string
+	switch kind {
+	case "today":
+		text, err = r.day(day.Today())
+	case "day":
+		date, perr := parseDate(arg)
+		if perr != nil {
+			return nil, fmt.Errorf("invalid date: %w", perr)
+		}
+		text, err = r.day(date)
+	case "entry":
+		var e entry.Entry
+		e, err = r.store.Get(arg)
+		text = formatEntries([]entry.Entry{e})
+	case "template":
+		var t storage.Template
+		t, err = r.store.GetTemplateByName(arg)
+		text = t.Content
+	case "context":
+		if _, err = r.store.GetContextByName(arg); err == nil {
+			var entries []entry.Entry
+			entries, err = r.store.List(storage.ListOptions{ContextName: arg, Limit: contextEntryLimit})
+			text = formatEntries(entries)
+			if len(entries) == 0 {
+				text = fmt.Sprintf("No entries tagged %s.", arg)
+			}
+		}
+	default:
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, mcp.ResourceNotFoundError(uri)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{
+		{URI: uri, MIMEType: markdownMIME, Text: text},
+	}}, nil
+}
+
+// day renders the entries created on date, oldest first.
+func (r *diaryResources) day(date time.Time) (string, error) {
+	day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+	entries, err := r.store.List(storage.ListOptions{Date: &day})
+	if err != nil {
+		return "", err
+	}
+	if len(entries) == 0 {
+		return fmt.Sprintf("No entries on %s.", day.Format("2006-01-02")), nil
+	}
+	reverse(entries)
+	return formatEntries(entries), nil
+}
+
+// formatEntries renders entries as markdown, each under a heading with its
+// time, ID, templates and contexts.
+func formatEntries(entries []entry.Entry) string {
+	parts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		d := toEntryDetail(e)
+		heading := fmt.Sprintf("## %s · %s", day.In(e.CreatedAt, e.TimeZone).Format("2006-01-02 15:04"), e.ID)
+		if len(d.Templates) > 0 {
+			heading += " · templates: " + strings.Join(d.Templates, ", ")
+		}
+		if len(d.Contexts) > 0 {
+			heading += " · contexts: " + strings.Join(d.Contexts, ", ")
+		}
+		parts = append(parts, heading+"\n\n"+e.Content)
+	}
+	return strings.Join(parts, "\n\n")
+}
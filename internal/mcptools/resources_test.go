@@ -0,0 +1,235 @@
+package mcptools_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func readResource(t *testing.T, session *mcp.ClientSession, uri string) (string, error) {
+	t.Helper()
+	res, err := session.ReadResource(context.Background(), &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		return "", err
+	}
+	if len(res.Contents) != 1 {
+		t.Fatalf("expected 1 content item for %s, got %d", uri, len(res.Contents))
+	}
+	return res.Contents[0].Text, nil
+}
+
+func TestMCPServer_Resources(t *testing.T) {
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	day := time.Date(2026, 3, 2, 9, 0, 0, 0, time.Local)
+	for _, e := range []entry.Entry{
+		{ID: "today001", Content: "written today", CreatedAt: now, UpdatedAt: now},
+		{ID: "march001", Content: "first in March", CreatedAt: day, UpdatedAt: day},
+		{ID: "march002", Content: "second in March", CreatedAt: day.Add(time.Hour), UpdatedAt: day.Add(time.Hour)},
+	} {
+		if err := store.Create(e); err != nil {
+			t.Fatalf("failed to create entry: %v", err)
+		}
+	}
+	if err := store.CreateTemplate(storage.Template{ID: "tmpl0001", Name: "standup", Content: "## Yesterday", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create template: %v", err)
+	}
+	if err := store.CreateContext(storage.Context{ID: "ctx00001", Name: "feature/auth", Source: "manual", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create context: %v", err)
+	}
+	if err := store.AttachContext("march002", "ctx00001"); err != nil {
+		t.Fatalf("failed to attach context: %v", err)
+	}
+
+	_, clientTransport := mcptools.NewDiaryMCPServer(store)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer session.Close()
+
+	t.Run("lists resources and templates", func(t *testing.T) {
+		list, err := session.ListResources(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("ListResources: %v", err)
+		}
+		uris := map[string]bool{}
+		for _, r := range list.Resources {
+			uris[r.URI] = true
+		}
+		for _, want := range []string{"diary://today", "diary://day/2026-03-02", "diary://template/standup", "diary://context/feature%2Fauth"} {
+			if !uris[want] {
+				t.Errorf("missing resource %s in %v", want, uris)
+			}
+		}
+
+		templates, err := session.ListResourceTemplates(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("ListResourceTemplates: %v", err)
+		}
+		if len(templates.ResourceTemplates) != 4 {
+			t.Errorf("expected 4 resource templates, got %d", len(templates.ResourceTemplates))
+		}
+	})
+
+	tests := []struct {
+		uri  string
+		want []string
+	}{
+		{"diary://today", []string{"written today"}},
+		{"diary://day/2026-03-02", []string{"first in March\n\n## 2026-03-02 10:00 · march002", "second in March"}},
+		{"diary://entry/march002", []string{"· march002 · contexts: feature/auth\n\nsecond in March"}},
+		{"diary://template/standup", []string{"## Yesterday"}},
+		{"diary://context/feature%2Fauth", []string{"second in March"}},
+		{"diary://day/2026-03-05", []string{"No entries on 2026-03-05."}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.uri, func(t *testing.T) {
+			text, err := readResource(t, session, tt.uri)
+			if err != nil {
+				t.Fatalf("ReadResource: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(text, want) {
+					t.Errorf("expected %q in:\n%s", want, text)
+				}
+			}
+		})
+	}
+
+	for _, uri := range []string{"diary://entry/missing1", "diary://template/nope", "diary://context/nope", "diary://unknown/x"} {
+		if _, err := readResource(t, session, uri); err == nil {
+			t.Errorf("expected error reading %s", uri)
+		}
+	}
+}
+
+func TestMCPServer_ResourceNotifications(t *testing.T) {
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	listChanged := make(chan struct{}, 10)
+	updated := make(chan string, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ResourceListChangedHandler: func(context.Context, *mcp.ResourceListChangedRequest) {
+			listChanged <- struct{}{}
+		},
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	_, clientTransport := mcptools.NewDiaryMCPServer(store)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer session.Close()
+
+	if err := session.Subscribe(context.Background(), &mcp.SubscribeParams{URI: "diary://today"}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	callTool(t, session, "jot", mcptools.JotInput{Content: "note from an assistant"}, nil)
+
+	select {
+	case <-listChanged:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a resource list changed notification after jot")
+	}
+	select {
+	case uri := <-updated:
+		if uri != "diary://today" {
+			t.Errorf("updated URI = %q, want diary://today", uri)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a resource updated notification for diary://today")
+	}
+
+	list, err := session.ListResources(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListResources: %v", err)
+	}
+	today := "diary://day/" + time.Now().Format("2006-01-02")
+	found := false
+	for _, r := range list.Resources {
+		found = found || r.URI == today
+	}
+	if !found {
+		t.Errorf("expected %s to be listed after jot", today)
+	}
+}
+
+func TestMCPServer_ResourcesUseEntryZone(t *testing.T) {
+	store, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	// 23:30 UTC on March 2 is 12:30 on March 3 in Auckland.
+	at := time.Date(2026, 3, 2, 23, 30, 0, 0, time.UTC)
+	if err := store.Create(entry.Entry{ID: "nz000001", Content: "written in Auckland", CreatedAt: at, UpdatedAt: at, TimeZone: "Pacific/Auckland"}); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+
+	updated := make(chan string, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updated <- req.Params.URI
+		},
+	})
+	_, clientTransport := mcptools.NewDiaryMCPServer(store)
+	session, err := client.Connect(context.Background(), clientTransport, nil)
+	if err != nil {
+		t.Fatalf("failed to connect client: %v", err)
+	}
+	defer session.Close()
+
+	const dayURI = "diary://day/2026-03-03"
+	text, err := readResource(t, session, dayURI)
+	if err != nil {
+		t.Fatalf("ReadResource: %v", err)
+	}
+	if !strings.Contains(text, "## 2026-03-03 12:30 · nz000001") {
+		t.Errorf("expected heading in the entry's zone, got:\n%s", text)
+	}
+
+	if err := session.Subscribe(context.Background(), &mcp.SubscribeParams{URI: dayURI}); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+	var preview mcptools.DeleteEntryOutput
+	callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "nz000001"}, &preview)
+	var out mcptools.DeleteEntryOutput
+	callTool(t, session, "delete_entry", mcptools.DeleteEntryInput{ID: "nz000001", ConfirmToken: preview.ConfirmToken}, &out)
+	if !out.Deleted || out.Date != "2026-03-03" {
+		t.Fatalf("delete output = %+v, want deleted on 2026-03-03", out)
+	}
+
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case uri := <-updated:
+			if uri == dayURI {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("expected a resource updated notification for %s", dayURI)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"strings"
 
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 )
@@ -28,7 +29,7 @@ func SearchHandler(store storage.Storage) func(ctx context.Context, req *mcp.Cal
 				results = append(results, EntryResult{
 					ID:      e.ID,
 					Preview: e.Preview(100),
-					Date:    e.CreatedAt.Format("2006-01-02"),
+					Date:    day.OfZone(e.CreatedAt, e.TimeZone).Format("2006-01-02"),
 					Score:   1.0,
 				})
 				if len(results) >= limit {
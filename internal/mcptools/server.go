@@ -2,6 +2,8 @@ package mcptools
 
 import (
 	"context"
+	"net/url"
+	"time"
 
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -21,13 +23,45 @@ func NewDiaryMCPServer(store storage.Storage) (*mcp.Server, mcp.Transport) {
 	return server, clientTransport
 }
 
-// CreateMCPServer creates an MCP server with registered diary tools.
-// dataDir is used for cache invalidation after write operations; pass "" to skip.
+// Options configures the tools an MCP server registers.
+type Options struct {
+	// DataDir is used for cache invalidation after write operations; "" skips it.
+	DataDir string
+	// ReadOnly registers only the tools that do not modify the diary.
+	ReadOnly bool
+	// StrictTemplates makes create_entry fail on template variables that
+	// have no value instead of rendering "<no value>".
+	StrictTemplates bool
+	// SelectTemplate picks the template for today's entry when jot has to
+	// create it and no template is given; nil creates it without one.
+	SelectTemplate func(now time.Time) string
+	// AfterDelete runs after delete_entry removes an entry, e.g. to drop its
+	// pending enrichment suggestions; nil does nothing.
+	AfterDelete func(entryID string) error
+}
+
+// CreateMCPServer creates an MCP server with registered diary tools and
+// resources. dataDir is used for cache invalidation after write operations;
+// pass "" to skip.
 func CreateMCPServer(store storage.Storage, dataDir string) *mcp.Server {
+	return CreateMCPServerWithOptions(store, Options{DataDir: dataDir})
+}
+
+// CreateMCPServerWithOptions creates an MCP server with registered diary
+// tools and resources, as configured by opts.
+func CreateMCPServerWithOptions(store storage.Storage, opts Options) *mcp.Server {
+	dataDir := opts.DataDir
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "diaryctl",
 		Version: "1.0.0",
-	}, nil)
+	}, &mcp.ServerOptions{
+		// Subscriptions are tracked by the SDK; there is nothing to set up per URI.
+		SubscribeHandler:   func(context.Context, *mcp.SubscribeRequest) error { return nil },
+		UnsubscribeHandler: func(context.Context, *mcp.UnsubscribeRequest) error { return nil },
+	})
+
+	resources := registerResources(server, store)
+	registerPrompts(server, store)
 
 	// Read tools
 	mcp.AddTool(server, &mcp.Tool{
@@ -37,19 +71,108 @@ func CreateMCPServer(store storage.Storage, dataDir string) *mcp.Server {
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "filter_entries",
-		Description: "Filter diary entries by date range and template",
+		Description: "Filter diary entries by date range, templates and contexts, newest first. Page with limit and next_cursor; total counts all matches",
 	}, FilterHandler(store))
 
-	// Write tools
 	mcp.AddTool(server, &mcp.Tool{
-		Name:        "create_entry",
-		Description: "Create a diary entry with optional template composition and variable substitution",
-	}, CreateEntryHandler(store, dataDir))
+		Name:        "get_entry",
+		Description: "Read a diary entry's full content, templates and contexts by ID",
+	}, GetEntryHandler(store))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_days",
+		Description: "List days that have entries, newest first, with entry counts and a preview",
+	}, ListDaysHandler(store))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_contexts",
+		Description: "List contexts (projects, branches, tags) used to group entries",
+	}, ListContextsHandler(store))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "list_templates",
 		Description: "Discover available templates and their previews",
 	}, ListTemplatesHandler(store))
 
+	if opts.ReadOnly {
+		return server
+	}
+
+	// Write tools
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "create_entry",
+		Description: "Create a diary entry with optional template composition and variable substitution",
+	}, notifying(resources, CreateEntryHandler(store, opts), func(_ CreateEntryInput, out CreateEntryOutput) []string {
+		return []string{entryURI(out.ID), resourceScheme + "day/" + out.Date}
+	}))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_entry",
+		Description: "Replace a diary entry's content, or append to it",
+	}, notifying(resources, UpdateEntryHandler(store, dataDir), func(_ UpdateEntryInput, out UpdateEntryOutput) []string {
+		return []string{entryURI(out.Entry.ID), resourceScheme + "day/" + out.Entry.Date}
+	}))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "jot",
+		Description: "Append a timestamped note to today's entry, creating it if needed",
+	}, notifying(resources, JotHandler(store, opts), func(_ JotInput, out JotOutput) []string {
+		return []string{entryURI(out.EntryID), resourceScheme + "day/" + out.Date}
+	}))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "delete_entry",
+		Description: "Delete a diary entry. The first call returns a preview and confirm_token; call again with the token to delete",
+	}, notifying(resources, DeleteEntryHandler(store, opts), func(in DeleteEntryInput, out DeleteEntryOutput) []string {
+		if !out.Deleted {
+			return nil
+		}
+		return []string{entryURI(in.ID), resourceScheme + "day/" + out.Date}
+	}))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "attach_context",
+		Description: "Attach a context to an entry, creating the context if needed",
+	}, notifying(resources, AttachContextHandler(store, dataDir), func(in AttachContextInput, out AttachContextOutput) []string {
+		return []string{entryURI(out.EntryID), resourceScheme + "context/" + url.PathEscape(in.ContextName)}
+	}))
+
+	return server
+}
+
+// CreateMCPServerV2 creates an MCP server with the tools for the block-based
+// data model: days and blocks with attributes instead of entries.
+func CreateMCPServerV2(store storage.StorageV2, opts Options) *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{
+		Name:    "diaryctl",
+		Version: "1.0.0",
+	}, nil)
+
+	// Read tools
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_day",
+		Description: "Read all blocks of a day, oldest first, with their attributes",
+	}, GetDayHandler(store))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "search_blocks",
+		Description: "Search blocks by content, date range and attributes, e.g. attributes {\"type\": \"decision\"} with this month's dates",
+	}, SearchBlocksHandler(store))
+
+	if opts.ReadOnly {
+		return server
+	}
+
+	// Write tools
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "add_block",
+		Description: "Add a block with optional attributes to a day (today by default)",
+	}, AddBlockHandler(store))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "update_block_attributes",
+		Description: "Set or remove attributes on a block without changing its content",
+	}, UpdateBlockAttributesHandler(store))
+
 	return server
 }
@@ -13,15 +13,19 @@ type SearchOutput struct {
 
 // FilterInput is the input schema for the filter_entries MCP tool.
 type FilterInput struct {
-	StartDate     string   `json:"start_date,omitempty" jsonschema-description:"ISO date lower bound (inclusive)"`
-	EndDate       string   `json:"end_date,omitempty" jsonschema-description:"ISO date upper bound (inclusive)"`
-	TemplateNames []string `json:"template_names,omitempty" jsonschema-description:"Filter to entries using these templates"`
-	Limit         int      `json:"limit" jsonschema-description:"Maximum number of results"`
+	StartDate     string   `json:"start_date,omitempty" jsonschema-description:"Date lower bound (inclusive), e.g. 2026-03-05, -7d or last week"`
+	EndDate       string   `json:"end_date,omitempty" jsonschema-description:"Date upper bound (inclusive), e.g. 2026-03-05, yesterday or last week"`
+	TemplateNames []string `json:"template_names,omitempty" jsonschema-description:"Filter to entries using any of these templates"`
+	ContextNames  []string `json:"context_names,omitempty" jsonschema-description:"Filter to entries tagged with any of these contexts"`
+	Limit         int      `json:"limit" jsonschema-description:"Maximum number of results per page (0 = all)"`
+	Cursor        string   `json:"cursor,omitempty" jsonschema-description:"next_cursor from a previous call, to fetch the following page"`
 }
 
 // FilterOutput is the output schema for the filter_entries MCP tool.
 type FilterOutput struct {
-	Entries []EntryResult `json:"entries"`
+	Entries    []EntryResult `json:"entries"`
+	Total      int           `json:"total" jsonschema-description:"Number of entries matching the filters across all pages"`
+	NextCursor string        `json:"next_cursor,omitempty" jsonschema-description:"Pass as cursor to fetch the next page; empty on the last page"`
 }
 
 // EntryResult is the common output format for entry-related MCP tools.
@@ -62,3 +66,175 @@ type TemplateResult struct {
 	Name    string `json:"name"`
 	Preview string `json:"preview"`
 }
+
+// EntryDetail is the full representation of an entry returned by get_entry,
+// update_entry and jot.
+type EntryDetail struct {
+	ID        string   `json:"id"`
+	Date      string   `json:"date"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+	Content   string   `json:"content"`
+	Templates []string `json:"templates,omitempty"`
+	Contexts  []string `json:"contexts,omitempty"`
+}
+
+// GetEntryInput is the input schema for the get_entry MCP tool.
+type GetEntryInput struct {
+	ID string `json:"id" jsonschema-description:"Entry ID"`
+}
+
+// GetEntryOutput is the output schema for the get_entry MCP tool.
+type GetEntryOutput struct {
+	Entry EntryDetail `json:"entry"`
+}
+
+// UpdateEntryInput is the input schema for the update_entry MCP tool.
+type UpdateEntryInput struct {
+	ID      string `json:"id" jsonschema-description:"Entry ID"`
+	Content string `json:"content" jsonschema-description:"New content, or the text to add when append is true"`
+	Append  bool   `json:"append,omitempty" jsonschema-description:"Append content on a new line instead of replacing the entry"`
+}
+
+// UpdateEntryOutput is the output schema for the update_entry MCP tool.
+type UpdateEntryOutput struct {
+	Entry EntryDetail `json:"entry"`
+}
+
+// JotInput is the input schema for the jot MCP tool.
+type JotInput struct {
+	Content  string   `json:"content" jsonschema-description:"Note to append to today's entry as a timestamped line"`
+	Template string   `json:"template,omitempty" jsonschema-description:"Template to use if today's entry has to be created (default: the configured default template)"`
+	Contexts []string `json:"contexts,omitempty" jsonschema-description:"Context names to attach to today's entry (created if missing)"`
+}
+
+// JotOutput is the output schema for the jot MCP tool.
+type JotOutput struct {
+	EntryID string `json:"entry_id"`
+	Date    string `json:"date"`
+	Line    string `json:"line"`
+}
+
+// DeleteEntryInput is the input schema for the delete_entry MCP tool.
+type DeleteEntryInput struct {
+	ID           string `json:"id" jsonschema-description:"Entry ID"`
+	ConfirmToken string `json:"confirm_token,omitempty" jsonschema-description:"Token returned by a previous call without one; required to actually delete"`
+}
+
+// DeleteEntryOutput is the output schema for the delete_entry MCP tool.
+type DeleteEntryOutput struct {
+	Deleted      bool   `json:"deleted"`
+	Date         string `json:"date,omitempty"`
+	ConfirmToken string `json:"confirm_token,omitempty"`
+	Preview      string `json:"preview"`
+	Message      string `json:"message"`
+}
+
+// ListContextsInput is the input schema for the list_contexts MCP tool.
+type ListContextsInput struct {
+	Limit int `json:"limit" jsonschema-description:"Maximum number of contexts to return"`
+}
+
+// ListContextsOutput is the output schema for the list_contexts MCP tool.
+type ListContextsOutput struct {
+	Contexts []ContextResult `json:"contexts"`
+}
+
+// ContextResult represents a context in list_contexts output.
+type ContextResult struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// AttachContextInput is the input schema for the attach_context MCP tool.
+type AttachContextInput struct {
+	EntryID     string `json:"entry_id" jsonschema-description:"Entry ID"`
+	ContextName string `json:"context_name" jsonschema-description:"Context name, e.g. project/auth (created if missing)"`
+}
+
+// AttachContextOutput is the output schema for the attach_context MCP tool.
+type AttachContextOutput struct {
+	EntryID  string   `json:"entry_id"`
+	Contexts []string `json:"contexts"`
+}
+
+// ListDaysInput is the input schema for the list_days MCP tool.
+type ListDaysInput struct {
+	StartDate    string `json:"start_date,omitempty" jsonschema-description:"Date lower bound (inclusive), e.g. 2026-03-05, -7d or last week"`
+	EndDate      string `json:"end_date,omitempty" jsonschema-description:"Date upper bound (inclusive), e.g. 2026-03-05, yesterday or last week"`
+	TemplateName string `json:"template_name,omitempty" jsonschema-description:"Only include days with entries using this template"`
+	Limit        int    `json:"limit" jsonschema-description:"Maximum number of days to return, newest first"`
+}
+
+// ListDaysOutput is the output schema for the list_days MCP tool.
+type ListDaysOutput struct {
+	Days []DayResult `json:"days"`
+}
+
+// DayResult summarises one day in list_days output.
+type DayResult struct {
+	Date    string `json:"date"`
+	Count   int    `json:"count"`
+	Preview string `json:"preview"`
+}
+
+// BlockDetail is the full representation of a block in V2 tool output.
+type BlockDetail struct {
+	ID         string            `json:"id"`
+	Date       string            `json:"date"`
+	CreatedAt  string            `json:"created_at"`
+	UpdatedAt  string            `json:"updated_at"`
+	Content    string            `json:"content"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// GetDayInput is the input schema for the get_day MCP tool.
+type GetDayInput struct {
+	Date string `json:"date,omitempty" jsonschema-description:"Day such as 2026-03-05, yesterday or last monday; defaults to today"`
+}
+
+// GetDayOutput is the output schema for the get_day MCP tool.
+type GetDayOutput struct {
+	Date   string        `json:"date"`
+	Blocks []BlockDetail `json:"blocks" jsonschema-description:"The day's blocks, oldest first"`
+}
+
+// AddBlockInput is the input schema for the add_block MCP tool.
+type AddBlockInput struct {
+	Content    string            `json:"content" jsonschema-description:"Block content"`
+	Date       string            `json:"date,omitempty" jsonschema-description:"Day to add to, e.g. 2026-03-05 or yesterday; defaults to today"`
+	Attributes map[string]string `json:"attributes,omitempty" jsonschema-description:"Key-value metadata, e.g. {\"type\": \"decision\"}"`
+}
+
+// AddBlockOutput is the output schema for the add_block MCP tool.
+type AddBlockOutput struct {
+	Block BlockDetail `json:"block"`
+}
+
+// UpdateBlockAttributesInput is the input schema for the update_block_attributes MCP tool.
+type UpdateBlockAttributesInput struct {
+	ID     string            `json:"id" jsonschema-description:"Block ID"`
+	Set    map[string]string `json:"set,omitempty" jsonschema-description:"Attributes to add or overwrite"`
+	Remove []string          `json:"remove,omitempty" jsonschema-description:"Attribute keys to remove"`
+}
+
+// UpdateBlockAttributesOutput is the output schema for the update_block_attributes MCP tool.
+type UpdateBlockAttributesOutput struct {
+	Block BlockDetail `json:"block"`
+}
+
+// SearchBlocksInput is the input schema for the search_blocks MCP tool.
+type SearchBlocksInput struct {
+	Query      string            `json:"query,omitempty" jsonschema-description:"Case-insensitive text to look for in block content"`
+	StartDate  string            `json:"start_date,omitempty" jsonschema-description:"Date lower bound (inclusive), e.g. 2026-03-05, -7d or last week"`
+	EndDate    string            `json:"end_date,omitempty" jsonschema-description:"Date upper bound (inclusive), e.g. 2026-03-05, yesterday or last week"`
+	Attributes map[string]string `json:"attributes,omitempty" jsonschema-description:"Only blocks with all of these attribute values, e.g. {\"type\": \"decision\"}"`
+	Limit      int               `json:"limit" jsonschema-description:"Maximum number of results (0 = all)"`
+	Offset     int               `json:"offset,omitempty" jsonschema-description:"Number of results to skip"`
+}
+
+// SearchBlocksOutput is the output schema for the search_blocks MCP tool.
+type SearchBlocksOutput struct {
+	Blocks []BlockDetail `json:"blocks" jsonschema-description:"Matching blocks, newest day first"`
+}
@@ -0,0 +1,39 @@
+package mcptools
+
+import (
+	"context"
+	"strings"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// UpdateEntryHandler returns the handler function for the update_entry MCP tool.
+func UpdateEntryHandler(store storage.Storage, dataDir string) func(ctx context.Context, req *mcp.CallToolRequest, input UpdateEntryInput) (*mcp.CallToolResult, UpdateEntryOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input UpdateEntryInput) (*mcp.CallToolResult, UpdateEntryOutput, error) {
+		e, err := getEntry(store, input.ID)
+		if err != nil {
+			return nil, UpdateEntryOutput{}, err
+		}
+
+		content := input.Content
+		if input.Append {
+			if err := entry.ValidateContent(content); err != nil {
+				return nil, UpdateEntryOutput{}, err
+			}
+			content = strings.TrimRight(e.Content, "\n") + "\n" + strings.TrimSpace(content)
+		}
+		if err := entry.ValidateContent(content); err != nil {
+			return nil, UpdateEntryOutput{}, err
+		}
+
+		updated, err := store.Update(e.ID, content, nil)
+		if err != nil {
+			return nil, UpdateEntryOutput{}, err
+		}
+		invalidateCache(dataDir)
+
+		return nil, UpdateEntryOutput{Entry: toEntryDetail(updated)}, nil
+	}
+}
@@ -0,0 +1,43 @@
+package mcptools_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/mcptools"
+)
+
+func TestMCPServer_UpdateEntry(t *testing.T) {
+	store, session := newTestSession(t)
+	now := time.Now()
+	if err := store.Create(entry.Entry{ID: "update01", Content: "original", CreatedAt: now, UpdatedAt: now}); err != nil {
+		t.Fatalf("failed to create entry: %v", err)
+	}
+
+	t.Run("appends content", func(t *testing.T) {
+		var output mcptools.UpdateEntryOutput
+		callTool(t, session, "update_entry", mcptools.UpdateEntryInput{ID: "update01", Content: "more", Append: true}, &output)
+		if output.Entry.Content != "original\nmore" {
+			t.Errorf("content = %q", output.Entry.Content)
+		}
+	})
+
+	t.Run("replaces content", func(t *testing.T) {
+		callTool(t, session, "update_entry", mcptools.UpdateEntryInput{ID: "update01", Content: "rewritten"}, nil)
+		e, err := store.Get("update01")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if e.Content != "rewritten" {
+			t.Errorf("stored content = %q, want %q", e.Content, "rewritten")
+		}
+	})
+
+	t.Run("rejects empty content", func(t *testing.T) {
+		result := callTool(t, session, "update_entry", mcptools.UpdateEntryInput{ID: "update01", Content: "  "}, nil)
+		if !result.IsError {
+			t.Error("expected IsError for empty content")
+		}
+	})
+}
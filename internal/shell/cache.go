@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
 )
 
 const cacheFileName = ".prompt-cache"
@@ -16,6 +18,7 @@ type PromptCache struct {
 	TodayDate       string    `json:"today_date"`
 	DefaultTemplate string    `json:"default_template"`
 	StorageBackend  string    `json:"storage_backend"`
+	Memories        int       `json:"memories,omitempty"`
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
@@ -54,7 +57,7 @@ func (c *PromptCache) IsFresh(ttl time.Duration) bool {
 		return false
 	}
 	now := time.Now()
-	today := now.Format("2006-01-02")
+	today := day.Today().Format("2006-01-02")
 
 	// Date changed — always stale
 	if c.TodayDate != today {
@@ -0,0 +1,21 @@
+package shell
+
+import (
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/daily"
+)
+
+// CountMemories returns the number of "on this day" entries for now: entries
+// from one week and one month ago and from the same date in previous years.
+func CountMemories(store daily.MemoryStore, now time.Time) (int, error) {
+	memories, err := daily.OnThisDay(store, now)
+	if err != nil {
+		return 0, err
+	}
+	n := 0
+	for _, m := range memories {
+		n += len(m.Entries)
+	}
+	return n, nil
+}
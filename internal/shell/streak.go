@@ -1,16 +1,14 @@
 package shell
 
 import (
-	"time"
-
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 )
 
 // ComputeStatus queries the storage backend and computes the prompt status:
 // whether today has an entry and the current streak of consecutive days.
 func ComputeStatus(store storage.Storage) (todayExists bool, streak int, err error) {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	today := day.Today()
 
 	// Query a generous window for streak computation.
 	// 365 days should cover any reasonable streak.
@@ -0,0 +1,181 @@
+// Package stats builds writing statistics from storage aggregates.
+package stats
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// Period is the writing done in one day, week or month.
+type Period struct {
+	Period  string `json:"period"`
+	Entries int    `json:"entries"`
+	Words   int    `json:"words"`
+}
+
+// Streaks describes runs of consecutive days with entries.
+type Streaks struct {
+	Current int     `json:"current"` // run ending today
+	Longest int     `json:"longest"`
+	Average float64 `json:"average"` // mean length of all runs
+}
+
+// Report holds writing statistics.
+type Report struct {
+	By             string              `json:"by"`
+	Entries        int                 `json:"entries"`
+	Words          int                 `json:"words"`
+	ActiveDays     int                 `json:"active_days"`
+	FirstDay       string              `json:"first_day,omitempty"`
+	LastDay        string              `json:"last_day,omitempty"`
+	WordsPerEntry  float64             `json:"words_per_entry"`
+	Periods        []Period            `json:"periods"` // oldest first, including empty periods
+	Streaks        Streaks             `json:"streaks"`
+	Weekdays       [7]int              `json:"weekdays"` // entries per weekday, Sunday first
+	BusiestWeekday string              `json:"busiest_weekday,omitempty"`
+	Hours          [24]int             `json:"hours"`        // entries by hour of creation
+	JotHours       [24]int             `json:"jot_hours"`    // jotted lines by timestamp hour
+	BusiestHour    int                 `json:"busiest_hour"` // -1 when there are no entries
+	Contexts       []storage.NameCount `json:"top_contexts"`
+	Templates      []storage.NameCount `json:"top_templates"`
+}
+
+// Build turns aggregates into a report with periods of by ("day", "week" or
+// "month"). Streaks are measured up to today.
+func Build(agg storage.Aggregates, by string, today time.Time) (Report, error) {
+	if _, err := periodStart(today, by); err != nil {
+		return Report{}, err
+	}
+	r := Report{By: by, Periods: []Period{}, BusiestHour: -1, Hours: agg.Hours, JotHours: agg.JotHours,
+		Contexts: agg.Contexts, Templates: agg.Templates}
+	if r.Contexts == nil {
+		r.Contexts = []storage.NameCount{}
+	}
+	if r.Templates == nil {
+		r.Templates = []storage.NameCount{}
+	}
+	if len(agg.Days) == 0 {
+		return r, nil
+	}
+
+	for _, d := range agg.Days {
+		r.Entries += d.Entries
+		r.Words += d.Words
+		r.Weekdays[d.Date.Weekday()] += d.Entries
+	}
+	r.ActiveDays = len(agg.Days)
+	r.FirstDay = agg.Days[0].Date.Format("2006-01-02")
+	r.LastDay = agg.Days[len(agg.Days)-1].Date.Format("2006-01-02")
+	r.WordsPerEntry = float64(r.Words) / float64(r.Entries)
+	r.Periods = periods(agg.Days, by)
+	r.Streaks = streaks(agg.Days, today)
+
+	busiest := 0
+	for wd, n := range r.Weekdays {
+		if n > r.Weekdays[busiest] {
+			busiest = wd
+		}
+	}
+	r.BusiestWeekday = time.Weekday(busiest).String()
+	for h, n := range r.Hours {
+		if n > 0 && (r.BusiestHour < 0 || n > r.Hours[r.BusiestHour]) {
+			r.BusiestHour = h
+		}
+	}
+	return r, nil
+}
+
+// periodStart returns the start of the day, ISO week or month containing t.
+func periodStart(t time.Time, by string) (time.Time, error) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch by {
+	case "day":
+		return day, nil
+	case "week":
+		offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+		return day.AddDate(0, 0, -offset), nil
+	case "month":
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location()), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid period %q: expected day, week or month", by)
+}
+
+// nextPeriod returns the start of the period after the one starting at start.
+func nextPeriod(start time.Time, by string) time.Time {
+	switch by {
+	case "week":
+		return start.AddDate(0, 0, 7)
+	case "month":
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// periodLabel names the period starting at start: "2006-01-02", "2006-W01"
+// (ISO week) or "2006-01".
+func periodLabel(start time.Time, by string) string {
+	switch by {
+	case "week":
+		y, w := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	case "month":
+		return start.Format("2006-01")
+	}
+	return start.Format("2006-01-02")
+}
+
+// periods buckets days (oldest first) into consecutive periods from the
+// first day to the last, including periods without entries.
+func periods(days []storage.DayTotal, by string) []Period {
+	var out []Period
+	start, _ := periodStart(days[0].Date, by)
+	i := 0
+	for i < len(days) {
+		next := nextPeriod(start, by)
+		p := Period{Period: periodLabel(start, by)}
+		for ; i < len(days) && days[i].Date.Before(next); i++ {
+			p.Entries += days[i].Entries
+			p.Words += days[i].Words
+		}
+		out = append(out, p)
+		start = next
+	}
+	return out
+}
+
+// streaks measures runs of consecutive days (oldest first) with entries.
+func streaks(days []storage.DayTotal, today time.Time) Streaks {
+	var s Streaks
+	var runs []int
+	run := 0
+	for i, d := range days {
+		if i > 0 && sameDay(days[i-1].Date.AddDate(0, 0, 1), d.Date) {
+			run++
+		} else {
+			if run > 0 {
+				runs = append(runs, run)
+			}
+			run = 1
+		}
+	}
+	runs = append(runs, run)
+
+	total := 0
+	for _, n := range runs {
+		total += n
+		s.Longest = max(s.Longest, n)
+	}
+	s.Average = float64(total) / float64(len(runs))
+	if sameDay(days[len(days)-1].Date, today) {
+		s.Current = runs[len(runs)-1]
+	}
+	return s
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
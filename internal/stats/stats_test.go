@@ -0,0 +1,74 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+func TestBuild(t *testing.T) {
+	agg := storage.Aggregates{
+		Days: []storage.DayTotal{
+			{Date: day(2026, 2, 23), Entries: 1, Words: 10}, // Monday
+			{Date: day(2026, 2, 24), Entries: 2, Words: 30},
+			{Date: day(2026, 2, 25), Entries: 1, Words: 5},
+			{Date: day(2026, 3, 3), Entries: 3, Words: 15}, // Tuesday, two weeks later
+			{Date: day(2026, 3, 16), Entries: 1, Words: 20},
+			{Date: day(2026, 3, 17), Entries: 1, Words: 20},
+		},
+		Contexts: []storage.NameCount{{Name: "work", Count: 4}},
+	}
+	agg.Hours[9] = 6
+	agg.Hours[21] = 3
+
+	r, err := Build(agg, "week", day(2026, 3, 17))
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if r.Entries != 9 || r.Words != 100 || r.ActiveDays != 6 || r.FirstDay != "2026-02-23" || r.LastDay != "2026-03-17" {
+		t.Errorf("totals = %+v", r)
+	}
+	wantPeriods := []Period{
+		{"2026-W09", 4, 45},
+		{"2026-W10", 3, 15},
+		{"2026-W11", 0, 0},
+		{"2026-W12", 2, 40},
+	}
+	if !reflect.DeepEqual(r.Periods, wantPeriods) {
+		t.Errorf("periods = %+v, want %+v", r.Periods, wantPeriods)
+	}
+	if r.Streaks != (Streaks{Current: 2, Longest: 3, Average: 2}) {
+		t.Errorf("streaks = %+v", r.Streaks)
+	}
+	if r.BusiestWeekday != "Tuesday" || r.Weekdays[time.Tuesday] != 6 || r.BusiestHour != 9 {
+		t.Errorf("busiest weekday %q (%v), hour %d", r.BusiestWeekday, r.Weekdays, r.BusiestHour)
+	}
+	if len(r.Templates) != 0 || r.Contexts[0].Name != "work" {
+		t.Errorf("contexts = %v, templates = %v", r.Contexts, r.Templates)
+	}
+
+	// No entry today ends the current streak
+	r, _ = Build(agg, "month", day(2026, 3, 19))
+	if r.Streaks.Current != 0 || len(r.Periods) != 2 || r.Periods[1] != (Period{"2026-03", 5, 55}) {
+		t.Errorf("month report = %+v", r)
+	}
+}
+
+func TestBuild_Empty(t *testing.T) {
+	r, err := Build(storage.Aggregates{}, "day", time.Now())
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+	if r.Entries != 0 || len(r.Periods) != 0 || r.BusiestHour != -1 || r.BusiestWeekday != "" {
+		t.Errorf("empty report = %+v", r)
+	}
+	if _, err := Build(storage.Aggregates{}, "year", time.Now()); err == nil {
+		t.Error("expected error for invalid period")
+	}
+}
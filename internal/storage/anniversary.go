@@ -0,0 +1,35 @@
+package storage
+
+import "time"
+
+// AnniversaryProvider is implemented by backends that find the days with
+// entries on a month and day natively instead of listing every day.
+type AnniversaryProvider interface {
+	AnniversaryDays(month time.Month, day int, before time.Time) ([]time.Time, error)
+}
+
+// DayLister is the subset of Storage needed to find anniversary days.
+type DayLister interface {
+	ListDays(opts ListDaysOptions) ([]DaySummary, error)
+}
+
+// AnniversaryDays returns the days before the day of before that fall on
+// month and day and have entries, newest first. Dates are in the local
+// timezone with the time part zeroed.
+func AnniversaryDays(s DayLister, month time.Month, day int, before time.Time) ([]time.Time, error) {
+	if ap, ok := s.(AnniversaryProvider); ok {
+		return ap.AnniversaryDays(month, day, before)
+	}
+	end := time.Date(before.Year(), before.Month(), before.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, -1)
+	days, err := s.ListDays(ListDaysOptions{EndDate: &end})
+	if err != nil {
+		return nil, err
+	}
+	var out []time.Time
+	for _, d := range days {
+		if d.Date.Month() == month && d.Date.Day() == day {
+			out = append(out, d.Date)
+		}
+	}
+	return out, nil
+}
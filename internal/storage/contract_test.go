@@ -6,10 +6,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
 	"github.com/chris-regnier/diaryctl/internal/storage/sqlite"
+	"github.com/chris-regnier/diaryctl/internal/template"
 )
 
 type storageFactory func(t *testing.T) storage.Storage
@@ -163,6 +165,39 @@ func runContractTests(t *testing.T, name string, factory storageFactory) {
 			}
 		})
 
+		t.Run("List until", func(t *testing.T) {
+			s := factory(t)
+			base := dateLocalAt(2026, 1, 15, 12, 0)
+			var created []entry.Entry
+			for i, offset := range []time.Duration{0, time.Hour, time.Hour, 25 * time.Hour} {
+				e := makeEntryAt(t, "entry "+string(rune('A'+i)), base.Add(offset))
+				if err := s.Create(e); err != nil {
+					t.Fatalf("Create %d: %v", i, err)
+				}
+				created = append(created, e)
+			}
+
+			until := base.Add(time.Hour)
+			entries, err := s.List(storage.ListOptions{Until: &until})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(entries) != 3 {
+				t.Fatalf("expected 3 entries at or before until, got %d", len(entries))
+			}
+			// Entries created at the same instant are ordered by ID descending
+			tied := []string{created[1].ID, created[2].ID}
+			if tied[0] < tied[1] {
+				tied[0], tied[1] = tied[1], tied[0]
+			}
+			want := []string{tied[0], tied[1], created[0].ID}
+			for i, e := range entries {
+				if e.ID != want[i] {
+					t.Errorf("entries[%d] = %s, want %s", i, e.ID, want[i])
+				}
+			}
+		})
+
 		t.Run("List date filter", func(t *testing.T) {
 			s := factory(t)
 			jan15 := dateLocalAt(2026, 1, 15, 12, 0)
@@ -631,6 +666,47 @@ func runTemplateContractTests(t *testing.T, name string, factory storageFactory)
 			}
 		})
 
+		t.Run("Template attributes", func(t *testing.T) {
+			s := factory(t)
+			tmpl := makeTemplate(t, "standup", "## Standup")
+			tmpl.Attributes = map[string]string{"type": "standup"}
+			if err := s.CreateTemplate(tmpl); err != nil {
+				t.Fatalf("CreateTemplate: %v", err)
+			}
+			got, err := s.GetTemplateByName("standup")
+			if err != nil || got.Attributes["type"] != "standup" {
+				t.Fatalf("attributes after create = %v (%v)", got.Attributes, err)
+			}
+			updated, err := s.UpdateTemplate(tmpl.ID, "standup", "## Standup", map[string]string{"type": "meeting"})
+			if err != nil || updated.Attributes["type"] != "meeting" {
+				t.Errorf("attributes after update = %v (%v)", updated.Attributes, err)
+			}
+			list, err := s.ListTemplates()
+			if err != nil || len(list) != 1 || list[0].Attributes["type"] != "meeting" {
+				t.Errorf("listed attributes = %+v (%v)", list, err)
+			}
+		})
+
+		t.Run("Import pack twice", func(t *testing.T) {
+			s := factory(t)
+			pack, err := template.Pack("standup-pack")
+			if err != nil {
+				t.Fatalf("Pack: %v", err)
+			}
+			if _, err := template.Import(s, pack); err != nil {
+				t.Fatalf("first Import: %v", err)
+			}
+			results, err := template.Import(s, pack)
+			if err != nil {
+				t.Fatalf("second Import: %v", err)
+			}
+			for _, r := range results {
+				if r.Action != template.ImportUnchanged {
+					t.Errorf("second import of %s: %v, want unchanged", r.Name, r.Action)
+				}
+			}
+		})
+
 		t.Run("UpdateTemplate not found", func(t *testing.T) {
 			s := factory(t)
 			_, err := s.UpdateTemplate("nonexist", "name", "content", nil)
@@ -660,6 +736,45 @@ func runTemplateContractTests(t *testing.T, name string, factory storageFactory)
 				t.Errorf("expected ErrNotFound, got %v", err)
 			}
 		})
+
+		t.Run("Template versions", func(t *testing.T) {
+			s := factory(t)
+			tmpl := makeTemplate(t, "daily", "v1 content")
+			_ = s.CreateTemplate(tmpl)
+			got, _ := s.GetTemplate(tmpl.ID)
+			if got.Version != 1 {
+				t.Errorf("new template version = %d, want 1", got.Version)
+			}
+
+			updated, err := s.UpdateTemplate(tmpl.ID, "daily", "v2 content", nil)
+			if err != nil {
+				t.Fatalf("UpdateTemplate: %v", err)
+			}
+			if updated.Version != 2 {
+				t.Errorf("version after content change = %d, want 2", updated.Version)
+			}
+			// Renaming without a content change keeps the version
+			renamed, err := s.UpdateTemplate(tmpl.ID, "daily-log", "v2 content", nil)
+			if err != nil {
+				t.Fatalf("UpdateTemplate: %v", err)
+			}
+			if renamed.Version != 2 {
+				t.Errorf("version after rename = %d, want 2", renamed.Version)
+			}
+
+			versions, err := s.ListTemplateVersions(tmpl.ID)
+			if err != nil {
+				t.Fatalf("ListTemplateVersions: %v", err)
+			}
+			if len(versions) != 2 || versions[0].Version != 1 || versions[0].Content != "v1 content" || versions[1].Content != "v2 content" {
+				t.Errorf("unexpected versions: %+v", versions)
+			}
+
+			_ = s.DeleteTemplate(tmpl.ID)
+			if _, err := s.ListTemplateVersions(tmpl.ID); !errors.Is(err, storage.ErrNotFound) {
+				t.Errorf("expected ErrNotFound after delete, got %v", err)
+			}
+		})
 	})
 }
 
@@ -688,6 +803,24 @@ func runAttributionContractTests(t *testing.T, name string, factory storageFacto
 			}
 		})
 
+		t.Run("Template ref keeps version", func(t *testing.T) {
+			s := factory(t)
+			e := makeEntry(t, "versioned")
+			e.Templates = []entry.TemplateRef{
+				{TemplateID: "t1", TemplateName: "daily", TemplateVersion: 3},
+			}
+			if err := s.Create(e); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			got, err := s.Get(e.ID)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if len(got.Templates) != 1 || got.Templates[0].TemplateVersion != 3 {
+				t.Errorf("expected template version 3, got %v", got.Templates)
+			}
+		})
+
 		t.Run("Create entry without template refs", func(t *testing.T) {
 			s := factory(t)
 			e := makeEntry(t, "no templates")
@@ -991,11 +1124,283 @@ func containsValidation(err error) bool {
 	return false
 }
 
+func runStatsContractTests(t *testing.T, name string, factory storageFactory) {
+	t.Run(name+" Stats", func(t *testing.T) {
+		s := factory(t)
+		work := makeContext(t, "work", "manual")
+		if err := s.CreateContext(work); err != nil {
+			t.Fatalf("CreateContext: %v", err)
+		}
+		e1 := makeEntryAt(t, "- **09:15** started the day\n- **14:30** reviewed PRs", dateLocalAt(2026, 3, 2, 9, 15))
+		e1.Templates = []entry.TemplateRef{{TemplateID: "tmpl0001", TemplateName: "daily"}}
+		e2 := makeEntryAt(t, "quiet evening", dateLocalAt(2026, 3, 2, 21, 0))
+		e3 := makeEntryAt(t, "one two three", dateLocalAt(2026, 3, 4, 9, 40))
+		for _, e := range []entry.Entry{e1, e2, e3} {
+			if err := s.Create(e); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+		for _, e := range []entry.Entry{e1, e3} {
+			if err := s.AttachContext(e.ID, work.ID); err != nil {
+				t.Fatalf("AttachContext: %v", err)
+			}
+		}
+
+		t.Run("all entries", func(t *testing.T) {
+			agg, err := storage.Aggregate(s, storage.StatsOptions{})
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+			if len(agg.Days) != 2 ||
+				!agg.Days[0].Date.Equal(dateLocal(2026, 3, 2)) || agg.Days[0].Entries != 2 || agg.Days[0].Words != 11 ||
+				!agg.Days[1].Date.Equal(dateLocal(2026, 3, 4)) || agg.Days[1].Entries != 1 || agg.Days[1].Words != 3 {
+				t.Errorf("days = %+v", agg.Days)
+			}
+			if agg.Hours[9] != 2 || agg.Hours[21] != 1 {
+				t.Errorf("hours = %v", agg.Hours)
+			}
+			if agg.JotHours[9] != 1 || agg.JotHours[14] != 1 || agg.JotHours[21] != 0 {
+				t.Errorf("jot hours = %v", agg.JotHours)
+			}
+			if len(agg.Contexts) != 1 || agg.Contexts[0] != (storage.NameCount{Name: "work", Count: 2}) {
+				t.Errorf("contexts = %+v", agg.Contexts)
+			}
+			if len(agg.Templates) != 1 || agg.Templates[0] != (storage.NameCount{Name: "daily", Count: 1}) {
+				t.Errorf("templates = %+v", agg.Templates)
+			}
+		})
+
+		t.Run("by context and date", func(t *testing.T) {
+			agg, err := storage.Aggregate(s, storage.StatsOptions{ContextName: "work"})
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+			if len(agg.Days) != 2 || agg.Days[0].Entries != 1 || agg.Days[1].Entries != 1 {
+				t.Errorf("days = %+v", agg.Days)
+			}
+			start := dateLocal(2026, 3, 3)
+			agg, err = storage.Aggregate(s, storage.StatsOptions{StartDate: &start})
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+			if len(agg.Days) != 1 || agg.Days[0].Words != 3 || agg.JotHours[9] != 0 || len(agg.Templates) != 0 {
+				t.Errorf("unexpected aggregates since %s: %+v", start.Format("2006-01-02"), agg)
+			}
+		})
+
+		t.Run("word counts follow updates", func(t *testing.T) {
+			if _, err := s.Update(e2.ID, "a much longer quiet evening", nil); err != nil {
+				t.Fatalf("Update: %v", err)
+			}
+			agg, err := storage.Aggregate(s, storage.StatsOptions{Top: 1})
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+			if agg.Days[0].Words != 14 {
+				t.Errorf("words = %d, want 14", agg.Days[0].Words)
+			}
+		})
+	})
+}
+
+func runAnniversaryContractTests(t *testing.T, name string, factory storageFactory) {
+	t.Run(name+" Anniversaries", func(t *testing.T) {
+		s := factory(t)
+		for _, at := range []time.Time{
+			dateLocalAt(2024, 3, 5, 23, 30),
+			dateLocalAt(2025, 3, 5, 8, 0),
+			dateLocalAt(2025, 3, 5, 9, 0),
+			dateLocalAt(2025, 3, 6, 0, 30),
+			dateLocalAt(2026, 3, 4, 12, 0),
+			dateLocalAt(2026, 3, 5, 7, 0),
+		} {
+			if err := s.Create(makeEntryAt(t, "memory", at)); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+
+		days, err := storage.AnniversaryDays(s, time.March, 5, dateLocalAt(2026, 3, 5, 18, 0))
+		if err != nil {
+			t.Fatalf("AnniversaryDays: %v", err)
+		}
+		if len(days) != 2 || !days[0].Equal(dateLocal(2025, 3, 5)) || !days[1].Equal(dateLocal(2024, 3, 5)) {
+			t.Errorf("days = %v, want 2025-03-05 and 2024-03-05", days)
+		}
+
+		days, err = storage.AnniversaryDays(s, time.March, 4, dateLocal(2026, 3, 4))
+		if err != nil {
+			t.Fatalf("AnniversaryDays: %v", err)
+		}
+		if len(days) != 0 {
+			t.Errorf("days = %v, want none before the day itself", days)
+		}
+	})
+}
+
+func runDayBoundaryContractTests(t *testing.T, name string, factory storageFactory) {
+	t.Run(name+" Day Boundary", func(t *testing.T) {
+		day.SetStartsAt(4 * time.Hour)
+		t.Cleanup(func() { day.SetStartsAt(0) })
+
+		s := factory(t)
+		for _, at := range []time.Time{
+			dateLocalAt(2026, 3, 5, 23, 30),
+			dateLocalAt(2026, 3, 6, 1, 30), // before 04:00: still March 5th
+			dateLocalAt(2026, 3, 6, 4, 30),
+			dateLocalAt(2026, 3, 7, 3, 59), // before 04:00: still March 6th
+		} {
+			if err := s.Create(makeEntryAt(t, "late night", at)); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+
+		t.Run("List date filters", func(t *testing.T) {
+			for _, d := range []time.Time{dateLocal(2026, 3, 5), dateLocal(2026, 3, 6)} {
+				entries, err := s.List(storage.ListOptions{Date: &d})
+				if err != nil {
+					t.Fatalf("List: %v", err)
+				}
+				if len(entries) != 2 {
+					t.Errorf("%s: got %d entries, want 2", d.Format("2006-01-02"), len(entries))
+				}
+			}
+			start, end := dateLocal(2026, 3, 6), dateLocal(2026, 3, 7)
+			entries, err := s.List(storage.ListOptions{StartDate: &start, EndDate: &end})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(entries) != 2 {
+				t.Errorf("range: got %d entries, want 2", len(entries))
+			}
+		})
+
+		t.Run("ListDays", func(t *testing.T) {
+			days, err := s.ListDays(storage.ListDaysOptions{})
+			if err != nil {
+				t.Fatalf("ListDays: %v", err)
+			}
+			if len(days) != 2 ||
+				!days[0].Date.Equal(dateLocal(2026, 3, 6)) || days[0].Count != 2 ||
+				!days[1].Date.Equal(dateLocal(2026, 3, 5)) || days[1].Count != 2 {
+				t.Errorf("days = %+v, want 2 entries on each of 2026-03-06 and 2026-03-05", days)
+			}
+			start := dateLocal(2026, 3, 6)
+			days, err = s.ListDays(storage.ListDaysOptions{StartDate: &start})
+			if err != nil {
+				t.Fatalf("ListDays: %v", err)
+			}
+			if len(days) != 1 || days[0].Count != 2 {
+				t.Errorf("days since 2026-03-06 = %+v", days)
+			}
+		})
+
+		t.Run("Aggregate", func(t *testing.T) {
+			agg, err := storage.Aggregate(s, storage.StatsOptions{})
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+			if len(agg.Days) != 2 || agg.Days[0].Entries != 2 || agg.Days[1].Entries != 2 {
+				t.Errorf("days = %+v", agg.Days)
+			}
+			// Hours stay on the clock
+			if agg.Hours[1] != 1 || agg.Hours[3] != 1 {
+				t.Errorf("hours = %v", agg.Hours)
+			}
+		})
+
+		t.Run("AnniversaryDays", func(t *testing.T) {
+			days, err := storage.AnniversaryDays(s, time.March, 6, dateLocal(2027, 3, 6))
+			if err != nil {
+				t.Fatalf("AnniversaryDays: %v", err)
+			}
+			if len(days) != 1 || !days[0].Equal(dateLocal(2026, 3, 6)) {
+				t.Errorf("days = %v, want 2026-03-06", days)
+			}
+		})
+	})
+}
+
+func runTimeZoneContractTests(t *testing.T, name string, factory storageFactory) {
+	t.Run(name+" Time Zones", func(t *testing.T) {
+		s := factory(t)
+		// 01:00 on March 6th in Tokyo, 22:00 on March 5th in Los Angeles
+		tokyo := makeEntryAt(t, "written in Tokyo", time.Date(2026, 3, 5, 16, 0, 0, 0, time.UTC))
+		tokyo.TimeZone = "Asia/Tokyo"
+		la := makeEntryAt(t, "written in LA", time.Date(2026, 3, 6, 6, 0, 0, 0, time.UTC))
+		la.TimeZone = "America/Los_Angeles"
+		for _, e := range []entry.Entry{tokyo, la} {
+			if err := s.Create(e); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+		}
+
+		t.Run("records zone", func(t *testing.T) {
+			got, err := s.Get(tokyo.ID)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got.TimeZone != "Asia/Tokyo" {
+				t.Errorf("TimeZone = %q, want Asia/Tokyo", got.TimeZone)
+			}
+			local := makeEntry(t, "written here")
+			if err := s.Create(local); err != nil {
+				t.Fatalf("Create: %v", err)
+			}
+			got, err = s.Get(local.ID)
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got.TimeZone != day.Zone() {
+				t.Errorf("TimeZone = %q, want the local zone %q", got.TimeZone, day.Zone())
+			}
+			if err := s.Delete(local.ID); err != nil {
+				t.Fatalf("Delete: %v", err)
+			}
+		})
+
+		t.Run("days follow recorded zone", func(t *testing.T) {
+			for d, want := range map[time.Time]string{
+				dateLocal(2026, 3, 5): la.ID,
+				dateLocal(2026, 3, 6): tokyo.ID,
+			} {
+				entries, err := s.List(storage.ListOptions{Date: &d})
+				if err != nil {
+					t.Fatalf("List: %v", err)
+				}
+				if len(entries) != 1 || entries[0].ID != want {
+					t.Errorf("%s: got %d entries, want %s", d.Format("2006-01-02"), len(entries), want)
+				}
+			}
+			days, err := s.ListDays(storage.ListDaysOptions{})
+			if err != nil {
+				t.Fatalf("ListDays: %v", err)
+			}
+			if len(days) != 2 || !days[0].Date.Equal(dateLocal(2026, 3, 6)) || !days[1].Date.Equal(dateLocal(2026, 3, 5)) {
+				t.Errorf("days = %+v, want 2026-03-06 and 2026-03-05", days)
+			}
+		})
+
+		t.Run("hours follow recorded zone", func(t *testing.T) {
+			agg, err := storage.Aggregate(s, storage.StatsOptions{})
+			if err != nil {
+				t.Fatalf("Aggregate: %v", err)
+			}
+			if agg.Hours[1] != 1 || agg.Hours[22] != 1 {
+				t.Errorf("hours = %v, want 01:00 and 22:00", agg.Hours)
+			}
+		})
+	})
+}
+
 func TestMarkdownStorage(t *testing.T) {
 	runContractTests(t, "Markdown", markdownFactory)
 	runTemplateContractTests(t, "Markdown", markdownFactory)
 	runAttributionContractTests(t, "Markdown", markdownFactory)
 	runContextContractTests(t, "Markdown", markdownFactory)
+	runStatsContractTests(t, "Markdown", markdownFactory)
+	runAnniversaryContractTests(t, "Markdown", markdownFactory)
+	runDayBoundaryContractTests(t, "Markdown", markdownFactory)
+	runTimeZoneContractTests(t, "Markdown", markdownFactory)
 }
 
 func TestSQLiteStorage(t *testing.T) {
@@ -1003,4 +1408,8 @@ func TestSQLiteStorage(t *testing.T) {
 	runTemplateContractTests(t, "SQLite", sqliteFactory)
 	runAttributionContractTests(t, "SQLite", sqliteFactory)
 	runContextContractTests(t, "SQLite", sqliteFactory)
+	runStatsContractTests(t, "SQLite", sqliteFactory)
+	runAnniversaryContractTests(t, "SQLite", sqliteFactory)
+	runDayBoundaryContractTests(t, "SQLite", sqliteFactory)
+	runTimeZoneContractTests(t, "SQLite", sqliteFactory)
 }
@@ -7,11 +7,13 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/adrg/frontmatter"
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 )
@@ -56,17 +58,40 @@ func (s *Store) entryPath(e entry.Entry) string {
 	return filepath.Join(s.baseDir, t.Format("2006"), t.Format("01"), t.Format("02"), e.ID+".md")
 }
 
+// dayDirAfter reports whether path is a YYYY/MM/DD entry directory that can
+// only hold entries created after until. Directories are named from CreatedAt
+// in whatever zone it was stored in, so a day of slack covers any offset.
+func (s *Store) dayDirAfter(path string, until time.Time) bool {
+	rel, err := filepath.Rel(s.baseDir, path)
+	if err != nil {
+		return false
+	}
+	d, err := time.Parse("2006/01/02", filepath.ToSlash(rel))
+	if err != nil {
+		return false
+	}
+	u := until.UTC()
+	last := time.Date(u.Year(), u.Month(), u.Day(), 0, 0, 0, 0, time.UTC).AddDate(0, 0, 1)
+	return d.After(last)
+}
+
 func (s *Store) marshal(e entry.Entry) []byte {
 	var b strings.Builder
 	b.WriteString("---\n")
 	fmt.Fprintf(&b, "id: %s\n", e.ID)
 	fmt.Fprintf(&b, "created_at: %s\n", e.CreatedAt.UTC().Format(time.RFC3339))
 	fmt.Fprintf(&b, "updated_at: %s\n", e.UpdatedAt.UTC().Format(time.RFC3339))
+	if e.TimeZone != "" {
+		fmt.Fprintf(&b, "timezone: %s\n", e.TimeZone)
+	}
 	if len(e.Templates) > 0 {
 		b.WriteString("templates:\n")
 		for _, ref := range e.Templates {
 			fmt.Fprintf(&b, "  - template_id: %s\n", ref.TemplateID)
 			fmt.Fprintf(&b, "    template_name: %s\n", ref.TemplateName)
+			if ref.TemplateVersion > 0 {
+				fmt.Fprintf(&b, "    template_version: %d\n", ref.TemplateVersion)
+			}
 		}
 	}
 	if len(e.Contexts) > 0 {
@@ -82,8 +107,9 @@ func (s *Store) marshal(e entry.Entry) []byte {
 }
 
 type fmTemplateRef struct {
-	TemplateID   string `yaml:"template_id"`
-	TemplateName string `yaml:"template_name"`
+	TemplateID      string `yaml:"template_id"`
+	TemplateName    string `yaml:"template_name"`
+	TemplateVersion int    `yaml:"template_version"`
 }
 
 type fmContextRef struct {
@@ -95,6 +121,7 @@ type frontMatter struct {
 	ID        string          `yaml:"id"`
 	CreatedAt string          `yaml:"created_at"`
 	UpdatedAt string          `yaml:"updated_at"`
+	TimeZone  string          `yaml:"timezone"`
 	Templates []fmTemplateRef `yaml:"templates"`
 	Contexts  []fmContextRef  `yaml:"contexts"`
 }
@@ -118,8 +145,9 @@ func (s *Store) unmarshal(data []byte) (entry.Entry, error) {
 	var templates []entry.TemplateRef
 	for _, ref := range fm.Templates {
 		templates = append(templates, entry.TemplateRef{
-			TemplateID:   ref.TemplateID,
-			TemplateName: ref.TemplateName,
+			TemplateID:      ref.TemplateID,
+			TemplateName:    ref.TemplateName,
+			TemplateVersion: ref.TemplateVersion,
 		})
 	}
 
@@ -136,6 +164,7 @@ func (s *Store) unmarshal(data []byte) (entry.Entry, error) {
 		Content:   strings.TrimSpace(string(content)),
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+		TimeZone:  fm.TimeZone,
 		Templates: templates,
 		Contexts:  contexts,
 	}, nil
@@ -186,6 +215,11 @@ func (s *Store) Create(e entry.Entry) error {
 		return fmt.Errorf("%w: %v", storage.ErrValidation, err)
 	}
 
+	// Record the zone the entry is written in, so its day stays put
+	if e.TimeZone == "" {
+		e.TimeZone = day.Zone()
+	}
+
 	path := s.entryPath(e)
 
 	// Check if file already exists
@@ -244,7 +278,13 @@ func (s *Store) List(opts storage.ListOptions) ([]entry.Entry, error) {
 		if err != nil {
 			return nil
 		}
-		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+		if d.IsDir() {
+			if opts.Until != nil && s.dayDirAfter(path, *opts.Until) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(d.Name(), ".md") {
 			return nil
 		}
 
@@ -258,7 +298,11 @@ func (s *Store) List(opts storage.ListOptions) ([]entry.Entry, error) {
 			return nil // skip malformed files
 		}
 
-		entryDate := localDate(e.CreatedAt)
+		if opts.Until != nil && e.CreatedAt.After(*opts.Until) {
+			return nil
+		}
+
+		entryDate := day.OfZone(e.CreatedAt, e.TimeZone)
 
 		// Date filter (takes precedence over range)
 		if opts.Date != nil {
@@ -315,9 +359,12 @@ func (s *Store) List(opts storage.ListOptions) ([]entry.Entry, error) {
 		return nil, fmt.Errorf("%w: listing entries: %v", storage.ErrStorage, err)
 	}
 
-	// Sort by created_at descending (reverse chronological)
+	// Sort by created_at descending (reverse chronological), ties by ID
 	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		}
+		return entries[i].ID > entries[j].ID
 	})
 
 	// Apply offset
@@ -362,7 +409,7 @@ func (s *Store) ListDays(opts storage.ListDaysOptions) ([]storage.DaySummary, er
 			return nil
 		}
 
-		entryDate := localDate(e.CreatedAt)
+		entryDate := day.OfZone(e.CreatedAt, e.TimeZone)
 
 		// Apply date range filters
 		if opts.StartDate != nil {
@@ -466,10 +513,12 @@ func (s *Store) Update(id string, content string, templates []entry.TemplateRef)
 // --- Template methods ---
 
 type templateFrontMatter struct {
-	ID        string `yaml:"id"`
-	Name      string `yaml:"name"`
-	CreatedAt string `yaml:"created_at"`
-	UpdatedAt string `yaml:"updated_at"`
+	ID         string            `yaml:"id"`
+	Name       string            `yaml:"name"`
+	CreatedAt  string            `yaml:"created_at"`
+	UpdatedAt  string            `yaml:"updated_at"`
+	Version    int               `yaml:"version"`
+	Attributes map[string]string `yaml:"attributes"`
 }
 
 func (s *Store) marshalTemplate(t storage.Template) []byte {
@@ -479,6 +528,18 @@ func (s *Store) marshalTemplate(t storage.Template) []byte {
 	fmt.Fprintf(&b, "name: %s\n", t.Name)
 	fmt.Fprintf(&b, "created_at: %s\n", t.CreatedAt.UTC().Format(time.RFC3339))
 	fmt.Fprintf(&b, "updated_at: %s\n", t.UpdatedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "version: %d\n", t.Version)
+	if len(t.Attributes) > 0 {
+		keys := make([]string, 0, len(t.Attributes))
+		for k := range t.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString("attributes:\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "  %s: %s\n", strconv.Quote(k), strconv.Quote(t.Attributes[k]))
+		}
+	}
 	b.WriteString("---\n\n")
 	b.WriteString(t.Content)
 	return []byte(b.String())
@@ -498,12 +559,19 @@ func (s *Store) unmarshalTemplate(data []byte) (storage.Template, error) {
 	if err != nil {
 		return storage.Template{}, fmt.Errorf("%w: parsing updated_at: %v", storage.ErrStorage, err)
 	}
+	version := fm.Version
+	if version == 0 {
+		// Templates written before versioning are at their first version.
+		version = 1
+	}
 	return storage.Template{
-		ID:        fm.ID,
-		Name:      fm.Name,
-		Content:   strings.TrimSpace(string(content)),
-		CreatedAt: createdAt,
-		UpdatedAt: updatedAt,
+		ID:         fm.ID,
+		Name:       fm.Name,
+		Content:    strings.TrimSpace(string(content)),
+		Attributes: fm.Attributes,
+		Version:    version,
+		CreatedAt:  createdAt,
+		UpdatedAt:  updatedAt,
 	}, nil
 }
 
@@ -511,6 +579,33 @@ func (s *Store) templatePath(name string) string {
 	return filepath.Join(s.templatesDir, name+".md")
 }
 
+// versionsDir holds a template's content snapshots, one file per version.
+func (s *Store) versionsDir(id string) string {
+	return filepath.Join(s.templatesDir, "versions", id)
+}
+
+func (s *Store) versionPath(id string, version int) string {
+	return filepath.Join(s.versionsDir(id), strconv.Itoa(version)+".md")
+}
+
+type versionFrontMatter struct {
+	TemplateID string `yaml:"template_id"`
+	Version    int    `yaml:"version"`
+	CreatedAt  string `yaml:"created_at"`
+}
+
+// writeVersion snapshots the template's current content.
+func (s *Store) writeVersion(t storage.Template) error {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "template_id: %s\n", t.ID)
+	fmt.Fprintf(&b, "version: %d\n", t.Version)
+	fmt.Fprintf(&b, "created_at: %s\n", t.UpdatedAt.UTC().Format(time.RFC3339))
+	b.WriteString("---\n\n")
+	b.WriteString(t.Content)
+	return s.atomicWrite(s.versionPath(t.ID, t.Version), []byte(b.String()))
+}
+
 // CreateTemplate persists a new template as a Markdown file.
 func (s *Store) CreateTemplate(t storage.Template) error {
 	if err := entry.ValidateTemplateName(t.Name); err != nil {
@@ -520,7 +615,11 @@ func (s *Store) CreateTemplate(t storage.Template) error {
 	if _, err := os.Stat(path); err == nil {
 		return fmt.Errorf("%w: template %q already exists", storage.ErrConflict, t.Name)
 	}
-	return s.atomicWrite(path, s.marshalTemplate(t))
+	t.Version = 1
+	if err := s.atomicWrite(path, s.marshalTemplate(t)); err != nil {
+		return err
+	}
+	return s.writeVersion(t)
 }
 
 // GetTemplate retrieves a template by ID by scanning the templates directory.
@@ -601,6 +700,16 @@ func (s *Store) UpdateTemplate(id string, name string, content string, attribute
 	updated.Content = content
 	updated.Attributes = attributes
 	updated.UpdatedAt = time.Now().UTC()
+	contentChanged := strings.TrimSpace(existing.Content) != strings.TrimSpace(content)
+	if contentChanged {
+		// Keep the outgoing version even if it predates versioning.
+		if _, err := os.Stat(s.versionPath(existing.ID, existing.Version)); os.IsNotExist(err) {
+			if err := s.writeVersion(existing); err != nil {
+				return storage.Template{}, err
+			}
+		}
+		updated.Version = existing.Version + 1
+	}
 
 	// If name changed, remove old file
 	if existing.Name != name {
@@ -618,9 +727,62 @@ func (s *Store) UpdateTemplate(id string, name string, content string, attribute
 	if err := s.atomicWrite(s.templatePath(name), s.marshalTemplate(updated)); err != nil {
 		return storage.Template{}, err
 	}
+	if contentChanged {
+		if err := s.writeVersion(updated); err != nil {
+			return storage.Template{}, err
+		}
+	}
 	return updated, nil
 }
 
+// ListTemplateVersions returns a template's content snapshots, oldest first.
+// A template created before versioning reports its current content as its
+// only version.
+func (s *Store) ListTemplateVersions(templateID string) ([]storage.TemplateVersion, error) {
+	tmpl, err := s.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(s.versionsDir(templateID))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("%w: reading template versions: %v", storage.ErrStorage, err)
+	}
+	var versions []storage.TemplateVersion
+	for _, de := range entries {
+		if de.IsDir() || !strings.HasSuffix(de.Name(), ".md") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(s.versionsDir(templateID), de.Name()))
+		if err != nil {
+			continue
+		}
+		var fm versionFrontMatter
+		content, err := frontmatter.Parse(strings.NewReader(string(data)), &fm)
+		if err != nil {
+			continue
+		}
+		createdAt, _ := time.Parse(time.RFC3339, fm.CreatedAt)
+		versions = append(versions, storage.TemplateVersion{
+			TemplateID: templateID,
+			Version:    fm.Version,
+			Content:    strings.TrimSpace(string(content)),
+			CreatedAt:  createdAt,
+		})
+	}
+	if len(versions) == 0 {
+		versions = append(versions, storage.TemplateVersion{
+			TemplateID: tmpl.ID,
+			Version:    tmpl.Version,
+			Content:    tmpl.Content,
+			CreatedAt:  tmpl.UpdatedAt,
+		})
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].Version < versions[j].Version
+	})
+	return versions, nil
+}
+
 // DeleteTemplate removes a template by ID.
 func (s *Store) DeleteTemplate(id string) error {
 	tmpl, err := s.GetTemplate(id)
@@ -630,6 +792,9 @@ func (s *Store) DeleteTemplate(id string) error {
 	if err := os.Remove(s.templatePath(tmpl.Name)); err != nil {
 		return fmt.Errorf("%w: deleting template file: %v", storage.ErrStorage, err)
 	}
+	if err := os.RemoveAll(s.versionsDir(id)); err != nil {
+		return fmt.Errorf("%w: deleting template versions: %v", storage.ErrStorage, err)
+	}
 	return nil
 }
 
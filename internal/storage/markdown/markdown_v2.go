@@ -189,6 +189,11 @@ func (m *MarkdownV2) CreateBlock(date time.Time, blk block.Block) error {
 		return fmt.Errorf("%w: block with ID %s already exists", storage.ErrValidation, blk.ID)
 	}
 
+	// Record the zone the block is written in
+	if blk.TimeZone == "" {
+		blk.TimeZone = day.Zone()
+	}
+
 	// Add block to day
 	d.AddBlock(blk)
 
@@ -327,10 +332,82 @@ func (m *MarkdownV2) ListBlocks(date time.Time) ([]block.Block, error) {
 
 // SearchBlocks searches for blocks matching the given criteria.
 // Results are ordered by date descending, then by CreatedAt descending.
-// TODO: Implement SearchBlocks - scan days, filter by attributes/content/date, return results
+// ContentQuery is a case-insensitive substring match; attribute values must
+// match exactly.
 func (m *MarkdownV2) SearchBlocks(opts storage.SearchOptions) ([]storage.BlockResult, error) {
-	// TODO: Implement SearchBlocks functionality
-	return []storage.BlockResult{}, nil
+	daysPath := filepath.Join(m.basePath, "days")
+	files, err := os.ReadDir(daysPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []storage.BlockResult{}, nil
+		}
+		return nil, fmt.Errorf("failed to read days directory: %w", err)
+	}
+
+	var start, end time.Time
+	if opts.StartDate != nil {
+		start = day.NormalizeDate(*opts.StartDate)
+	}
+	if opts.EndDate != nil {
+		end = day.NormalizeDate(*opts.EndDate)
+	}
+	query := strings.ToLower(opts.ContentQuery)
+
+	results := []storage.BlockResult{}
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		date, err := time.ParseInLocation("2006-01-02", strings.TrimSuffix(f.Name(), ".json"), time.Local)
+		if err != nil {
+			continue // Skip invalid filenames
+		}
+		if (opts.StartDate != nil && date.Before(start)) || (opts.EndDate != nil && date.After(end)) {
+			continue
+		}
+
+		d, err := m.loadDay(date)
+		if err != nil {
+			return nil, err
+		}
+		for _, blk := range d.Blocks {
+			if query != "" && !strings.Contains(strings.ToLower(blk.Content), query) {
+				continue
+			}
+			if !hasAttributes(blk, opts.Attributes) {
+				continue
+			}
+			results = append(results, storage.BlockResult{Block: blk, Day: date})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if !results[i].Day.Equal(results[j].Day) {
+			return results[i].Day.After(results[j].Day)
+		}
+		return results[i].Block.CreatedAt.After(results[j].Block.CreatedAt)
+	})
+
+	if opts.Offset > 0 {
+		if opts.Offset >= len(results) {
+			return []storage.BlockResult{}, nil
+		}
+		results = results[opts.Offset:]
+	}
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+	return results, nil
+}
+
+// hasAttributes reports whether blk has every key-value pair in attrs.
+func hasAttributes(blk block.Block, attrs map[string]string) bool {
+	for k, v := range attrs {
+		if got, ok := blk.Attributes[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
 }
 
 // Template methods
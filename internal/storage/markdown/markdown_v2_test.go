@@ -3,6 +3,7 @@ package markdown_test
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 
@@ -109,6 +110,60 @@ func TestMarkdownV2_CreateBlock(t *testing.T) {
 	}
 }
 
+// TestMarkdownV2_SearchBlocks verifies filtering by date range, attributes and content.
+func TestMarkdownV2_SearchBlocks(t *testing.T) {
+	store, err := markdown.NewV2(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	mar1 := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+	mar2 := mar1.AddDate(0, 0, 1)
+	create := func(date time.Time, id, content string, hour int, attrs map[string]string) {
+		at := date.Add(time.Duration(hour) * time.Hour)
+		blk := block.Block{ID: id, Content: content, CreatedAt: at, UpdatedAt: at, Attributes: attrs}
+		if err := store.CreateBlock(date, blk); err != nil {
+			t.Fatalf("CreateBlock failed: %v", err)
+		}
+	}
+	create(mar1, "blk00001", "Chose Postgres", 9, map[string]string{"type": "decision"})
+	create(mar1, "blk00002", "Lunch", 12, map[string]string{"type": "note"})
+	create(mar2, "blk00003", "Chose gRPC over REST", 10, map[string]string{"type": "decision", "project": "api"})
+
+	ids := func(results []storage.BlockResult) []string {
+		out := []string{}
+		for _, r := range results {
+			out = append(out, r.Block.ID)
+		}
+		return out
+	}
+	tests := []struct {
+		name string
+		opts storage.SearchOptions
+		want []string
+	}{
+		{"all, newest first", storage.SearchOptions{}, []string{"blk00003", "blk00002", "blk00001"}},
+		{"attribute", storage.SearchOptions{Attributes: map[string]string{"type": "decision"}}, []string{"blk00003", "blk00001"}},
+		{"attributes are ANDed", storage.SearchOptions{Attributes: map[string]string{"type": "decision", "project": "api"}}, []string{"blk00003"}},
+		{"content is case-insensitive", storage.SearchOptions{ContentQuery: "chose"}, []string{"blk00003", "blk00001"}},
+		{"date range", storage.SearchOptions{StartDate: &mar1, EndDate: &mar1}, []string{"blk00002", "blk00001"}},
+		{"offset and limit", storage.SearchOptions{Offset: 1, Limit: 1}, []string{"blk00002"}},
+		{"offset past end", storage.SearchOptions{Offset: 5}, []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := store.SearchBlocks(tt.opts)
+			if err != nil {
+				t.Fatalf("SearchBlocks failed: %v", err)
+			}
+			if got := ids(results); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 // TestMarkdownV2_CreateTemplate verifies that CreateTemplate saves a template and it can be retrieved.
 func TestMarkdownV2_CreateTemplate(t *testing.T) {
 	// Setup: Create temporary directory for test
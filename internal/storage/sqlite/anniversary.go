@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// AnniversaryDays implements storage.AnniversaryProvider.
+func (s *Store) AnniversaryDays(month time.Month, day int, before time.Time) ([]time.Time, error) {
+	rows, err := s.db.Query(`SELECT DISTINCT `+localDay("entries")+` AS day FROM entries
+		WHERE strftime('%m-%d', `+localDay("entries")+`) = ? AND `+localDay("entries")+` < ?
+		ORDER BY day DESC`, fmt.Sprintf("%02d-%02d", int(month), day), before.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing anniversary days: %v", storage.ErrStorage, err)
+	}
+	defer rows.Close()
+
+	var days []time.Time
+	for rows.Next() {
+		var dayStr string
+		if err := rows.Scan(&dayStr); err != nil {
+			return nil, fmt.Errorf("%w: scanning day: %v", storage.ErrStorage, err)
+		}
+		// libSQL's date() may return "YYYY-MM-DD" or "YYYY-MM-DDT00:00:00Z"
+		if len(dayStr) > 10 {
+			dayStr = dayStr[:10]
+		}
+		d, err := time.ParseInLocation("2006-01-02", dayStr, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parsing date: %v", storage.ErrStorage, err)
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+// Ensure Store implements AnniversaryProvider.
+var _ storage.AnniversaryProvider = (*Store)(nil)
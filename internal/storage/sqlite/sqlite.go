@@ -2,17 +2,33 @@ package sqlite
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 	_ "github.com/tursodatabase/go-libsql"
 )
 
+// localClock returns the SQL date modifiers that turn created_at of the
+// entries table aliased as alias into the wall clock time of the zone the
+// entry was written in. Entries without a recorded zone use the local one.
+func localClock(alias string) string {
+	return fmt.Sprintf("%[1]s.created_at, CASE WHEN %[1]s.utc_offset IS NULL THEN 'localtime' ELSE printf('%%+d seconds', %[1]s.utc_offset) END", alias)
+}
+
+// localDay returns an SQL expression for the day an entry of the table
+// aliased as alias was written on, counting times before the configured day
+// boundary as the previous day.
+func localDay(alias string) string {
+	return fmt.Sprintf("date(%s, '-%d seconds')", localClock(alias), int(day.StartsAt().Seconds()))
+}
+
 // Store implements storage.Storage using SQLite via Turso/libSQL.
 type Store struct {
 	db *sql.DB
@@ -70,6 +86,13 @@ func createSchema(db *sql.DB) error {
 			template_name TEXT NOT NULL,
 			PRIMARY KEY (entry_id, template_id)
 		)`,
+		`CREATE TABLE IF NOT EXISTS template_versions (
+			template_id TEXT NOT NULL REFERENCES templates(id) ON DELETE CASCADE,
+			version     INTEGER NOT NULL,
+			content     TEXT NOT NULL,
+			created_at  TEXT NOT NULL,
+			PRIMARY KEY (template_id, version)
+		)`,
 		`CREATE TABLE IF NOT EXISTS contexts (
 			id         TEXT PRIMARY KEY,
 			name       TEXT NOT NULL UNIQUE,
@@ -88,9 +111,71 @@ func createSchema(db *sql.DB) error {
 			return fmt.Errorf("%w: creating schema: %v", storage.ErrStorage, err)
 		}
 	}
+	return migrateSchema(db)
+}
+
+// migrateSchema adds columns introduced after the initial schema to
+// existing databases.
+func migrateSchema(db *sql.DB) error {
+	columns := []struct{ table, column, def string }{
+		{"templates", "version", "INTEGER NOT NULL DEFAULT 1"},
+		{"entry_templates", "template_version", "INTEGER NOT NULL DEFAULT 0"},
+		{"entries", "word_count", "INTEGER"},
+		{"entries", "timezone", "TEXT"},
+		{"entries", "utc_offset", "INTEGER"}, // seconds east of UTC in timezone at created_at
+		{"templates", "attributes", "TEXT"},  // JSON object of default block attributes
+	}
+	for _, c := range columns {
+		ok, err := hasColumn(db, c.table, c.column)
+		if err != nil {
+			return err
+		}
+		if ok {
+			continue
+		}
+		if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.column, c.def)); err != nil {
+			return fmt.Errorf("%w: adding %s.%s: %v", storage.ErrStorage, c.table, c.column, err)
+		}
+	}
+	return backfillWordCounts(db)
+}
+
+// backfillWordCounts fills in word_count for entries written before the
+// column existed.
+func backfillWordCounts(db *sql.DB) error {
+	rows, err := db.Query("SELECT id, content FROM entries WHERE word_count IS NULL")
+	if err != nil {
+		return fmt.Errorf("%w: reading entries for word counts: %v", storage.ErrStorage, err)
+	}
+	counts := map[string]int{}
+	for rows.Next() {
+		var id, content string
+		if err := rows.Scan(&id, &content); err != nil {
+			rows.Close()
+			return fmt.Errorf("%w: scanning entry for word count: %v", storage.ErrStorage, err)
+		}
+		counts[id] = entry.WordCount(content)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("%w: reading entries for word counts: %v", storage.ErrStorage, err)
+	}
+	for id, n := range counts {
+		if _, err := db.Exec("UPDATE entries SET word_count = ? WHERE id = ?", n, id); err != nil {
+			return fmt.Errorf("%w: backfilling word count: %v", storage.ErrStorage, err)
+		}
+	}
 	return nil
 }
 
+func hasColumn(db *sql.DB, table, column string) (bool, error) {
+	var n int
+	if err := db.QueryRow("SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?", table, column).Scan(&n); err != nil {
+		return false, fmt.Errorf("%w: inspecting %s: %v", storage.ErrStorage, table, err)
+	}
+	return n > 0, nil
+}
+
 // Close closes the database connection.
 func (s *Store) Close() error {
 	return s.db.Close()
@@ -102,6 +187,16 @@ func (s *Store) Create(e entry.Entry) error {
 		return fmt.Errorf("%w: %v", storage.ErrValidation, err)
 	}
 
+	// Record the zone the entry is written in, so its day stays put
+	if e.TimeZone == "" {
+		e.TimeZone = day.Zone()
+	}
+	var zone, offset any
+	if e.TimeZone != "" {
+		_, off := day.In(e.CreatedAt, e.TimeZone).Zone()
+		zone, offset = e.TimeZone, off
+	}
+
 	tx, err := s.db.Begin()
 	if err != nil {
 		return fmt.Errorf("%w: beginning transaction: %v", storage.ErrStorage, err)
@@ -109,11 +204,14 @@ func (s *Store) Create(e entry.Entry) error {
 	defer tx.Rollback()
 
 	_, err = tx.Exec(
-		"INSERT INTO entries (id, content, created_at, updated_at) VALUES (?, ?, ?, ?)",
+		"INSERT INTO entries (id, content, created_at, updated_at, word_count, timezone, utc_offset) VALUES (?, ?, ?, ?, ?, ?, ?)",
 		e.ID,
 		e.Content,
 		e.CreatedAt.UTC().Format(time.RFC3339),
 		e.UpdatedAt.UTC().Format(time.RFC3339),
+		entry.WordCount(e.Content),
+		zone,
+		offset,
 	)
 	if err != nil {
 		return fmt.Errorf("%w: inserting entry: %v", storage.ErrStorage, err)
@@ -121,8 +219,8 @@ func (s *Store) Create(e entry.Entry) error {
 
 	for _, ref := range e.Templates {
 		_, err = tx.Exec(
-			"INSERT INTO entry_templates (entry_id, template_id, template_name) VALUES (?, ?, ?)",
-			e.ID, ref.TemplateID, ref.TemplateName,
+			"INSERT INTO entry_templates (entry_id, template_id, template_name, template_version) VALUES (?, ?, ?, ?)",
+			e.ID, ref.TemplateID, ref.TemplateName, ref.TemplateVersion,
 		)
 		if err != nil {
 			return fmt.Errorf("%w: inserting template ref: %v", storage.ErrStorage, err)
@@ -145,18 +243,21 @@ func (s *Store) Create(e entry.Entry) error {
 // Get retrieves an entry by ID.
 func (s *Store) Get(id string) (entry.Entry, error) {
 	row := s.db.QueryRow(
-		"SELECT id, content, created_at, updated_at FROM entries WHERE id = ?", id,
+		"SELECT id, content, created_at, updated_at, timezone FROM entries WHERE id = ?", id,
 	)
 
 	var e entry.Entry
 	var createdStr, updatedStr string
-	if err := row.Scan(&e.ID, &e.Content, &createdStr, &updatedStr); err != nil {
+	var zone sql.NullString
+	if err := row.Scan(&e.ID, &e.Content, &createdStr, &updatedStr, &zone); err != nil {
 		if err == sql.ErrNoRows {
 			return entry.Entry{}, storage.ErrNotFound
 		}
 		return entry.Entry{}, fmt.Errorf("%w: querying entry: %v", storage.ErrStorage, err)
 	}
 
+	e.TimeZone = zone.String
+
 	var err error
 	e.CreatedAt, err = time.Parse(time.RFC3339, createdStr)
 	if err != nil {
@@ -187,7 +288,7 @@ func (s *Store) Get(id string) (entry.Entry, error) {
 // loadTemplateRefs loads template references for an entry.
 func (s *Store) loadTemplateRefs(entryID string) ([]entry.TemplateRef, error) {
 	rows, err := s.db.Query(
-		"SELECT template_id, template_name FROM entry_templates WHERE entry_id = ?", entryID,
+		"SELECT template_id, template_name, template_version FROM entry_templates WHERE entry_id = ?", entryID,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: querying template refs: %v", storage.ErrStorage, err)
@@ -197,7 +298,7 @@ func (s *Store) loadTemplateRefs(entryID string) ([]entry.TemplateRef, error) {
 	var refs []entry.TemplateRef
 	for rows.Next() {
 		var ref entry.TemplateRef
-		if err := rows.Scan(&ref.TemplateID, &ref.TemplateName); err != nil {
+		if err := rows.Scan(&ref.TemplateID, &ref.TemplateName, &ref.TemplateVersion); err != nil {
 			return nil, fmt.Errorf("%w: scanning template ref: %v", storage.ErrStorage, err)
 		}
 		refs = append(refs, ref)
@@ -207,7 +308,7 @@ func (s *Store) loadTemplateRefs(entryID string) ([]entry.TemplateRef, error) {
 
 // List returns entries matching the given options.
 func (s *Store) List(opts storage.ListOptions) ([]entry.Entry, error) {
-	query := "SELECT DISTINCT entries.id, entries.content, entries.created_at, entries.updated_at FROM entries"
+	query := "SELECT DISTINCT entries.id, entries.content, entries.created_at, entries.updated_at, entries.timezone FROM entries"
 	var args []any
 	var conditions []string
 
@@ -225,24 +326,29 @@ func (s *Store) List(opts storage.ListOptions) ([]entry.Entry, error) {
 
 	if opts.Date != nil {
 		// Date takes precedence over range
-		conditions = append(conditions, "date(entries.created_at, 'localtime') = ?")
+		conditions = append(conditions, localDay("entries")+" = ?")
 		args = append(args, opts.Date.Format("2006-01-02"))
 	} else {
 		if opts.StartDate != nil {
-			conditions = append(conditions, "date(entries.created_at, 'localtime') >= ?")
+			conditions = append(conditions, localDay("entries")+" >= ?")
 			args = append(args, opts.StartDate.Format("2006-01-02"))
 		}
 		if opts.EndDate != nil {
-			conditions = append(conditions, "date(entries.created_at, 'localtime') <= ?")
+			conditions = append(conditions, localDay("entries")+" <= ?")
 			args = append(args, opts.EndDate.Format("2006-01-02"))
 		}
 	}
 
+	if opts.Until != nil {
+		conditions = append(conditions, "entries.created_at <= ?")
+		args = append(args, opts.Until.UTC().Format(time.RFC3339))
+	}
+
 	if len(conditions) > 0 {
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " ORDER BY entries.created_at DESC"
+	query += " ORDER BY entries.created_at DESC, entries.id DESC"
 
 	if opts.Limit > 0 {
 		query += fmt.Sprintf(" LIMIT %d", opts.Limit)
@@ -261,9 +367,11 @@ func (s *Store) List(opts storage.ListOptions) ([]entry.Entry, error) {
 	for rows.Next() {
 		var e entry.Entry
 		var createdStr, updatedStr string
-		if err := rows.Scan(&e.ID, &e.Content, &createdStr, &updatedStr); err != nil {
+		var zone sql.NullString
+		if err := rows.Scan(&e.ID, &e.Content, &createdStr, &updatedStr, &zone); err != nil {
 			return nil, fmt.Errorf("%w: scanning row: %v", storage.ErrStorage, err)
 		}
+		e.TimeZone = zone.String
 		e.CreatedAt, _ = time.Parse(time.RFC3339, createdStr)
 		e.UpdatedAt, _ = time.Parse(time.RFC3339, updatedStr)
 
@@ -293,8 +401,8 @@ func (s *Store) List(opts storage.ListOptions) ([]entry.Entry, error) {
 
 // ListDays returns aggregated day summaries grouped by date.
 func (s *Store) ListDays(opts storage.ListDaysOptions) ([]storage.DaySummary, error) {
-	query := `SELECT date(entries.created_at, 'localtime') as day, COUNT(*) as cnt,
-		(SELECT content FROM entries e2 WHERE date(e2.created_at, 'localtime') = date(entries.created_at, 'localtime') ORDER BY e2.created_at DESC LIMIT 1) as preview
+	query := `SELECT ` + localDay("entries") + ` as day, COUNT(*) as cnt,
+		(SELECT content FROM entries e2 WHERE ` + localDay("e2") + ` = ` + localDay("entries") + ` ORDER BY e2.created_at DESC LIMIT 1) as preview
 		FROM entries`
 	var args []any
 	var conditions []string
@@ -306,11 +414,11 @@ func (s *Store) ListDays(opts storage.ListDaysOptions) ([]storage.DaySummary, er
 	}
 
 	if opts.StartDate != nil {
-		conditions = append(conditions, "date(entries.created_at, 'localtime') >= ?")
+		conditions = append(conditions, localDay("entries")+" >= ?")
 		args = append(args, opts.StartDate.Format("2006-01-02"))
 	}
 	if opts.EndDate != nil {
-		conditions = append(conditions, "date(entries.created_at, 'localtime') <= ?")
+		conditions = append(conditions, localDay("entries")+" <= ?")
 		args = append(args, opts.EndDate.Format("2006-01-02"))
 	}
 
@@ -318,7 +426,7 @@ func (s *Store) ListDays(opts storage.ListDaysOptions) ([]storage.DaySummary, er
 		query += " WHERE " + strings.Join(conditions, " AND ")
 	}
 
-	query += " GROUP BY date(entries.created_at, 'localtime') ORDER BY day DESC"
+	query += " GROUP BY " + localDay("entries") + " ORDER BY day DESC"
 
 	rows, err := s.db.Query(query, args...)
 	if err != nil {
@@ -386,8 +494,8 @@ func (s *Store) Update(id string, content string, templates []entry.TemplateRef)
 	}
 
 	if _, err := tx.Exec(
-		"UPDATE entries SET content = ?, updated_at = ? WHERE id = ?",
-		content, now, id,
+		"UPDATE entries SET content = ?, updated_at = ?, word_count = ? WHERE id = ?",
+		content, now, entry.WordCount(content), id,
 	); err != nil {
 		return entry.Entry{}, fmt.Errorf("%w: updating entry: %v", storage.ErrStorage, err)
 	}
@@ -399,8 +507,8 @@ func (s *Store) Update(id string, content string, templates []entry.TemplateRef)
 		}
 		for _, ref := range templates {
 			if _, err := tx.Exec(
-				"INSERT INTO entry_templates (entry_id, template_id, template_name) VALUES (?, ?, ?)",
-				id, ref.TemplateID, ref.TemplateName,
+				"INSERT INTO entry_templates (entry_id, template_id, template_name, template_version) VALUES (?, ?, ?, ?)",
+				id, ref.TemplateID, ref.TemplateName, ref.TemplateVersion,
 			); err != nil {
 				return entry.Entry{}, fmt.Errorf("%w: inserting template ref: %v", storage.ErrStorage, err)
 			}
@@ -421,9 +529,19 @@ func (s *Store) CreateTemplate(t storage.Template) error {
 	if err := entry.ValidateTemplateName(t.Name); err != nil {
 		return fmt.Errorf("%w: %v", storage.ErrValidation, err)
 	}
-	_, err := s.db.Exec(
-		"INSERT INTO templates (id, name, content, created_at, updated_at) VALUES (?, ?, ?, ?, ?)",
-		t.ID, t.Name, t.Content,
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("%w: beginning transaction: %v", storage.ErrStorage, err)
+	}
+	defer tx.Rollback()
+
+	attrs, err := marshalAttributes(t.Attributes)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		"INSERT INTO templates (id, name, content, attributes, version, created_at, updated_at) VALUES (?, ?, ?, ?, 1, ?, ?)",
+		t.ID, t.Name, t.Content, attrs,
 		t.CreatedAt.UTC().Format(time.RFC3339),
 		t.UpdatedAt.UTC().Format(time.RFC3339),
 	)
@@ -433,13 +551,31 @@ func (s *Store) CreateTemplate(t storage.Template) error {
 		}
 		return fmt.Errorf("%w: inserting template: %v", storage.ErrStorage, err)
 	}
+	if err := insertTemplateVersion(tx, t.ID, 1, t.Content, t.UpdatedAt); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("%w: committing: %v", storage.ErrStorage, err)
+	}
+	return nil
+}
+
+func insertTemplateVersion(tx *sql.Tx, templateID string, version int, content string, at time.Time) error {
+	_, err := tx.Exec(
+		"INSERT OR IGNORE INTO template_versions (template_id, version, content, created_at) VALUES (?, ?, ?, ?)",
+		templateID, version, content, at.UTC().Format(time.RFC3339),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: inserting template version: %v", storage.ErrStorage, err)
+	}
 	return nil
 }
 
 // GetTemplate retrieves a template by ID.
 func (s *Store) GetTemplate(id string) (storage.Template, error) {
 	row := s.db.QueryRow(
-		"SELECT id, name, content, created_at, updated_at FROM templates WHERE id = ?", id,
+		"SELECT id, name, content, attributes, version, created_at, updated_at FROM templates WHERE id = ?", id,
 	)
 	return s.scanTemplate(row)
 }
@@ -447,21 +583,25 @@ func (s *Store) GetTemplate(id string) (storage.Template, error) {
 // GetTemplateByName retrieves a template by name.
 func (s *Store) GetTemplateByName(name string) (storage.Template, error) {
 	row := s.db.QueryRow(
-		"SELECT id, name, content, created_at, updated_at FROM templates WHERE name = ?", name,
+		"SELECT id, name, content, attributes, version, created_at, updated_at FROM templates WHERE name = ?", name,
 	)
 	return s.scanTemplate(row)
 }
 
 func (s *Store) scanTemplate(row *sql.Row) (storage.Template, error) {
 	var t storage.Template
+	var attrs sql.NullString
 	var createdStr, updatedStr string
-	if err := row.Scan(&t.ID, &t.Name, &t.Content, &createdStr, &updatedStr); err != nil {
+	if err := row.Scan(&t.ID, &t.Name, &t.Content, &attrs, &t.Version, &createdStr, &updatedStr); err != nil {
 		if err == sql.ErrNoRows {
 			return storage.Template{}, storage.ErrNotFound
 		}
 		return storage.Template{}, fmt.Errorf("%w: querying template: %v", storage.ErrStorage, err)
 	}
 	var err error
+	if t.Attributes, err = unmarshalAttributes(attrs); err != nil {
+		return storage.Template{}, err
+	}
 	t.CreatedAt, err = time.Parse(time.RFC3339, createdStr)
 	if err != nil {
 		return storage.Template{}, fmt.Errorf("%w: parsing created_at: %v", storage.ErrStorage, err)
@@ -476,7 +616,7 @@ func (s *Store) scanTemplate(row *sql.Row) (storage.Template, error) {
 // ListTemplates returns all templates sorted by name.
 func (s *Store) ListTemplates() ([]storage.Template, error) {
 	rows, err := s.db.Query(
-		"SELECT id, name, content, created_at, updated_at FROM templates ORDER BY name",
+		"SELECT id, name, content, attributes, version, created_at, updated_at FROM templates ORDER BY name",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: listing templates: %v", storage.ErrStorage, err)
@@ -486,10 +626,14 @@ func (s *Store) ListTemplates() ([]storage.Template, error) {
 	var templates []storage.Template
 	for rows.Next() {
 		var t storage.Template
+		var attrs sql.NullString
 		var createdStr, updatedStr string
-		if err := rows.Scan(&t.ID, &t.Name, &t.Content, &createdStr, &updatedStr); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.Content, &attrs, &t.Version, &createdStr, &updatedStr); err != nil {
 			return nil, fmt.Errorf("%w: scanning template row: %v", storage.ErrStorage, err)
 		}
+		if t.Attributes, err = unmarshalAttributes(attrs); err != nil {
+			return nil, err
+		}
 		t.CreatedAt, _ = time.Parse(time.RFC3339, createdStr)
 		t.UpdatedAt, _ = time.Parse(time.RFC3339, updatedStr)
 		templates = append(templates, t)
@@ -498,9 +642,12 @@ func (s *Store) ListTemplates() ([]storage.Template, error) {
 }
 
 // UpdateTemplate modifies an existing template's name, content, and attributes.
-// Note: V1 sqlite schema does not support attributes, so the attributes parameter is ignored.
 func (s *Store) UpdateTemplate(id string, name string, content string, attributes map[string]string) (storage.Template, error) {
 	now := time.Now().UTC().Format(time.RFC3339)
+	attrs, err := marshalAttributes(attributes)
+	if err != nil {
+		return storage.Template{}, err
+	}
 
 	tx, err := s.db.Begin()
 	if err != nil {
@@ -508,18 +655,31 @@ func (s *Store) UpdateTemplate(id string, name string, content string, attribute
 	}
 	defer tx.Rollback()
 
-	// Check existence
-	var exists int
-	if err := tx.QueryRow("SELECT COUNT(*) FROM templates WHERE id = ?", id).Scan(&exists); err != nil {
+	var oldContent, oldUpdated string
+	var version int
+	err = tx.QueryRow("SELECT content, version, updated_at FROM templates WHERE id = ?", id).Scan(&oldContent, &version, &oldUpdated)
+	if err == sql.ErrNoRows {
+		return storage.Template{}, storage.ErrNotFound
+	}
+	if err != nil {
 		return storage.Template{}, fmt.Errorf("%w: checking template: %v", storage.ErrStorage, err)
 	}
-	if exists == 0 {
-		return storage.Template{}, storage.ErrNotFound
+
+	if strings.TrimSpace(oldContent) != strings.TrimSpace(content) {
+		// Keep the outgoing version even if it predates versioning.
+		oldAt, _ := time.Parse(time.RFC3339, oldUpdated)
+		if err := insertTemplateVersion(tx, id, version, oldContent, oldAt); err != nil {
+			return storage.Template{}, err
+		}
+		version++
+		if err := insertTemplateVersion(tx, id, version, content, time.Now()); err != nil {
+			return storage.Template{}, err
+		}
 	}
 
 	_, err = tx.Exec(
-		"UPDATE templates SET name = ?, content = ?, updated_at = ? WHERE id = ?",
-		name, content, now, id,
+		"UPDATE templates SET name = ?, content = ?, attributes = ?, version = ?, updated_at = ? WHERE id = ?",
+		name, content, attrs, version, now, id,
 	)
 	if err != nil {
 		if strings.Contains(err.Error(), "UNIQUE") {
@@ -535,8 +695,75 @@ func (s *Store) UpdateTemplate(id string, name string, content string, attribute
 	return s.GetTemplate(id)
 }
 
-// DeleteTemplate removes a template by ID.
+// marshalAttributes encodes template attributes for the attributes column;
+// no attributes are stored as NULL.
+func marshalAttributes(attributes map[string]string) (sql.NullString, error) {
+	if len(attributes) == 0 {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("%w: encoding template attributes: %v", storage.ErrStorage, err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func unmarshalAttributes(s sql.NullString) (map[string]string, error) {
+	if !s.Valid || s.String == "" {
+		return nil, nil
+	}
+	var attributes map[string]string
+	if err := json.Unmarshal([]byte(s.String), &attributes); err != nil {
+		return nil, fmt.Errorf("%w: decoding template attributes: %v", storage.ErrStorage, err)
+	}
+	return attributes, nil
+}
+
+// ListTemplateVersions returns a template's content snapshots, oldest first.
+// A template created before versioning reports its current content as its
+// only version.
+func (s *Store) ListTemplateVersions(templateID string) ([]storage.TemplateVersion, error) {
+	tmpl, err := s.GetTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Query(
+		"SELECT version, content, created_at FROM template_versions WHERE template_id = ? ORDER BY version", templateID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: listing template versions: %v", storage.ErrStorage, err)
+	}
+	defer rows.Close()
+
+	var versions []storage.TemplateVersion
+	for rows.Next() {
+		v := storage.TemplateVersion{TemplateID: templateID}
+		var createdStr string
+		if err := rows.Scan(&v.Version, &v.Content, &createdStr); err != nil {
+			return nil, fmt.Errorf("%w: scanning template version: %v", storage.ErrStorage, err)
+		}
+		v.CreatedAt, _ = time.Parse(time.RFC3339, createdStr)
+		versions = append(versions, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("%w: listing template versions: %v", storage.ErrStorage, err)
+	}
+	if len(versions) == 0 {
+		versions = append(versions, storage.TemplateVersion{
+			TemplateID: tmpl.ID,
+			Version:    tmpl.Version,
+			Content:    tmpl.Content,
+			CreatedAt:  tmpl.UpdatedAt,
+		})
+	}
+	return versions, nil
+}
+
+// DeleteTemplate removes a template and its version history by ID.
 func (s *Store) DeleteTemplate(id string) error {
+	if _, err := s.db.Exec("DELETE FROM template_versions WHERE template_id = ?", id); err != nil {
+		return fmt.Errorf("%w: deleting template versions: %v", storage.ErrStorage, err)
+	}
 	result, err := s.db.Exec("DELETE FROM templates WHERE id = ?", id)
 	if err != nil {
 		return fmt.Errorf("%w: deleting template: %v", storage.ErrStorage, err)
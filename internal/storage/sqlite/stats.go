@@ -0,0 +1,148 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// statsScope returns the joins, WHERE clause and arguments restricting a
+// stats query to the entries matching opts.
+func statsScope(opts storage.StatsOptions) (string, string, []any) {
+	var join string
+	var conditions []string
+	var args []any
+	if opts.ContextName != "" {
+		join = " JOIN entry_contexts sec ON sec.entry_id = entries.id JOIN contexts sctx ON sctx.id = sec.context_id"
+		conditions = append(conditions, "sctx.name = ?")
+		args = append(args, opts.ContextName)
+	}
+	if opts.StartDate != nil {
+		conditions = append(conditions, localDay("entries")+" >= ?")
+		args = append(args, opts.StartDate.Format("2006-01-02"))
+	}
+	if opts.EndDate != nil {
+		conditions = append(conditions, localDay("entries")+" <= ?")
+		args = append(args, opts.EndDate.Format("2006-01-02"))
+	}
+	var where string
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return join, where, args
+}
+
+// Aggregate implements storage.StatsProvider with SQL aggregates.
+func (s *Store) Aggregate(opts storage.StatsOptions) (storage.Aggregates, error) {
+	join, where, args := statsScope(opts)
+	var agg storage.Aggregates
+
+	days, err := s.aggregateDays(join, where, args)
+	if err != nil {
+		return agg, err
+	}
+	agg.Days = days
+
+	hourQuery := `SELECT CAST(strftime('%H', ` + localClock("entries") + `) AS INTEGER) AS hour, COUNT(*)
+		FROM entries` + join + where + ` GROUP BY hour`
+	if err := s.scanHours(hourQuery, args, &agg.Hours); err != nil {
+		return agg, err
+	}
+
+	// Split content into lines and count "- **HH:MM**" jot timestamps
+	jotQuery := `WITH RECURSIVE lines(rest, line) AS (
+			SELECT entries.content || char(10), NULL FROM entries` + join + where + `
+			UNION ALL
+			SELECT substr(rest, instr(rest, char(10)) + 1), substr(rest, 1, instr(rest, char(10)) - 1)
+			FROM lines WHERE rest <> ''
+		)
+		SELECT CAST(substr(line, 5, 2) AS INTEGER) AS hour, COUNT(*) FROM lines
+		WHERE line GLOB '- [*][*][0-2][0-9]:[0-5][0-9][*][*]*' AND CAST(substr(line, 5, 2) AS INTEGER) < 24
+		GROUP BY hour`
+	if err := s.scanHours(jotQuery, args, &agg.JotHours); err != nil {
+		return agg, err
+	}
+
+	limit := ""
+	if opts.Top > 0 {
+		limit = fmt.Sprintf(" LIMIT %d", opts.Top)
+	}
+	agg.Contexts, err = s.scanCounts(`SELECT c.name, COUNT(DISTINCT entries.id) AS n FROM entries
+		JOIN entry_contexts ec ON ec.entry_id = entries.id JOIN contexts c ON c.id = ec.context_id`+join+where+`
+		GROUP BY c.name ORDER BY n DESC, c.name`+limit, args)
+	if err != nil {
+		return agg, err
+	}
+	agg.Templates, err = s.scanCounts(`SELECT et.template_name, COUNT(DISTINCT entries.id) AS n FROM entries
+		JOIN entry_templates et ON et.entry_id = entries.id`+join+where+`
+		GROUP BY et.template_name ORDER BY n DESC, et.template_name`+limit, args)
+	return agg, err
+}
+
+func (s *Store) aggregateDays(join, where string, args []any) ([]storage.DayTotal, error) {
+	rows, err := s.db.Query(`SELECT `+localDay("entries")+` AS day, COUNT(*), COALESCE(SUM(entries.word_count), 0)
+		FROM entries`+join+where+` GROUP BY day ORDER BY day`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: aggregating days: %v", storage.ErrStorage, err)
+	}
+	defer rows.Close()
+
+	days := []storage.DayTotal{}
+	for rows.Next() {
+		var dayStr string
+		var d storage.DayTotal
+		if err := rows.Scan(&dayStr, &d.Entries, &d.Words); err != nil {
+			return nil, fmt.Errorf("%w: scanning day totals: %v", storage.ErrStorage, err)
+		}
+		// libSQL's date() may return "YYYY-MM-DD" or "YYYY-MM-DDT00:00:00Z"
+		if len(dayStr) > 10 {
+			dayStr = dayStr[:10]
+		}
+		d.Date, err = time.ParseInLocation("2006-01-02", dayStr, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("%w: parsing date: %v", storage.ErrStorage, err)
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+func (s *Store) scanHours(query string, args []any, hours *[24]int) error {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return fmt.Errorf("%w: aggregating hours: %v", storage.ErrStorage, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hour, n int
+		if err := rows.Scan(&hour, &n); err != nil {
+			return fmt.Errorf("%w: scanning hour counts: %v", storage.ErrStorage, err)
+		}
+		if hour >= 0 && hour < 24 {
+			hours[hour] += n
+		}
+	}
+	return rows.Err()
+}
+
+func (s *Store) scanCounts(query string, args []any) ([]storage.NameCount, error) {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("%w: aggregating counts: %v", storage.ErrStorage, err)
+	}
+	defer rows.Close()
+	counts := []storage.NameCount{}
+	for rows.Next() {
+		var c storage.NameCount
+		if err := rows.Scan(&c.Name, &c.Count); err != nil {
+			return nil, fmt.Errorf("%w: scanning counts: %v", storage.ErrStorage, err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
+// Ensure Store implements StatsProvider.
+var _ storage.StatsProvider = (*Store)(nil)
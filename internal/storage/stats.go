@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+// StatsOptions scopes the entries counted by Aggregate.
+type StatsOptions struct {
+	StartDate   *time.Time // inclusive lower bound (nil = no lower bound)
+	EndDate     *time.Time // inclusive upper bound (nil = no upper bound)
+	ContextName string     // only entries tagged with this context
+	Top         int        // contexts and templates to return (0 = all)
+}
+
+// DayTotal is the number of entries and words written on one calendar day.
+type DayTotal struct {
+	Date    time.Time `json:"date"` // time part zeroed, local timezone
+	Entries int       `json:"entries"`
+	Words   int       `json:"words"`
+}
+
+// NameCount is the number of entries tagged with a context or template.
+type NameCount struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// Aggregates are the raw writing figures a backend computes for stats.
+type Aggregates struct {
+	Days      []DayTotal  // days with entries, oldest first
+	Hours     [24]int     // entries by hour of creation in the zone written in
+	JotHours  [24]int     // jotted lines by the hour in their timestamp
+	Contexts  []NameCount // most used first, then by name
+	Templates []NameCount // most used first, then by name
+}
+
+// StatsProvider is implemented by backends that compute Aggregates natively
+// instead of loading every entry.
+type StatsProvider interface {
+	Aggregate(opts StatsOptions) (Aggregates, error)
+}
+
+// Aggregate computes writing figures for the entries matching opts, natively
+// when s is a StatsProvider and from the listed entries otherwise.
+func Aggregate(s Storage, opts StatsOptions) (Aggregates, error) {
+	if sp, ok := s.(StatsProvider); ok {
+		return sp.Aggregate(opts)
+	}
+	entries, err := s.List(ListOptions{StartDate: opts.StartDate, EndDate: opts.EndDate, ContextName: opts.ContextName})
+	if err != nil {
+		return Aggregates{}, err
+	}
+	return AggregateEntries(entries, opts.Top), nil
+}
+
+// jotLinePattern matches the timestamp of a jotted line: "- **15:04** text".
+var jotLinePattern = regexp.MustCompile(`(?m)^- \*\*([0-2][0-9]):[0-5][0-9]\*\*`)
+
+// AggregateEntries computes writing figures from entries in memory, keeping
+// the top contexts and templates (all when top is 0).
+func AggregateEntries(entries []entry.Entry, top int) Aggregates {
+	var agg Aggregates
+	days := map[string]*DayTotal{}
+	contexts := map[string]int{}
+	templates := map[string]int{}
+	for _, e := range entries {
+		local := day.In(e.CreatedAt, e.TimeZone)
+		date := day.OfZone(e.CreatedAt, e.TimeZone)
+		key := date.Format("2006-01-02")
+		d := days[key]
+		if d == nil {
+			d = &DayTotal{Date: date}
+			days[key] = d
+		}
+		d.Entries++
+		d.Words += entry.WordCount(e.Content)
+		agg.Hours[local.Hour()]++
+		for _, m := range jotLinePattern.FindAllStringSubmatch(e.Content, -1) {
+			if h, _ := strconv.Atoi(m[1]); h < 24 {
+				agg.JotHours[h]++
+			}
+		}
+		for _, ref := range e.Contexts {
+			contexts[ref.ContextName]++
+		}
+		for _, ref := range e.Templates {
+			templates[ref.TemplateName]++
+		}
+	}
+
+	agg.Days = make([]DayTotal, 0, len(days))
+	for _, d := range days {
+		agg.Days = append(agg.Days, *d)
+	}
+	sort.Slice(agg.Days, func(i, j int) bool { return agg.Days[i].Date.Before(agg.Days[j].Date) })
+	agg.Contexts = topCounts(contexts, top)
+	agg.Templates = topCounts(templates, top)
+	return agg
+}
+
+// topCounts sorts counts by count descending, then name, keeping the first
+// top (all when top is 0).
+func topCounts(counts map[string]int, top int) []NameCount {
+	out := make([]NameCount, 0, len(counts))
+	for name, n := range counts {
+		out = append(out, NameCount{Name: name, Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Count != out[j].Count {
+			return out[i].Count > out[j].Count
+		}
+		return out[i].Name < out[j].Name
+	})
+	if top > 0 && len(out) > top {
+		out = out[:top]
+	}
+	return out
+}
@@ -22,6 +22,7 @@ type ListOptions struct {
 	EndDate      *time.Time // inclusive upper bound (nil = no upper bound)
 	TemplateName string     // filter entries by template attribution
 	ContextName  string     // filter entries by context name
+	Until        *time.Time // inclusive upper bound on CreatedAt, for keyset paging (nil = no bound)
 	OrderBy      string     // "created_at" (default: desc)
 	Limit        int        // 0 = no limit
 	Offset       int        // pagination offset
@@ -47,10 +48,19 @@ type Template struct {
 	Name       string            `json:"name"`
 	Content    string            `json:"content"`
 	Attributes map[string]string `json:"attributes,omitempty"` // Default attributes for generated blocks
+	Version    int               `json:"version,omitempty"`    // Incremented whenever content changes; starts at 1
 	CreatedAt  time.Time         `json:"created_at"`
 	UpdatedAt  time.Time         `json:"updated_at"`
 }
 
+// TemplateVersion is a snapshot of a template's content at one version.
+type TemplateVersion struct {
+	TemplateID string    `json:"template_id"`
+	Version    int       `json:"version"`
+	Content    string    `json:"content"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
 // Context represents a semantic grouping for diary entries.
 type Context struct {
 	ID        string    `json:"id"`
@@ -78,6 +88,7 @@ type Storage interface {
 	ListTemplates() ([]Template, error)
 	UpdateTemplate(id string, name string, content string, attributes map[string]string) (Template, error)
 	DeleteTemplate(id string) error
+	ListTemplateVersions(templateID string) ([]TemplateVersion, error) // oldest first
 
 	// Context methods
 	CreateContext(c Context) error
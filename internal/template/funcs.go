@@ -0,0 +1,187 @@
+package template
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+)
+
+// defaultDateLayout is used by today and yesterday dates unless reformatted.
+const defaultDateLayout = "2006-01-02"
+
+// Date is a point in time paired with the layout used to print it, so that
+// date arithmetic can be chained in pipelines:
+//
+//	{{date "Mon Jan 2" | addDays -1}}
+type Date struct {
+	Time   time.Time
+	Layout string
+}
+
+// String formats the date with its layout.
+func (d Date) String() string {
+	layout := d.Layout
+	if layout == "" {
+		layout = defaultDateLayout
+	}
+	return d.Time.Format(layout)
+}
+
+// funcMap returns the functions available to templates rendered with opts.
+func funcMap(opts RenderOptions) template.FuncMap {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	// Before the day boundary it is still the previous day
+	todayTime := now
+	if day.Of(now).Before(day.NormalizeDate(now.Local())) {
+		todayTime = now.AddDate(0, 0, -1)
+	}
+	today := Date{Time: todayTime, Layout: defaultDateLayout}
+
+	return template.FuncMap{
+		// Dates
+		"now":   func() Date { return Date{Time: now, Layout: "15:04"} },
+		"today": func() Date { return today },
+		"date": func(layout string, d ...Date) Date {
+			t := today.Time
+			if len(d) > 0 {
+				t = d[0].Time
+			}
+			return Date{Time: t, Layout: layout}
+		},
+		"addDays":   func(n int, d Date) Date { d.Time = d.Time.AddDate(0, 0, n); return d },
+		"addWeeks":  func(n int, d Date) Date { d.Time = d.Time.AddDate(0, 0, 7*n); return d },
+		"addMonths": func(n int, d Date) Date { d.Time = d.Time.AddDate(0, n, 0); return d },
+		"isoWeek":   func(d Date) int { _, w := d.Time.ISOWeek(); return w },
+		"isoYear":   func(d Date) int { y, _ := d.Time.ISOWeek(); return y },
+		"weekday":   func(d Date) string { return d.Time.Weekday().String() },
+		"isWeekend": func(d Date) bool { return isWeekend(d.Time) },
+		"isWeekday": func(d Date) bool { return !isWeekend(d.Time) },
+		"isDay": func(days string, d Date) bool {
+			for _, day := range strings.Split(days, ",") {
+				day = strings.ToLower(strings.TrimSpace(day))
+				name := strings.ToLower(d.Time.Weekday().String())
+				if day != "" && strings.HasPrefix(name, day) {
+					return true
+				}
+			}
+			return false
+		},
+
+		// Values
+		"default": func(def any, v ...any) any {
+			if len(v) == 0 || isEmpty(v[0]) {
+				return def
+			}
+			return v[0]
+		},
+
+		// Strings
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"title":      title,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       func(sep string, list []string) string { return strings.Join(list, sep) },
+		"repeat":     func(n int, s string) string { return strings.Repeat(s, n) },
+		"lines":      func(s string) []string { return strings.Split(strings.TrimRight(s, "\n"), "\n") },
+
+		// Diary
+		"contexts": func() []string {
+			if opts.Contexts == nil {
+				return nil
+			}
+			return opts.Contexts()
+		},
+		"yesterday": func() string {
+			if opts.Yesterday == nil {
+				return ""
+			}
+			return opts.Yesterday()
+		},
+		"openTasks": openTasks,
+	}
+}
+
+func isWeekend(t time.Time) bool {
+	return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+}
+
+// isEmpty reports whether v is nil (as for a missing variable), empty, or the
+// zero value of its type.
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+func title(s string) string {
+	words := strings.Fields(s)
+	for i, w := range words {
+		r, size := utf8.DecodeRuneInString(w)
+		words[i] = string(unicode.ToUpper(r)) + w[size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// openTasks returns the unchecked markdown task lines ("- [ ] ...") in s,
+// joined by newlines.
+func openTasks(s string) string {
+	return strings.Join(OpenTasks(s), "\n")
+}
+
+// OpenTasks returns the unchecked markdown task lines ("- [ ] ...") in s,
+// trimmed of surrounding whitespace.
+func OpenTasks(s string) []string {
+	var open []string
+	for _, line := range strings.Split(s, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "- [ ]") || strings.HasPrefix(trimmed, "* [ ]") {
+			open = append(open, trimmed)
+		}
+	}
+	return open
+}
+
+// missingVars returns the variables referenced by t that are not in vars.
+// References that are arguments to default are allowed to be missing.
+func missingVars(t *template.Template, vars map[string]string) []string {
+	seen := map[string]bool{}
+	var missing []string
+	for _, tmpl := range t.Templates() {
+		if tmpl.Tree == nil {
+			continue
+		}
+		walkFields(tmpl.Tree.Root, false, func(name string) {
+			if _, ok := vars[name]; !ok && !seen[name] {
+				seen[name] = true
+				missing = append(missing, name)
+			}
+		})
+	}
+	return missing
+}
+
+func missingVarsError(missing []string) error {
+	return fmt.Errorf("missing template variables: %s", strings.Join(missing, ", "))
+}
@@ -0,0 +1,116 @@
+package template
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/day"
+)
+
+func TestRenderWithFuncs(t *testing.T) {
+	// Friday, October 16, 2026 (ISO week 42)
+	now := time.Date(2026, 10, 16, 9, 30, 0, 0, time.Local)
+	opts := RenderOptions{
+		Vars:      map[string]string{"name": "ada lovelace", "mood": ""},
+		Now:       now,
+		Contexts:  func() []string { return []string{"feature/auth", "sprint-12"} },
+		Yesterday: func() string { return "# Thu\n- [ ] write tests\n- [x] ship it\n  - [ ] review PR" },
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		want string
+	}{
+		{"today", "{{today}}", "2026-10-16"},
+		{"now", "{{now}}", "09:30"},
+		{"date layout", `{{date "Mon Jan 2"}}`, "Fri Oct 16"},
+		{"date arithmetic", `{{date "Mon Jan 2" | addDays -1}}`, "Thu Oct 15"},
+		{"chained arithmetic", `{{today | addWeeks 1 | addMonths -1}}`, "2026-09-23"},
+		{"reformat", `{{today | addDays 1 | date "Monday"}}`, "Saturday"},
+		{"iso week", "Week {{isoWeek today}} review", "Week 42 review"},
+		{"iso year", "{{isoYear today}}-W{{isoWeek today}}", "2026-W42"},
+		{"weekday", "{{weekday today}}", "Friday"},
+		{"weekday conditional", `{{if isDay "mon,fri" today}}retro{{else}}standup{{end}}`, "retro"},
+		{"weekend conditional", `{{if isWeekend (addDays 1 today)}}weekend{{end}}`, "weekend"},
+		{"isWeekday", `{{isWeekday today}}`, "true"},
+		{"default missing", `{{default "none" .missing}}`, "none"},
+		{"default empty", `{{.mood | default "ok"}}`, "ok"},
+		{"default set", `{{.name | default "anon"}}`, "ada lovelace"},
+		{"strings", `{{.name | title}} {{upper "x"}} {{lower "Y"}} {{trim "  z  "}}`, "Ada Lovelace X y z"},
+		{"replace", `{{replace "-" " " "a-b-c"}}`, "a b c"},
+		{"contains", `{{if contains "love" .name}}yes{{end}}`, "yes"},
+		{"split join", `{{split "," "a,b" | join " + "}}`, "a + b"},
+		{"repeat", `{{repeat 3 "="}}`, "==="},
+		{"contexts", `{{join ", " contexts}}`, "feature/auth, sprint-12"},
+		{"open tasks", "{{openTasks yesterday}}", "- [ ] write tests\n- [ ] review PR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderWith(tt.tmpl, opts)
+			if err != nil {
+				t.Fatalf("RenderWith: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWithDayBoundary(t *testing.T) {
+	day.SetStartsAt(4 * time.Hour)
+	t.Cleanup(func() { day.SetStartsAt(0) })
+
+	// 01:30 on Saturday still belongs to Friday's entry
+	opts := RenderOptions{Now: time.Date(2026, 10, 17, 1, 30, 0, 0, time.Local)}
+	got, err := RenderWith(`{{today}} {{weekday today}} {{now}}`, opts)
+	if err != nil {
+		t.Fatalf("RenderWith: %v", err)
+	}
+	if want := "2026-10-16 Friday 01:30"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestRenderWithUnsetProviders(t *testing.T) {
+	got, err := RenderWith("[{{yesterday}}][{{join \",\" contexts}}]", RenderOptions{})
+	if err != nil {
+		t.Fatalf("RenderWith: %v", err)
+	}
+	if got != "[][]" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestRenderWithStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		vars    map[string]string
+		wantErr string
+	}{
+		{"all present", "{{.a}} {{.b}}", map[string]string{"a": "1", "b": "2"}, ""},
+		{"missing reported once", "{{.a}} {{.b}} {{.a}} {{.c}}", map[string]string{"b": "2"}, "missing template variables: a, c"},
+		{"missing in if", "{{if .flag}}x{{end}}", nil, "missing template variables: flag"},
+		{"default argument allowed", `{{default "x" .a}}`, nil, ""},
+		{"default pipeline allowed", `{{.a | default "x"}}`, nil, ""},
+		{"range body rebinds dot", `{{range split "," "a,b"}}{{.}}{{end}}`, nil, ""},
+		{"functions are not variables", "{{today}} {{yesterday}}", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := RenderWith(tt.tmpl, RenderOptions{Vars: tt.vars, Strict: true})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
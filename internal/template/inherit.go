@@ -0,0 +1,228 @@
+package template
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Template content may reuse other templates with three directives, which are
+// expanded when the template is loaded, before variables are rendered:
+//
+//	{{include "name"}}               inserts the named template
+//	{{extends "parent"}}             must come first; uses parent as the layout
+//	{{section "name"}}...{{endsection}}
+//
+// In a parent, a section marks an overridable region and its body is the
+// default content. In a child that extends a parent, sections supply the
+// overrides; child content outside sections is ignored.
+var (
+	directivePattern  = regexp.MustCompile(`\{\{-?\s*(include|extends|section)\s+"([^"]*)"\s*-?\}\}`)
+	endSectionPattern = regexp.MustCompile(`\{\{-?\s*endsection\s*-?\}\}`)
+	includePattern    = regexp.MustCompile(`\{\{-?\s*include\s+"([^"]*)"\s*-?\}\}`)
+	extendsPattern    = regexp.MustCompile(`^\s*\{\{-?\s*extends\s+"([^"]*)"\s*-?\}\}[ \t]*\n?`)
+)
+
+// Resolve loads the named template and expands its include, extends and section
+// directives. Errors name the template that caused them; cycles are reported
+// with the full chain (e.g. a -> b -> a).
+func Resolve(loader TemplateLoader, name string) (string, error) {
+	content, err := resolveRaw(loader, name, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	return stripSections(name, content)
+}
+
+// resolveRaw expands includes and inheritance for name but keeps section
+// markers so that templates extending it can override them. Front matter is
+// stripped; if declared is non-nil, the variables it declares are appended.
+func resolveRaw(loader TemplateLoader, name string, chain []string, declared *[]Variable) (string, error) {
+	for _, n := range chain {
+		if n == name {
+			return "", fmt.Errorf("template cycle: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+	}
+	chain = append(chain, name)
+
+	tmpl, err := loader.GetTemplateByName(name)
+	if err != nil {
+		if len(chain) > 1 {
+			return "", fmt.Errorf("template %q: referenced template %q: %w", chain[len(chain)-2], name, err)
+		}
+		return "", fmt.Errorf("template %q: %w", name, err)
+	}
+	vars, content, err := splitFrontMatter(name, tmpl.Content)
+	if err != nil {
+		return "", err
+	}
+	if declared != nil {
+		*declared = append(*declared, vars...)
+	}
+
+	var parent string
+	if m := extendsPattern.FindStringSubmatch(content); m != nil {
+		parent = m[1]
+		content = content[len(m[0]):]
+	}
+	for _, m := range directivePattern.FindAllStringSubmatch(content, -1) {
+		if m[1] == "extends" {
+			return "", fmt.Errorf("template %q: extends must be the first line", name)
+		}
+	}
+
+	content, err = expandIncludes(loader, content, chain, declared)
+	if err != nil {
+		return "", err
+	}
+
+	if parent == "" {
+		if _, err := parseSections(name, content); err != nil {
+			return "", err
+		}
+		return content, nil
+	}
+
+	overrides, err := parseSections(name, content)
+	if err != nil {
+		return "", err
+	}
+	base, err := resolveRaw(loader, parent, chain, declared)
+	if err != nil {
+		return "", err
+	}
+	overrideMap := make(map[string]string, len(overrides))
+	for _, s := range overrides {
+		overrideMap[s.name] = content[s.bodyStart:s.bodyEnd]
+	}
+	return applyOverrides(parent, base, overrideMap)
+}
+
+func expandIncludes(loader TemplateLoader, content string, chain []string, declared *[]Variable) (string, error) {
+	var firstErr error
+	out := includePattern.ReplaceAllStringFunc(content, func(match string) string {
+		if firstErr != nil {
+			return match
+		}
+		included := includePattern.FindStringSubmatch(match)[1]
+		resolved, err := resolveRaw(loader, included, chain, declared)
+		if err != nil {
+			firstErr = err
+			return match
+		}
+		return strings.TrimRight(resolved, "\n")
+	})
+	if firstErr != nil {
+		return "", firstErr
+	}
+	return out, nil
+}
+
+// section is a top-level {{section}} span within a template's content.
+type section struct {
+	name      string
+	start     int // start of the opening marker
+	bodyStart int // end of the opening marker
+	bodyEnd   int // start of the closing marker
+	end       int // end of the closing marker
+}
+
+// parseSections returns the top-level sections in content, validating that
+// every section is closed and names are not repeated.
+func parseSections(tmplName, content string) ([]section, error) {
+	type marker struct {
+		start, end int
+		name       string
+		closing    bool
+	}
+	var markers []marker
+	for _, loc := range directivePattern.FindAllStringSubmatchIndex(content, -1) {
+		if content[loc[2]:loc[3]] == "section" {
+			markers = append(markers, marker{start: loc[0], end: loc[1], name: content[loc[4]:loc[5]]})
+		}
+	}
+	for _, loc := range endSectionPattern.FindAllStringIndex(content, -1) {
+		markers = append(markers, marker{start: loc[0], end: loc[1], closing: true})
+	}
+	sort.Slice(markers, func(i, j int) bool { return markers[i].start < markers[j].start })
+
+	var sections []section
+	var stack []marker
+	seen := map[string]bool{}
+	for _, m := range markers {
+		if !m.closing {
+			if m.name == "" {
+				return nil, fmt.Errorf("template %q: section name is empty", tmplName)
+			}
+			if seen[m.name] {
+				return nil, fmt.Errorf("template %q: duplicate section %q", tmplName, m.name)
+			}
+			seen[m.name] = true
+			stack = append(stack, m)
+			continue
+		}
+		if len(stack) == 0 {
+			return nil, fmt.Errorf("template %q: endsection without section", tmplName)
+		}
+		open := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if len(stack) == 0 {
+			sections = append(sections, section{
+				name: open.name, start: open.start, bodyStart: open.end, bodyEnd: m.start, end: m.end,
+			})
+		}
+	}
+	if len(stack) > 0 {
+		return nil, fmt.Errorf("template %q: section %q is not closed", tmplName, stack[len(stack)-1].name)
+	}
+	return sections, nil
+}
+
+// applyOverrides replaces the bodies of sections in content (at any depth)
+// with the overrides given, keeping the section markers.
+func applyOverrides(tmplName, content string, overrides map[string]string) (string, error) {
+	sections, err := parseSections(tmplName, content)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	prev := 0
+	for _, s := range sections {
+		b.WriteString(content[prev:s.bodyStart])
+		if body, ok := overrides[s.name]; ok {
+			b.WriteString(body)
+		} else {
+			inner, err := applyOverrides(tmplName, content[s.bodyStart:s.bodyEnd], overrides)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(inner)
+		}
+		b.WriteString(content[s.bodyEnd:s.end])
+		prev = s.end
+	}
+	b.WriteString(content[prev:])
+	return b.String(), nil
+}
+
+// stripSections removes section markers, keeping their bodies.
+func stripSections(tmplName, content string) (string, error) {
+	sections, err := parseSections(tmplName, content)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	prev := 0
+	for _, s := range sections {
+		b.WriteString(content[prev:s.start])
+		inner, err := stripSections(tmplName, content[s.bodyStart:s.bodyEnd])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(inner)
+		prev = s.end
+	}
+	b.WriteString(content[prev:])
+	return b.String(), nil
+}
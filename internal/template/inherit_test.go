@@ -0,0 +1,162 @@
+package template
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+)
+
+func newMock(templates map[string]string) *mockStorage {
+	ms := &mockStorage{templates: map[string]storage.Template{}}
+	for name, content := range templates {
+		ms.templates[name] = storage.Template{ID: name, Name: name, Content: content}
+	}
+	return ms
+}
+
+func TestResolve(t *testing.T) {
+	ms := newMock(map[string]string{
+		"header": "# {{.date}}",
+		"base": "{{include \"header\"}}\n\n" +
+			"{{section \"body\"}}## Notes{{endsection}}\n\n" +
+			"{{section \"footer\"}}-- end{{endsection}}",
+		"standup": "{{extends \"base\"}}\n" +
+			"ignored text\n" +
+			"{{section \"body\"}}## Yesterday\n## Today{{endsection}}",
+		"standup-short": "{{extends \"standup\"}}\n" +
+			"{{section \"footer\"}}{{endsection}}",
+		"nested":       "{{section \"outer\"}}A {{section \"inner\"}}B{{endsection}} C{{endsection}}",
+		"nested-child": "{{extends \"nested\"}}{{section \"inner\"}}X{{endsection}}",
+		"plain":        "no directives {{.x}}",
+	})
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"plain", "no directives {{.x}}"},
+		{"base", "# {{.date}}\n\n## Notes\n\n-- end"},
+		{"standup", "# {{.date}}\n\n## Yesterday\n## Today\n\n-- end"},
+		{"standup-short", "# {{.date}}\n\n## Yesterday\n## Today\n\n"},
+		{"nested", "A B C"},
+		{"nested-child", "A X C"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resolve(ms, tt.name)
+			if err != nil {
+				t.Fatalf("Resolve: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveErrors(t *testing.T) {
+	ms := newMock(map[string]string{
+		"a":            "{{include \"b\"}}",
+		"b":            "{{include \"c\"}}",
+		"c":            "{{include \"a\"}}",
+		"self":         "{{extends \"self\"}}",
+		"dangling":     "see {{include \"missing\"}}",
+		"late-extends": "text\n{{extends \"a\"}}",
+		"unclosed":     "{{section \"body\"}}oops",
+		"stray-end":    "oops{{endsection}}",
+		"dup":          "{{section \"x\"}}{{endsection}}{{section \"x\"}}{{endsection}}",
+	})
+
+	tests := []struct {
+		name    string
+		wantErr string
+	}{
+		{"a", "template cycle: a -> b -> c -> a"},
+		{"self", "template cycle: self -> self"},
+		{"dangling", `template "dangling": referenced template "missing"`},
+		{"late-extends", `template "late-extends": extends must be the first line`},
+		{"unclosed", `template "unclosed": section "body" is not closed`},
+		{"stray-end", `template "stray-end": endsection without section`},
+		{"dup", `template "dup": duplicate section "x"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Resolve(ms, tt.name)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %q, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+
+	_, err := Resolve(ms, "dangling")
+	if !errors.Is(err, storage.ErrNotFound) {
+		t.Errorf("expected ErrNotFound in chain, got %v", err)
+	}
+}
+
+func TestResolveDiamondIsNotACycle(t *testing.T) {
+	ms := newMock(map[string]string{
+		"sig":  "-- me",
+		"left": "{{include \"sig\"}}",
+		"top":  "{{include \"left\"}} / {{include \"sig\"}}",
+	})
+	got, err := Resolve(ms, "top")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if got != "-- me / -- me" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestComposeResolvesInheritance(t *testing.T) {
+	ms := newMock(map[string]string{
+		"base":  "# Day\n{{section \"body\"}}default{{endsection}}",
+		"child": "{{extends \"base\"}}{{section \"body\"}}custom{{endsection}}",
+	})
+	content, refs, err := Compose(ms, []string{"child"})
+	if err != nil {
+		t.Fatalf("Compose: %v", err)
+	}
+	if content != "# Day\ncustom" {
+		t.Errorf("got content %q", content)
+	}
+	if len(refs) != 1 || refs[0].TemplateName != "child" {
+		t.Errorf("expected attribution to the requested template only, got %v", refs)
+	}
+}
+
+func TestResolveV2Templates(t *testing.T) {
+	store, err := markdown.NewV2(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewV2: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now().UTC()
+	for i, tmpl := range []storage.Template{
+		{Name: "meeting-base", Content: "# Meeting\n{{section \"agenda\"}}- TBD{{endsection}}"},
+		{Name: "one-on-one", Content: "{{extends \"meeting-base\"}}\n{{section \"agenda\"}}- Feedback\n- Goals{{endsection}}"},
+	} {
+		tmpl.ID = []string{"tmpl0001", "tmpl0002"}[i]
+		tmpl.CreatedAt, tmpl.UpdatedAt = now, now
+		if err := store.CreateTemplate(tmpl); err != nil {
+			t.Fatalf("CreateTemplate: %v", err)
+		}
+	}
+
+	got, err := Resolve(store, "one-on-one")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if got != "# Meeting\n- Feedback\n- Goals" {
+		t.Errorf("got %q", got)
+	}
+}
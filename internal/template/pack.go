@@ -0,0 +1,199 @@
+package template
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/adrg/frontmatter"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// TemplateStore is the storage needed to import templates.
+type TemplateStore interface {
+	TemplateLoader
+	CreateTemplate(t storage.Template) error
+	UpdateTemplate(id string, name string, content string, attributes map[string]string) (storage.Template, error)
+}
+
+// Import outcomes.
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+)
+
+// ImportResult reports what Import did with one template.
+type ImportResult struct {
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+type exportFrontMatter struct {
+	Name       string            `yaml:"name"`
+	Attributes map[string]string `yaml:"attributes"`
+}
+
+// Marshal encodes a template as a shareable markdown file. The front matter
+// carries the name and attributes; the body is the template content as-is,
+// including its own variables front matter if it has one.
+func Marshal(t storage.Template) []byte {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "name: %s\n", t.Name)
+	if len(t.Attributes) > 0 {
+		keys := make([]string, 0, len(t.Attributes))
+		for k := range t.Attributes {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b.WriteString("attributes:\n")
+		for _, k := range keys {
+			fmt.Fprintf(&b, "  %s: %s\n", strconv.Quote(k), strconv.Quote(t.Attributes[k]))
+		}
+	}
+	b.WriteString("---\n\n")
+	b.WriteString(strings.TrimSpace(t.Content))
+	b.WriteString("\n")
+	return []byte(b.String())
+}
+
+// Unmarshal decodes a template file written by Marshal. If the front matter
+// has no name, fallbackName (usually the file name) is used.
+func Unmarshal(data []byte, fallbackName string) (storage.Template, error) {
+	var fm exportFrontMatter
+	body, err := frontmatter.Parse(strings.NewReader(string(data)), &fm)
+	if err != nil {
+		return storage.Template{}, fmt.Errorf("template %q: parsing front matter: %w", fallbackName, err)
+	}
+	name := fm.Name
+	if name == "" {
+		name = fallbackName
+	}
+	if err := entry.ValidateTemplateName(name); err != nil {
+		return storage.Template{}, err
+	}
+	content := strings.TrimSpace(string(body))
+	if content == "" {
+		return storage.Template{}, fmt.Errorf("template %q: content must not be empty", name)
+	}
+	return storage.Template{Name: name, Content: content, Attributes: fm.Attributes}, nil
+}
+
+// Export writes each template to dir as <name>.md, creating dir if needed.
+func Export(dir string, templates []storage.Template) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating export directory: %w", err)
+	}
+	for _, t := range templates {
+		if err := os.WriteFile(filepath.Join(dir, t.Name+".md"), Marshal(t), 0644); err != nil {
+			return fmt.Errorf("exporting template %q: %w", t.Name, err)
+		}
+	}
+	return nil
+}
+
+// ReadFiles reads template files from a file or a directory of *.md files.
+func ReadFiles(p string) ([]storage.Template, error) {
+	info, err := os.Stat(p)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return readFiles(os.DirFS(filepath.Dir(p)), []string{filepath.Base(p)})
+	}
+	matches, err := fs.Glob(os.DirFS(p), "*.md")
+	if err != nil {
+		return nil, err
+	}
+	return readFiles(os.DirFS(p), matches)
+}
+
+func readFiles(fsys fs.FS, names []string) ([]storage.Template, error) {
+	templates := make([]storage.Template, 0, len(names))
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		t, err := Unmarshal(data, strings.TrimSuffix(path.Base(name), ".md"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		templates = append(templates, t)
+	}
+	sort.Slice(templates, func(i, j int) bool { return templates[i].Name < templates[j].Name })
+	return templates, nil
+}
+
+// Import creates templates that do not exist yet and updates those whose
+// content or attributes have changed; identical templates are left alone.
+func Import(store TemplateStore, templates []storage.Template) ([]ImportResult, error) {
+	results := make([]ImportResult, 0, len(templates))
+	for _, t := range templates {
+		existing, err := store.GetTemplateByName(t.Name)
+		switch {
+		case err == nil:
+			if strings.TrimSpace(existing.Content) == strings.TrimSpace(t.Content) && maps.Equal(existing.Attributes, t.Attributes) {
+				results = append(results, ImportResult{Name: t.Name, Action: ImportUnchanged})
+				continue
+			}
+			if _, err := store.UpdateTemplate(existing.ID, t.Name, t.Content, t.Attributes); err != nil {
+				return results, fmt.Errorf("updating template %q: %w", t.Name, err)
+			}
+			results = append(results, ImportResult{Name: t.Name, Action: ImportUpdated})
+		case errors.Is(err, storage.ErrNotFound):
+			id, err := entry.NewID()
+			if err != nil {
+				return results, err
+			}
+			now := time.Now().UTC()
+			t.ID, t.CreatedAt, t.UpdatedAt = id, now, now
+			if err := store.CreateTemplate(t); err != nil {
+				return results, fmt.Errorf("creating template %q: %w", t.Name, err)
+			}
+			results = append(results, ImportResult{Name: t.Name, Action: ImportCreated})
+		default:
+			return results, err
+		}
+	}
+	return results, nil
+}
+
+//go:embed packs/*/*.md
+var packFS embed.FS
+
+// Packs returns the names of the built-in template packs.
+func Packs() []string {
+	dirs, _ := fs.ReadDir(packFS, "packs")
+	names := make([]string, 0, len(dirs))
+	for _, d := range dirs {
+		if d.IsDir() {
+			names = append(names, d.Name())
+		}
+	}
+	return names
+}
+
+// Pack returns the templates in the named built-in pack.
+func Pack(name string) ([]storage.Template, error) {
+	sub, err := fs.Sub(packFS, path.Join("packs", name))
+	if err != nil {
+		return nil, err
+	}
+	matches, _ := fs.Glob(sub, "*.md")
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("unknown template pack %q (available: %s)", name, strings.Join(Packs(), ", "))
+	}
+	return readFiles(sub, matches)
+}
@@ -0,0 +1,160 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/storage/markdown"
+)
+
+func TestMarshalRoundTrip(t *testing.T) {
+	in := storage.Template{
+		Name:       "standup",
+		Content:    moodTemplate,
+		Attributes: map[string]string{"type": "standup", "team": "core: \"infra\""},
+	}
+	out, err := Unmarshal(Marshal(in), "ignored")
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if out.Name != in.Name || out.Content != in.Content || !reflect.DeepEqual(out.Attributes, in.Attributes) {
+		t.Errorf("round trip mismatch:\ngot  %#v\nwant %#v", out, in)
+	}
+
+	// Files without front matter are named after the file
+	plain, err := Unmarshal([]byte("# Notes\n"), "notes")
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if plain.Name != "notes" || plain.Content != "# Notes" {
+		t.Errorf("got %#v", plain)
+	}
+
+	if _, err := Unmarshal([]byte("---\nname: Bad Name\n---\nx"), "x"); err == nil {
+		t.Error("expected invalid name error")
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	src, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("markdown.New: %v", err)
+	}
+	if _, err := Import(src, []storage.Template{
+		{Name: "retro", Content: "## Retro", Attributes: map[string]string{"type": "retro"}},
+		{Name: "daily", Content: "# {{today}}"},
+	}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	templates, err := src.ListTemplates()
+	if err != nil {
+		t.Fatalf("ListTemplates: %v", err)
+	}
+
+	dir := filepath.Join(t.TempDir(), "shared")
+	if err := Export(dir, templates); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "retro.md"))
+	if err != nil {
+		t.Fatalf("reading export: %v", err)
+	}
+	if !strings.Contains(string(data), "attributes:\n  \"type\": \"retro\"") {
+		t.Errorf("expected attributes in export, got:\n%s", data)
+	}
+
+	dst, err := markdown.New(t.TempDir())
+	if err != nil {
+		t.Fatalf("markdown.New: %v", err)
+	}
+	_ = dst.CreateTemplate(storage.Template{ID: "t1", Name: "daily", Content: "# old"})
+
+	files, err := ReadFiles(dir)
+	if err != nil {
+		t.Fatalf("ReadFiles: %v", err)
+	}
+	results, err := Import(dst, files)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	want := []ImportResult{{"daily", ImportUpdated}, {"retro", ImportCreated}}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("got %v, want %v", results, want)
+	}
+	got, _ := dst.GetTemplateByName("retro")
+	if got.Attributes["type"] != "retro" {
+		t.Errorf("expected attributes to be imported, got %v", got.Attributes)
+	}
+
+	// Importing again changes nothing
+	single, err := ReadFiles(filepath.Join(dir, "retro.md"))
+	if err != nil {
+		t.Fatalf("ReadFiles(file): %v", err)
+	}
+	results, err = Import(dst, single)
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if want := []ImportResult{{"retro", ImportUnchanged}}; !reflect.DeepEqual(results, want) {
+		t.Errorf("got %v, want %v", results, want)
+	}
+}
+
+func TestPacks(t *testing.T) {
+	packs := Packs()
+	for _, want := range []string{"incident-pack", "retro-pack", "standup-pack"} {
+		found := false
+		for _, p := range packs {
+			found = found || p == want
+		}
+		if !found {
+			t.Errorf("missing pack %q in %v", want, packs)
+		}
+	}
+
+	for _, name := range packs {
+		templates, err := Pack(name)
+		if err != nil {
+			t.Fatalf("Pack(%s): %v", name, err)
+		}
+		ms := &mockStorage{templates: map[string]storage.Template{}}
+		for _, tmpl := range templates {
+			ms.templates[tmpl.Name] = tmpl
+		}
+		// Every built-in template must resolve and render with its required
+		// variables answered.
+		for _, tmpl := range templates {
+			t.Run(tmpl.Name, func(t *testing.T) {
+				vars, err := Variables(ms, []string{tmpl.Name})
+				if err != nil {
+					t.Fatalf("Variables: %v", err)
+				}
+				answers := map[string]string{}
+				for _, v := range vars {
+					if v.Required {
+						answers[v.Name] = "x"
+					}
+				}
+				answers, err = ApplyAnswers(vars, answers)
+				if err != nil {
+					t.Fatalf("ApplyAnswers: %v", err)
+				}
+				content, err := Resolve(ms, tmpl.Name)
+				if err != nil {
+					t.Fatalf("Resolve: %v", err)
+				}
+				if _, err := RenderWith(content, RenderOptions{Vars: answers, Strict: true}); err != nil {
+					t.Errorf("RenderWith: %v", err)
+				}
+			})
+		}
+	}
+
+	if _, err := Pack("nope"); err == nil || !strings.Contains(err.Error(), "available: ") {
+		t.Errorf("expected unknown pack error, got %v", err)
+	}
+}
@@ -4,8 +4,19 @@ import (
 	"bytes"
 	"fmt"
 	"text/template"
+	"text/template/parse"
+	"time"
 )
 
+// RenderOptions controls template rendering.
+type RenderOptions struct {
+	Vars      map[string]string // variables available as {{.name}}
+	Now       time.Time         // reference time for date functions; zero = time.Now()
+	Contexts  func() []string   // active context names for {{contexts}}; called lazily
+	Yesterday func() string     // yesterday's entry content for {{yesterday}}; called lazily
+	Strict    bool              // error on variables missing from Vars instead of "<no value>"
+}
+
 // Render executes a Go text/template with the provided variables.
 // It takes a template content string and a map of variable names to values,
 // and returns the rendered result.
@@ -26,13 +37,39 @@ import (
 //	content, err := Render("Hello {{.name}}", map[string]string{"name": "Alice"})
 //	// content = "Hello Alice"
 func Render(tmplContent string, vars map[string]string) (string, error) {
-	// Parse the template
-	tmpl, err := template.New("content").Parse(tmplContent)
+	return RenderWith(tmplContent, RenderOptions{Vars: vars})
+}
+
+// RenderWith executes a template with the function library and options given.
+//
+// Besides variables, templates can use:
+//   - dates: today, now, date "layout" [d], addDays/addWeeks/addMonths n d,
+//     isoWeek, isoYear, weekday, isWeekend, isWeekday, isDay "Mon,Fri" d
+//   - values: default "fallback" .var
+//   - strings: upper, lower, title, trim, trimPrefix, trimSuffix, replace,
+//     contains, hasPrefix, hasSuffix, split, join, repeat, lines
+//   - diary: contexts, yesterday, openTasks
+//
+// Example:
+//
+//	{{if isDay "Fri" today}}Week {{isoWeek today}} review{{end}}
+//	{{openTasks yesterday}}
+func RenderWith(tmplContent string, opts RenderOptions) (string, error) {
+	tmpl, err := template.New("content").Funcs(funcMap(opts)).Parse(tmplContent)
 	if err != nil {
 		return "", fmt.Errorf("parsing template: %w", err)
 	}
 
-	// Execute the template with vars
+	vars := opts.Vars
+	if vars == nil {
+		vars = map[string]string{}
+	}
+	if opts.Strict {
+		if missing := missingVars(tmpl, vars); len(missing) > 0 {
+			return "", missingVarsError(missing)
+		}
+	}
+
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, vars); err != nil {
 		return "", fmt.Errorf("executing template: %w", err)
@@ -40,3 +77,64 @@ func Render(tmplContent string, vars map[string]string) (string, error) {
 
 	return buf.String(), nil
 }
+
+// walkFields calls fn for each top-level variable reference ({{.name}}) in
+// node. References are skipped when allowed is set (arguments to default, or
+// inside range/with where dot is rebound).
+func walkFields(node parse.Node, allowed bool, fn func(string)) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, c := range n.Nodes {
+			walkFields(c, allowed, fn)
+		}
+	case *parse.ActionNode:
+		walkFields(n.Pipe, allowed, fn)
+	case *parse.IfNode:
+		walkFields(n.Pipe, allowed, fn)
+		walkFields(n.List, allowed, fn)
+		walkFields(n.ElseList, allowed, fn)
+	case *parse.RangeNode:
+		walkFields(n.Pipe, allowed, fn)
+		walkFields(n.List, true, fn)
+		walkFields(n.ElseList, allowed, fn)
+	case *parse.WithNode:
+		walkFields(n.Pipe, allowed, fn)
+		walkFields(n.List, true, fn)
+		walkFields(n.ElseList, allowed, fn)
+	case *parse.TemplateNode:
+		walkFields(n.Pipe, allowed, fn)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for i, cmd := range n.Cmds {
+			// {{.x | default "y"}} passes .x to default
+			next := i+1 < len(n.Cmds) && isDefault(n.Cmds[i+1])
+			walkFields(cmd, allowed || next, fn)
+		}
+	case *parse.CommandNode:
+		if isDefault(n) {
+			allowed = true
+		}
+		for _, arg := range n.Args {
+			walkFields(arg, allowed, fn)
+		}
+	case *parse.ChainNode:
+		walkFields(n.Node, allowed, fn)
+	case *parse.FieldNode:
+		if !allowed && len(n.Ident) > 0 {
+			fn(n.Ident[0])
+		}
+	}
+}
+
+func isDefault(cmd *parse.CommandNode) bool {
+	if len(cmd.Args) == 0 {
+		return false
+	}
+	id, ok := cmd.Args[0].(*parse.IdentifierNode)
+	return ok && id.Ident == "default"
+}
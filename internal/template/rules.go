@@ -0,0 +1,159 @@
+package template
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+)
+
+// Rule selects a default template when all of its conditions hold. Empty
+// conditions always hold.
+type Rule struct {
+	Name     string   `json:"name,omitempty"`     // label shown by "template which"
+	Template string   `json:"template"`           // template name(s), comma-separated
+	Days     []string `json:"days,omitempty"`     // weekday prefixes ("mon", "fri"), "weekday" or "weekend"
+	After    string   `json:"after,omitempty"`    // HH:MM, inclusive
+	Before   string   `json:"before,omitempty"`   // HH:MM, exclusive
+	Contexts []string `json:"contexts,omitempty"` // glob patterns, e.g. "incident/*"; any active context may match
+}
+
+// Label returns the rule's name, or its position if unnamed.
+func (r Rule) Label(i int) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return fmt.Sprintf("rule %d", i+1)
+}
+
+// Selection is the outcome of evaluating template rules.
+type Selection struct {
+	Template string `json:"template"`
+	Rule     string `json:"rule,omitempty"` // empty when falling back to the default
+	Reason   string `json:"reason"`
+}
+
+// Select evaluates rules in order and returns the template of the first one
+// that matches now and the active contexts, falling back to def. A malformed
+// rule is reported as an error along with the fallback selection.
+func Select(rules []Rule, def string, now time.Time, contexts []string) (Selection, error) {
+	for i, r := range rules {
+		reasons, ok, err := r.match(now, contexts)
+		if err != nil {
+			return fallback(def), fmt.Errorf("template rule %q: %w", r.Label(i), err)
+		}
+		if ok {
+			reason := "always"
+			if len(reasons) > 0 {
+				reason = strings.Join(reasons, ", ")
+			}
+			return Selection{Template: r.Template, Rule: r.Label(i), Reason: reason}, nil
+		}
+	}
+	return fallback(def), nil
+}
+
+func fallback(def string) Selection {
+	if def == "" {
+		return Selection{Reason: "no rule matched and no default_template is set"}
+	}
+	return Selection{Template: def, Reason: "no rule matched; using default_template"}
+}
+
+// match reports whether the rule applies, with a description of each
+// condition that held.
+func (r Rule) match(now time.Time, contexts []string) ([]string, bool, error) {
+	if r.Template == "" {
+		return nil, false, fmt.Errorf("template is required")
+	}
+	var reasons []string
+
+	if len(r.Days) > 0 {
+		day := strings.ToLower(now.Weekday().String())
+		matched := false
+		for _, d := range r.Days {
+			d = strings.ToLower(strings.TrimSpace(d))
+			switch d {
+			case "weekday", "weekdays":
+				matched = matched || !isWeekend(now)
+			case "weekend", "weekends":
+				matched = matched || isWeekend(now)
+			case "":
+			default:
+				if !isDayPrefix(d) {
+					return nil, false, fmt.Errorf("invalid day %q", d)
+				}
+				matched = matched || strings.HasPrefix(day, d)
+			}
+		}
+		if !matched {
+			return nil, false, nil
+		}
+		reasons = append(reasons, "day is "+now.Weekday().String())
+	}
+
+	if r.After != "" || r.Before != "" {
+		mins := now.Hour()*60 + now.Minute()
+		if r.After != "" {
+			after, err := parseClock(r.After)
+			if err != nil {
+				return nil, false, err
+			}
+			if mins < after {
+				return nil, false, nil
+			}
+			reasons = append(reasons, "after "+r.After)
+		}
+		if r.Before != "" {
+			before, err := parseClock(r.Before)
+			if err != nil {
+				return nil, false, err
+			}
+			if mins >= before {
+				return nil, false, nil
+			}
+			reasons = append(reasons, "before "+r.Before)
+		}
+	}
+
+	if len(r.Contexts) > 0 {
+		var hit string
+		for _, pattern := range r.Contexts {
+			for _, c := range contexts {
+				ok, err := path.Match(pattern, c)
+				if err != nil {
+					return nil, false, fmt.Errorf("invalid context pattern %q: %w", pattern, err)
+				}
+				if ok && hit == "" {
+					hit = fmt.Sprintf("context %s matches %s", c, pattern)
+				}
+			}
+		}
+		if hit == "" {
+			return nil, false, nil
+		}
+		reasons = append(reasons, hit)
+	}
+
+	return reasons, true, nil
+}
+
+// isDayPrefix reports whether d abbreviates exactly one weekday name.
+func isDayPrefix(d string) bool {
+	n := 0
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if strings.HasPrefix(strings.ToLower(wd.String()), d) {
+			n++
+		}
+	}
+	return n == 1
+}
+
+// parseClock parses HH:MM into minutes since midnight.
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time %q: expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
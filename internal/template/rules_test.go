@@ -0,0 +1,74 @@
+package template
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSelect(t *testing.T) {
+	rules := []Rule{
+		{Name: "incidents", Template: "incident", Contexts: []string{"incident/*"}},
+		{Name: "friday", Template: "weekly-review", Days: []string{"fri"}},
+		{Name: "weekday-mornings", Template: "standup", Days: []string{"weekday"}, Before: "12:00"},
+		{Template: "weekend", Days: []string{"sat", "sun"}, After: "08:00"},
+	}
+	// October 2026: 12th is a Monday, 16th a Friday, 17th a Saturday
+	at := func(day, hour int) time.Time { return time.Date(2026, 10, day, hour, 0, 0, 0, time.Local) }
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		contexts []string
+		want     string
+		rule     string
+		reason   string
+	}{
+		{"monday morning", at(12, 9), nil, "standup", "weekday-mornings", "day is Monday, before 12:00"},
+		{"monday afternoon", at(12, 14), nil, "daily", "", "no rule matched; using default_template"},
+		{"friday beats mornings", at(16, 9), nil, "weekly-review", "friday", "day is Friday"},
+		{"incident context first", at(16, 9), []string{"feature/x", "incident/db-outage"}, "incident", "incidents", "context incident/db-outage matches incident/*"},
+		{"nested context does not match single star", at(12, 14), []string{"incident/db/replica"}, "daily", "", ""},
+		{"unnamed rule", at(17, 10), nil, "weekend", "rule 4", "day is Saturday, after 08:00"},
+		{"before after window", at(17, 7), nil, "daily", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Select(rules, "daily", tt.now, tt.contexts)
+			if err != nil {
+				t.Fatalf("Select: %v", err)
+			}
+			if got.Template != tt.want || got.Rule != tt.rule {
+				t.Errorf("got %s (%s), want %s (%s)", got.Template, got.Rule, tt.want, tt.rule)
+			}
+			if tt.reason != "" && got.Reason != tt.reason {
+				t.Errorf("reason = %q, want %q", got.Reason, tt.reason)
+			}
+		})
+	}
+}
+
+func TestSelectInvalidRules(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local)
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr string
+	}{
+		{"no template", Rule{Name: "r"}, `template rule "r": template is required`},
+		{"bad time", Rule{Template: "x", Before: "noon"}, `template rule "rule 1": invalid time "noon"`},
+		{"ambiguous day", Rule{Template: "x", Days: []string{"t"}}, `invalid day "t"`},
+		{"bad pattern", Rule{Template: "x", Contexts: []string{"["}}, `invalid context pattern "["`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Select([]Rule{tt.rule}, "daily", now, []string{"a"})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+			if got.Template != "daily" {
+				t.Errorf("expected fallback to default, got %q", got.Template)
+			}
+		})
+	}
+}
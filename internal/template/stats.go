@@ -0,0 +1,112 @@
+package template
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// PeriodCount is the number of entries that used a template in one period.
+type PeriodCount struct {
+	Period string `json:"period"`
+	Count  int    `json:"count"`
+}
+
+// Usage summarises how a template has been used.
+type Usage struct {
+	Name     string        `json:"name"`
+	Total    int           `json:"total"`
+	Versions map[int]int   `json:"versions,omitempty"` // entries per template version; 0 = recorded before versioning
+	Periods  []PeriodCount `json:"periods"`            // oldest first
+	LastUsed time.Time     `json:"last_used"`
+	Deleted  bool          `json:"deleted,omitempty"` // referenced by entries but no longer stored
+}
+
+// UsageReport is the result of UsageStats.
+type UsageReport struct {
+	By        string   `json:"by"`
+	Templates []Usage  `json:"templates"` // most used first
+	NeverUsed []string `json:"never_used"`
+}
+
+// periodKey buckets t by "day", "week" (ISO), "month" or "year".
+func periodKey(t time.Time, by string) (string, error) {
+	t = t.Local()
+	switch by {
+	case "day":
+		return t.Format("2006-01-02"), nil
+	case "week":
+		y, w := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w), nil
+	case "month":
+		return t.Format("2006-01"), nil
+	case "year":
+		return t.Format("2006"), nil
+	}
+	return "", fmt.Errorf("invalid period %q: expected day, week, month or year", by)
+}
+
+// UsageStats counts the entries that used each template, bucketed by period.
+// Entries are matched to templates by ID so renames are followed; templates
+// with no entries are listed in NeverUsed.
+func UsageStats(templates []storage.Template, entries []entry.Entry, by string) (UsageReport, error) {
+	if _, err := periodKey(time.Time{}, by); err != nil {
+		return UsageReport{}, err
+	}
+
+	names := make(map[string]string, len(templates))
+	for _, t := range templates {
+		names[t.ID] = t.Name
+	}
+
+	usage := map[string]*Usage{}
+	periods := map[string]map[string]int{}
+	for _, e := range entries {
+		for _, ref := range e.Templates {
+			name, ok := names[ref.TemplateID]
+			if !ok {
+				name = ref.TemplateName
+			}
+			u := usage[name]
+			if u == nil {
+				u = &Usage{Name: name, Versions: map[int]int{}, Deleted: !ok}
+				usage[name] = u
+				periods[name] = map[string]int{}
+			}
+			u.Total++
+			u.Versions[ref.TemplateVersion]++
+			if e.CreatedAt.After(u.LastUsed) {
+				u.LastUsed = e.CreatedAt
+			}
+			key, _ := periodKey(e.CreatedAt, by)
+			periods[name][key]++
+		}
+	}
+
+	report := UsageReport{By: by, Templates: []Usage{}, NeverUsed: []string{}}
+	for name, u := range usage {
+		for key, n := range periods[name] {
+			u.Periods = append(u.Periods, PeriodCount{Period: key, Count: n})
+		}
+		sort.Slice(u.Periods, func(i, j int) bool { return u.Periods[i].Period < u.Periods[j].Period })
+		report.Templates = append(report.Templates, *u)
+	}
+	sort.Slice(report.Templates, func(i, j int) bool {
+		a, b := report.Templates[i], report.Templates[j]
+		if a.Total != b.Total {
+			return a.Total > b.Total
+		}
+		return a.Name < b.Name
+	})
+
+	for _, t := range templates {
+		if _, ok := usage[t.Name]; !ok {
+			report.NeverUsed = append(report.NeverUsed, t.Name)
+		}
+	}
+	sort.Strings(report.NeverUsed)
+	return report, nil
+}
@@ -0,0 +1,75 @@
+package template
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func TestUsageStats(t *testing.T) {
+	templates := []storage.Template{
+		{ID: "t1", Name: "standup"},
+		{ID: "t2", Name: "retro"},
+		{ID: "t3", Name: "incident"},
+	}
+	day := func(s string) time.Time {
+		d, _ := time.ParseInLocation("2006-01-02", s, time.Local)
+		return d
+	}
+	used := func(at string, refs ...entry.TemplateRef) entry.Entry {
+		return entry.Entry{CreatedAt: day(at), Templates: refs}
+	}
+	entries := []entry.Entry{
+		// Recorded under an old name; matched by ID
+		used("2026-09-28", entry.TemplateRef{TemplateID: "t1", TemplateName: "daily-standup"}),
+		used("2026-10-01", entry.TemplateRef{TemplateID: "t1", TemplateName: "standup", TemplateVersion: 2}),
+		used("2026-10-02", entry.TemplateRef{TemplateID: "t1", TemplateName: "standup", TemplateVersion: 2},
+			entry.TemplateRef{TemplateID: "t2", TemplateName: "retro", TemplateVersion: 1}),
+		used("2026-10-03", entry.TemplateRef{TemplateID: "gone", TemplateName: "old"}),
+		used("2026-10-04"),
+	}
+
+	report, err := UsageStats(templates, entries, "month")
+	if err != nil {
+		t.Fatalf("UsageStats: %v", err)
+	}
+	if len(report.Templates) != 3 {
+		t.Fatalf("expected 3 used templates, got %+v", report.Templates)
+	}
+	standup := report.Templates[0]
+	if standup.Name != "standup" || standup.Total != 3 {
+		t.Errorf("expected standup first with 3 uses, got %+v", standup)
+	}
+	if want := map[int]int{0: 1, 2: 2}; !reflect.DeepEqual(standup.Versions, want) {
+		t.Errorf("versions = %v, want %v", standup.Versions, want)
+	}
+	wantPeriods := []PeriodCount{{"2026-09", 1}, {"2026-10", 2}}
+	if !reflect.DeepEqual(standup.Periods, wantPeriods) {
+		t.Errorf("periods = %v, want %v", standup.Periods, wantPeriods)
+	}
+	if !standup.LastUsed.Equal(day("2026-10-02")) {
+		t.Errorf("last used = %v", standup.LastUsed)
+	}
+	if report.Templates[1].Name != "old" || !report.Templates[1].Deleted {
+		t.Errorf("expected deleted template 'old' second, got %+v", report.Templates[1])
+	}
+	if want := []string{"incident"}; !reflect.DeepEqual(report.NeverUsed, want) {
+		t.Errorf("never used = %v, want %v", report.NeverUsed, want)
+	}
+
+	week, err := UsageStats(templates, entries, "week")
+	if err != nil {
+		t.Fatalf("UsageStats(week): %v", err)
+	}
+	// 2026-09-28 is the Monday of the week holding the other standups
+	if want := []PeriodCount{{"2026-W40", 3}}; !reflect.DeepEqual(week.Templates[0].Periods, want) {
+		t.Errorf("weekly periods = %v, want %v", week.Templates[0].Periods, want)
+	}
+
+	if _, err := UsageStats(templates, entries, "fortnight"); err == nil {
+		t.Error("expected invalid period error")
+	}
+}
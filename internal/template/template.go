@@ -29,7 +29,8 @@ func ParseNames(s string) []string {
 	return names
 }
 
-// Compose loads the named templates and concatenates their content.
+// Compose loads the named templates, resolves their includes and inheritance,
+// and concatenates the results.
 // Returns the combined content and a slice of TemplateRefs for attribution.
 // If names is empty, returns ("", nil, nil).
 // If any template is not found, returns an error immediately (fail fast).
@@ -46,10 +47,15 @@ func Compose(loader TemplateLoader, names []string) (string, []entry.TemplateRef
 		if err != nil {
 			return "", nil, fmt.Errorf("template %q: %w", name, err)
 		}
-		parts = append(parts, tmpl.Content)
+		content, err := Resolve(loader, name)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, content)
 		refs = append(refs, entry.TemplateRef{
-			TemplateID:   tmpl.ID,
-			TemplateName: tmpl.Name,
+			TemplateID:      tmpl.ID,
+			TemplateName:    tmpl.Name,
+			TemplateVersion: tmpl.Version,
 		})
 	}
 
@@ -0,0 +1,164 @@
+package template
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/adrg/frontmatter"
+)
+
+// Variable types for guided capture.
+const (
+	VarText      = "text"
+	VarMultiline = "multiline"
+	VarNumber    = "number"
+	VarChoice    = "choice"
+)
+
+// Variable is a template variable declared in the template's front matter:
+//
+//	---
+//	variables:
+//	  - name: mood
+//	    prompt: How are you feeling?
+//	    type: choice
+//	    choices: [great, good, okay, bad]
+//	    default: good
+//	    required: true
+//	---
+type Variable struct {
+	Name     string   `yaml:"name" json:"name"`
+	Prompt   string   `yaml:"prompt" json:"prompt,omitempty"`
+	Type     string   `yaml:"type" json:"type,omitempty"` // text (default), multiline, number, choice
+	Default  string   `yaml:"default" json:"default,omitempty"`
+	Choices  []string `yaml:"choices" json:"choices,omitempty"`
+	Required bool     `yaml:"required" json:"required,omitempty"`
+}
+
+// Question returns the prompt text, falling back to the variable name.
+func (v Variable) Question() string {
+	if v.Prompt != "" {
+		return v.Prompt
+	}
+	return v.Name
+}
+
+// Kind returns the variable type, defaulting to text.
+func (v Variable) Kind() string {
+	if v.Type == "" {
+		return VarText
+	}
+	return v.Type
+}
+
+// Validate checks an answer against the variable's type and choices.
+// Empty answers are valid unless the variable is required.
+func (v Variable) Validate(answer string) error {
+	if answer == "" {
+		if v.Required {
+			return fmt.Errorf("%s is required", v.Name)
+		}
+		return nil
+	}
+	switch v.Kind() {
+	case VarNumber:
+		if _, err := strconv.ParseFloat(answer, 64); err != nil {
+			return fmt.Errorf("%s must be a number, got %q", v.Name, answer)
+		}
+	case VarChoice:
+		if len(v.Choices) > 0 && !slices.Contains(v.Choices, answer) {
+			return fmt.Errorf("%s must be one of %s, got %q", v.Name, strings.Join(v.Choices, ", "), answer)
+		}
+	}
+	return nil
+}
+
+type frontMatter struct {
+	Variables []Variable `yaml:"variables"`
+}
+
+// splitFrontMatter separates a leading YAML front matter block from template
+// content and returns the variables it declares.
+func splitFrontMatter(tmplName, content string) ([]Variable, string, error) {
+	if !strings.HasPrefix(content, "---") {
+		return nil, content, nil
+	}
+	var fm frontMatter
+	body, err := frontmatter.Parse(strings.NewReader(content), &fm)
+	if err != nil {
+		return nil, "", fmt.Errorf("template %q: parsing front matter: %w", tmplName, err)
+	}
+	for i, v := range fm.Variables {
+		if v.Name == "" {
+			return nil, "", fmt.Errorf("template %q: variable %d has no name", tmplName, i+1)
+		}
+		switch v.Kind() {
+		case VarText, VarMultiline, VarNumber, VarChoice:
+		default:
+			return nil, "", fmt.Errorf("template %q: variable %q has unknown type %q", tmplName, v.Name, v.Type)
+		}
+		if v.Kind() == VarChoice && len(v.Choices) == 0 {
+			return nil, "", fmt.Errorf("template %q: choice variable %q has no choices", tmplName, v.Name)
+		}
+	}
+	return fm.Variables, strings.TrimLeft(string(body), "\n"), nil
+}
+
+// Body returns template content without its front matter. Content with
+// malformed front matter is returned unchanged.
+func Body(content string) string {
+	_, body, err := splitFrontMatter("", content)
+	if err != nil {
+		return content
+	}
+	return body
+}
+
+// Variables returns the variables declared by the named templates and the
+// templates they include or extend, in declaration order. When several
+// templates declare the same name, the first declaration wins.
+func Variables(loader TemplateLoader, names []string) ([]Variable, error) {
+	var vars []Variable
+	seen := map[string]bool{}
+	for _, name := range names {
+		var declared []Variable
+		if _, err := resolveRaw(loader, name, nil, &declared); err != nil {
+			return nil, err
+		}
+		for _, v := range declared {
+			if !seen[v.Name] {
+				seen[v.Name] = true
+				vars = append(vars, v)
+			}
+		}
+	}
+	return vars, nil
+}
+
+// ApplyAnswers validates answers against the declared variables and fills in
+// defaults for missing ones. Answers for undeclared names are kept as-is.
+// All validation problems are reported together.
+func ApplyAnswers(vars []Variable, answers map[string]string) (map[string]string, error) {
+	out := make(map[string]string, len(answers)+len(vars))
+	for k, v := range answers {
+		out[k] = v
+	}
+	var problems []string
+	for _, v := range vars {
+		answer, ok := out[v.Name]
+		if !ok || answer == "" {
+			answer = v.Default
+		}
+		if err := v.Validate(answer); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		out[v.Name] = answer
+	}
+	if len(problems) > 0 {
+		return nil, fmt.Errorf("invalid template answers: %s", strings.Join(problems, "; "))
+	}
+	return out, nil
+}
@@ -0,0 +1,156 @@
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const moodTemplate = `---
+variables:
+  - name: mood
+    prompt: How are you feeling?
+    type: choice
+    choices: [great, good, bad]
+    default: good
+  - name: energy
+    type: number
+    required: true
+---
+# Check-in
+Mood: {{.mood}} ({{.energy}}/10)`
+
+func TestVariables(t *testing.T) {
+	ms := newMock(map[string]string{
+		"mood": moodTemplate,
+		"focus": "---\nvariables:\n  - name: focus\n    type: multiline\n  - name: mood\n    default: meh\n---\n" +
+			"{{include \"mood\"}}\n## Focus\n{{.focus}}",
+		"plain": "# {{.date}}",
+	})
+
+	vars, err := Variables(ms, []string{"focus", "plain"})
+	if err != nil {
+		t.Fatalf("Variables: %v", err)
+	}
+	var names []string
+	for _, v := range vars {
+		names = append(names, v.Name)
+	}
+	if want := []string{"focus", "mood", "energy"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("got %v, want %v", names, want)
+	}
+	if vars[1].Default != "meh" {
+		t.Errorf("expected first declaration of mood to win, got default %q", vars[1].Default)
+	}
+
+	content, err := Resolve(ms, "focus")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	if strings.Contains(content, "variables:") {
+		t.Errorf("front matter leaked into resolved content: %q", content)
+	}
+	if !strings.HasPrefix(content, "# Check-in\n") {
+		t.Errorf("got %q", content)
+	}
+}
+
+func TestVariablesFrontMatterErrors(t *testing.T) {
+	ms := newMock(map[string]string{
+		"unnamed":   "---\nvariables:\n  - prompt: hi\n---\nbody",
+		"bad-type":  "---\nvariables:\n  - name: x\n    type: date\n---\nbody",
+		"no-choice": "---\nvariables:\n  - name: x\n    type: choice\n---\nbody",
+	})
+	tests := []struct {
+		name    string
+		wantErr string
+	}{
+		{"unnamed", `template "unnamed": variable 1 has no name`},
+		{"bad-type", `template "bad-type": variable "x" has unknown type "date"`},
+		{"no-choice", `template "no-choice": choice variable "x" has no choices`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Variables(ms, []string{tt.name})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("got error %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyAnswers(t *testing.T) {
+	ms := newMock(map[string]string{"mood": moodTemplate})
+	vars, err := Variables(ms, []string{"mood"})
+	if err != nil {
+		t.Fatalf("Variables: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		answers map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{"defaults filled", map[string]string{"energy": "7"}, map[string]string{"mood": "good", "energy": "7"}, ""},
+		{"extra answers kept", map[string]string{"energy": "7", "mood": "great", "x": "y"}, map[string]string{"mood": "great", "energy": "7", "x": "y"}, ""},
+		{"required missing", nil, nil, "energy is required"},
+		{"bad number", map[string]string{"energy": "lots"}, nil, `energy must be a number, got "lots"`},
+		{"bad choice", map[string]string{"energy": "1", "mood": "meh"}, nil, "mood must be one of great, good, bad"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplyAnswers(vars, tt.answers)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApplyAnswers: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	content, err := Resolve(ms, "mood")
+	if err != nil {
+		t.Fatalf("Resolve: %v", err)
+	}
+	answers, _ := ApplyAnswers(vars, map[string]string{"energy": "7"})
+	out, err := RenderWith(content, RenderOptions{Vars: answers, Strict: true})
+	if err != nil {
+		t.Fatalf("RenderWith: %v", err)
+	}
+	if out != "# Check-in\nMood: good (7/10)" {
+		t.Errorf("got %q", out)
+	}
+}
+
+func TestFrontMatterWithoutVariables(t *testing.T) {
+	ms := newMock(map[string]string{
+		"rule":  "---\n\nafter a rule",
+		"empty": "---\n---\nbody",
+	})
+	for name, want := range map[string]string{"rule": "---\n\nafter a rule", "empty": "body"} {
+		got, err := Resolve(ms, name)
+		if err != nil {
+			t.Fatalf("Resolve(%s): %v", name, err)
+		}
+		if got != want {
+			t.Errorf("Resolve(%s) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestBody(t *testing.T) {
+	if got := Body(moodTemplate); !strings.HasPrefix(got, "# Check-in") {
+		t.Errorf("got %q", got)
+	}
+	if got := Body("# plain"); got != "# plain" {
+		t.Errorf("got %q", got)
+	}
+}
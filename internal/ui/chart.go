@@ -0,0 +1,34 @@
+package ui
+
+import "strings"
+
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a row of block characters scaled to the
+// largest value. Zero values render as the lowest block and any non-zero
+// value at least one level above it.
+func Sparkline(values []int) string {
+	peak := 0
+	for _, v := range values {
+		peak = max(peak, v)
+	}
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if peak > 0 && v > 0 {
+			level = (v*(len(sparkLevels)-1) + peak - 1) / peak
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
+
+// Bar renders n as a horizontal bar up to width cells long, scaled so that
+// peak fills the width. Non-zero values get at least one cell.
+func Bar(n, peak, width int) string {
+	if n <= 0 || peak <= 0 || width <= 0 {
+		return ""
+	}
+	cells := max(n*width/peak, 1)
+	return strings.Repeat("█", min(cells, width))
+}
@@ -0,0 +1,37 @@
+package ui
+
+import "testing"
+
+func TestSparkline(t *testing.T) {
+	tests := []struct {
+		values []int
+		want   string
+	}{
+		{nil, ""},
+		{[]int{0, 0}, "▁▁"},
+		{[]int{0, 1, 7}, "▁▂█"},
+		{[]int{0, 1, 4, 7}, "▁▂▅█"},
+	}
+	for _, tt := range tests {
+		if got := Sparkline(tt.values); got != tt.want {
+			t.Errorf("Sparkline(%v) = %q, want %q", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestBar(t *testing.T) {
+	tests := []struct {
+		n, peak, width int
+		want           string
+	}{
+		{0, 10, 10, ""},
+		{10, 10, 4, "████"},
+		{5, 10, 4, "██"},
+		{1, 100, 4, "█"},
+	}
+	for _, tt := range tests {
+		if got := Bar(tt.n, tt.peak, tt.width); got != tt.want {
+			t.Errorf("Bar(%d, %d, %d) = %q, want %q", tt.n, tt.peak, tt.width, got, tt.want)
+		}
+	}
+}
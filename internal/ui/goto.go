@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chris-regnier/diaryctl/internal/dateparse"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// startGoto opens the go-to-date prompt.
+func (m pickerModel) startGoto() (tea.Model, tea.Cmd) {
+	ti := textinput.New()
+	ti.Placeholder = "yesterday, last monday, -3d, 2026-W41, march..."
+	ti.Prompt = "go to: "
+	ti.Focus()
+	ti.CharLimit = 64
+	ti.Width = m.contentWidth() - 10
+	m.gotoInput = ti
+	m.gotoErr = ""
+	m.gotoActive = true
+	return m, textinput.Blink
+}
+
+func (m pickerModel) updateGotoInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		input := strings.TrimSpace(m.gotoInput.Value())
+		if input == "" {
+			m.gotoActive = false
+			return m, nil
+		}
+		r, err := dateparse.ParseRange(input)
+		if err != nil {
+			m.gotoErr = err.Error()
+			return m, nil
+		}
+		return m.gotoRange(r)
+	case "esc":
+		m.gotoActive = false
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.gotoInput, cmd = m.gotoInput.Update(msg)
+	return m, cmd
+}
+
+// gotoRange opens the day detail for a single day, or the date list limited
+// to the days of a longer range. The prompt stays open when nothing was
+// written in the range.
+func (m pickerModel) gotoRange(r dateparse.Range) (tea.Model, tea.Cmd) {
+	if r.SingleDay() {
+		key := r.Start.Format("2006-01-02")
+		days, err := m.store.ListDays(storage.ListDaysOptions{})
+		if err != nil {
+			m.err = err
+			return m, tea.Quit
+		}
+		for i, d := range days {
+			if d.Date.Format("2006-01-02") == key {
+				m.gotoActive = false
+				m.days = days
+				m.dayIdx = i
+				m.heatmapDay = false
+				return m.loadDayDetail()
+			}
+		}
+		m.gotoErr = fmt.Sprintf("no entries on %s", key)
+		return m, nil
+	}
+
+	start, end := r.Start, r.End
+	all, err := m.store.ListDays(storage.ListDaysOptions{StartDate: &start, EndDate: &end})
+	if err != nil {
+		m.err = err
+		return m, tea.Quit
+	}
+	var days []storage.DaySummary
+	for _, d := range all {
+		if !d.Date.Before(start) && !d.Date.After(end) {
+			days = append(days, d)
+		}
+	}
+	span := fmt.Sprintf("%s to %s", start.Format("2006-01-02"), end.Format("2006-01-02"))
+	if len(days) == 0 {
+		m.gotoErr = "no entries from " + span
+		return m, nil
+	}
+	m.gotoActive = false
+	return m.showDateList(days, "Daily View: "+span)
+}
+
+// showDateList lists days on the date list screen under title.
+func (m pickerModel) showDateList(days []storage.DaySummary, title string) (tea.Model, tea.Cmd) {
+	m.days = days
+	m.heatmapDay = false
+	items := make([]list.Item, len(days))
+	for i, d := range days {
+		items[i] = dateItem{summary: d}
+	}
+	m.dateList = m.cfg.Theme.NewList(items, 0, 0)
+	m.dateList.Title = title
+	m.dateList.SetShowHelp(false)
+	if m.ready {
+		m.dateList.SetSize(m.contentWidth(), m.height-2)
+	}
+	m.screen = screenDateList
+	return m, nil
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// typeGoto opens the go-to-date prompt, types input and presses enter.
+func typeGoto(t *testing.T, m pickerModel, input string) pickerModel {
+	t.Helper()
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'g'}})
+	m = updated.(pickerModel)
+	if !m.gotoActive {
+		t.Fatal("expected go-to prompt after pressing 'g'")
+	}
+	for _, r := range input {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(pickerModel)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	return runCmd(t, updated.(pickerModel), cmd)
+}
+
+func TestGotoDate(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	yesterday := today.AddDate(0, 0, -1)
+	march5 := time.Date(2026, time.March, 5, 0, 0, 0, 0, time.Local)
+	march9 := time.Date(2026, time.March, 9, 0, 0, 0, 0, time.Local)
+	e1 := entry.Entry{ID: "goto0001", Content: "yesterday's entry", CreatedAt: yesterday.Add(9 * time.Hour)}
+	e2 := entry.Entry{ID: "goto0002", Content: "march entry", CreatedAt: march5.Add(9 * time.Hour)}
+	e3 := entry.Entry{ID: "goto0003", Content: "another march entry", CreatedAt: march9.Add(9 * time.Hour)}
+	mock := &mockStorage{
+		days: []storage.DaySummary{
+			{Date: yesterday, Count: 1, Preview: e1.Content},
+			{Date: march9, Count: 1, Preview: e3.Content},
+			{Date: march5, Count: 1, Preview: e2.Content},
+		},
+		entries: map[string][]entry.Entry{
+			yesterday.Format("2006-01-02"): {e1},
+			"2026-03-05":                   {e2},
+			"2026-03-09":                   {e3},
+		},
+	}
+	m := newTUIModel(mock, TUIConfig{Editor: "vi", Theme: presets["default-dark"]})
+	sized, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = sized.(pickerModel)
+
+	t.Run("relative day opens day detail", func(t *testing.T) {
+		got := typeGoto(t, m, "yesterday")
+		if got.gotoActive || got.screen != screenDayDetail {
+			t.Fatalf("expected day detail, got screen %d (prompt open %v)", got.screen, got.gotoActive)
+		}
+		if !got.days[got.dayIdx].Date.Equal(yesterday) || len(got.dayList.Items()) != 1 {
+			t.Errorf("expected yesterday's entry, got day %v", got.days[got.dayIdx].Date)
+		}
+	})
+
+	t.Run("range opens date list", func(t *testing.T) {
+		got := typeGoto(t, m, "march 2026")
+		if got.gotoActive || got.screen != screenDateList {
+			t.Fatalf("expected date list, got screen %d (prompt open %v)", got.screen, got.gotoActive)
+		}
+		if len(got.dateList.Items()) != 2 {
+			t.Errorf("expected 2 days in March, got %d", len(got.dateList.Items()))
+		}
+		if !strings.Contains(got.dateList.Title, "2026-03-01 to 2026-03-31") {
+			t.Errorf("title = %q", got.dateList.Title)
+		}
+	})
+
+	t.Run("day without entries keeps prompt", func(t *testing.T) {
+		got := typeGoto(t, m, "2026-03-06")
+		if !got.gotoActive || got.screen != screenToday {
+			t.Fatalf("expected prompt to stay open on today, got screen %d", got.screen)
+		}
+		if !strings.Contains(stripANSI(got.View()), "no entries on 2026-03-06") {
+			t.Errorf("expected no-entries message in view:\n%s", stripANSI(got.View()))
+		}
+	})
+
+	t.Run("invalid input keeps prompt", func(t *testing.T) {
+		got := typeGoto(t, m, "someday")
+		if !got.gotoActive || got.gotoErr == "" {
+			t.Fatalf("expected error in open prompt, got %q", got.gotoErr)
+		}
+		updated, _ := got.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		if updated.(pickerModel).gotoActive {
+			t.Error("expected esc to close the prompt")
+		}
+	})
+}
@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chris-regnier/diaryctl/internal/template"
+)
+
+// ErrGuidedCancelled is returned when the user cancels a guided form.
+var ErrGuidedCancelled = errors.New("guided capture cancelled")
+
+// guidedForm asks for template variables one at a time. It is used both as an
+// overlay in the TUI and as a standalone program by RunGuidedForm.
+type guidedForm struct {
+	title     string
+	vars      []template.Variable
+	answers   map[string]string
+	idx       int
+	input     textinput.Model
+	area      textarea.Model
+	choice    int
+	err       string
+	done      bool
+	cancelled bool
+	width     int
+	theme     Theme
+}
+
+func newGuidedForm(title string, vars []template.Variable, preset map[string]string, width int, theme Theme) guidedForm {
+	f := guidedForm{
+		title:   title,
+		vars:    vars,
+		answers: make(map[string]string, len(vars)),
+		width:   max(width, 30),
+		theme:   theme,
+	}
+	for k, v := range preset {
+		f.answers[k] = v
+	}
+	f.focus()
+	return f
+}
+
+// current returns the variable being asked for.
+func (f guidedForm) current() template.Variable {
+	return f.vars[f.idx]
+}
+
+// focus prepares the input widget for the current variable, prefilled with
+// any preset answer or the default.
+func (f *guidedForm) focus() {
+	f.err = ""
+	if f.idx >= len(f.vars) {
+		f.done = true
+		return
+	}
+	v := f.current()
+	value, ok := f.answers[v.Name]
+	if !ok {
+		value = v.Default
+	}
+	switch v.Kind() {
+	case template.VarMultiline:
+		ta := textarea.New()
+		ta.Placeholder = "^J=newline ↵=next"
+		ta.ShowLineNumbers = false
+		ta.SetWidth(f.width - 4)
+		ta.SetHeight(4)
+		ta.SetValue(value)
+		ta.Focus()
+		f.area = ta
+	case template.VarChoice:
+		f.choice = 0
+		for i, c := range v.Choices {
+			if c == value {
+				f.choice = i
+			}
+		}
+	default:
+		ti := textinput.New()
+		ti.Prompt = "> "
+		ti.Width = f.width - 6
+		ti.SetValue(value)
+		ti.Focus()
+		f.input = ti
+	}
+}
+
+// value returns the answer currently entered for the current variable.
+func (f guidedForm) value() string {
+	v := f.current()
+	switch v.Kind() {
+	case template.VarMultiline:
+		return strings.TrimSpace(f.area.Value())
+	case template.VarChoice:
+		return v.Choices[f.choice]
+	default:
+		return strings.TrimSpace(f.input.Value())
+	}
+}
+
+// next validates answer for the current variable and moves on.
+func (f guidedForm) next(answer string) (guidedForm, tea.Cmd) {
+	v := f.current()
+	if err := v.Validate(answer); err != nil {
+		f.err = err.Error()
+		return f, nil
+	}
+	f.answers[v.Name] = answer
+	f.idx++
+	f.focus()
+	if f.done {
+		return f, nil
+	}
+	return f, textinput.Blink
+}
+
+func (f guidedForm) Update(msg tea.KeyMsg) (guidedForm, tea.Cmd) {
+	if f.done {
+		return f, nil
+	}
+	v := f.current()
+	switch msg.String() {
+	case "ctrl+c":
+		f.cancelled = true
+		f.done = true
+		return f, nil
+	case "enter":
+		return f.next(f.value())
+	case "esc":
+		// Skip: fall back to the default
+		if v.Required && v.Default == "" {
+			f.err = fmt.Sprintf("%s is required", v.Name)
+			return f, nil
+		}
+		return f.next(v.Default)
+	}
+
+	var cmd tea.Cmd
+	switch v.Kind() {
+	case template.VarMultiline:
+		if msg.String() == "ctrl+j" {
+			f.area.InsertString("\n")
+			return f, nil
+		}
+		f.area, cmd = f.area.Update(msg)
+	case template.VarChoice:
+		switch msg.String() {
+		case "up", "k", "shift+tab":
+			f.choice = (f.choice + len(v.Choices) - 1) % len(v.Choices)
+		case "down", "j", "tab":
+			f.choice = (f.choice + 1) % len(v.Choices)
+		}
+	default:
+		f.input, cmd = f.input.Update(msg)
+	}
+	return f, cmd
+}
+
+func (f guidedForm) View() string {
+	if f.done {
+		return ""
+	}
+	v := f.current()
+	var b strings.Builder
+	b.WriteString(f.theme.HeaderStyle().Render(fmt.Sprintf("%s (%d/%d)", f.title, f.idx+1, len(f.vars))))
+	b.WriteString("\n\n")
+	question := v.Question()
+	if v.Required {
+		question += " *"
+	}
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(f.theme.Primary).Render(question))
+	b.WriteString("\n")
+
+	switch v.Kind() {
+	case template.VarMultiline:
+		b.WriteString(f.area.View())
+	case template.VarChoice:
+		for i, c := range v.Choices {
+			if i == f.choice {
+				b.WriteString(f.theme.AccentStyle().Render("> " + c))
+			} else {
+				b.WriteString("  " + c)
+			}
+			b.WriteString("\n")
+		}
+	default:
+		b.WriteString(f.input.View())
+	}
+
+	if f.err != "" {
+		b.WriteString("\n" + f.theme.DangerStyle().Render(f.err))
+	}
+	hint := "enter next  esc skip  ctrl+c cancel"
+	if v.Kind() == template.VarChoice {
+		hint = "↑/↓ choose  " + hint
+	}
+	b.WriteString("\n" + f.theme.HelpStyle().Render(hint))
+	return b.String()
+}
+
+// guidedProgram runs a guidedForm on its own, outside the TUI.
+type guidedProgram struct {
+	form guidedForm
+}
+
+func (m guidedProgram) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m guidedProgram) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		var cmd tea.Cmd
+		m.form, cmd = m.form.Update(msg)
+		if m.form.done {
+			return m, tea.Quit
+		}
+		return m, cmd
+	}
+	return m, nil
+}
+
+func (m guidedProgram) View() string {
+	return m.form.View()
+}
+
+// RunGuidedForm prompts for each variable in turn and returns the answers,
+// including any preset answers passed in. Preset values prefill the prompts.
+// It returns ErrGuidedCancelled if the user cancels.
+func RunGuidedForm(title string, vars []template.Variable, preset map[string]string, theme Theme) (map[string]string, error) {
+	form := newGuidedForm(title, vars, preset, 60, theme)
+	if form.done {
+		return form.answers, nil
+	}
+	result, err := tea.NewProgram(guidedProgram{form: form}).Run()
+	if err != nil {
+		return nil, err
+	}
+	form = result.(guidedProgram).form
+	if form.cancelled {
+		return nil, ErrGuidedCancelled
+	}
+	return form.answers, nil
+}
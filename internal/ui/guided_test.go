@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	dctx "github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+	"github.com/chris-regnier/diaryctl/internal/template"
+)
+
+// templateStorage adds named templates to mockStorage.
+type templateStorage struct {
+	*mockStorage
+	templates map[string]string
+}
+
+func (s *templateStorage) GetTemplateByName(name string) (storage.Template, error) {
+	content, ok := s.templates[name]
+	if !ok {
+		return storage.Template{}, storage.ErrNotFound
+	}
+	return storage.Template{ID: name, Name: name, Content: content}, nil
+}
+
+var guidedVars = []template.Variable{
+	{Name: "win", Prompt: "Biggest win?", Required: true},
+	{Name: "mood", Type: template.VarChoice, Choices: []string{"good", "okay", "bad"}, Default: "okay"},
+	{Name: "notes", Type: template.VarMultiline},
+}
+
+func typeText(f guidedForm, s string) guidedForm {
+	for _, r := range s {
+		f, _ = f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return f
+}
+
+func TestGuidedForm(t *testing.T) {
+	f := newGuidedForm("retro", guidedVars, nil, 60, presets["default-dark"])
+
+	// Required variable cannot be skipped or left empty
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if f.idx != 0 || !strings.Contains(f.err, "win is required") {
+		t.Fatalf("expected required error, got idx=%d err=%q", f.idx, f.err)
+	}
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if f.idx != 0 {
+		t.Fatal("expected empty required answer to be rejected")
+	}
+
+	f = typeText(f, "shipped auth")
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if f.idx != 1 || f.err != "" {
+		t.Fatalf("expected to advance, got idx=%d err=%q", f.idx, f.err)
+	}
+
+	// Choice starts on the default and moves with the arrow keys
+	if f.choice != 1 {
+		t.Errorf("expected default choice to be selected, got %d", f.choice)
+	}
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyUp})
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	// Multiline supports ctrl+j for newlines
+	f = typeText(f, "a")
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyCtrlJ})
+	f = typeText(f, "b")
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !f.done || f.cancelled {
+		t.Fatalf("expected form to be done, got done=%v cancelled=%v", f.done, f.cancelled)
+	}
+	want := map[string]string{"win": "shipped auth", "mood": "good", "notes": "a\nb"}
+	if !reflect.DeepEqual(f.answers, want) {
+		t.Errorf("got %v, want %v", f.answers, want)
+	}
+}
+
+func TestGuidedFormSkipAndCancel(t *testing.T) {
+	f := newGuidedForm("retro", guidedVars[1:], map[string]string{"notes": "preset"}, 60, presets["default-dark"])
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if f.answers["mood"] != "okay" {
+		t.Errorf("expected skip to use default, got %q", f.answers["mood"])
+	}
+	if got := f.area.Value(); got != "preset" {
+		t.Errorf("expected preset answer to prefill input, got %q", got)
+	}
+	f, _ = f.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if !f.done || !f.cancelled {
+		t.Error("expected ctrl+c to cancel")
+	}
+}
+
+func TestCreateWithGuidedTemplate(t *testing.T) {
+	store := &templateStorage{
+		mockStorage: &mockStorage{entries: map[string][]entry.Entry{}, byID: map[string]entry.Entry{}},
+		templates: map[string]string{
+			"plain": "# Notes",
+			"retro": "---\nvariables:\n  - name: win\n---\n## Win\n{{.win}}",
+		},
+	}
+	m := newTUIModel(store, TUIConfig{Editor: "vi"})
+	sized, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = sized.(pickerModel)
+
+	// Templates without variables go straight to the editor
+	if msg, ok := createWithTemplatesCallback(&m, []string{"plain"})().(openEditorForCreateMsg); !ok || msg.content != "# Notes" {
+		t.Fatalf("expected editor for plain template, got %#v", msg)
+	}
+
+	msg := createWithTemplatesCallback(&m, []string{"retro"})()
+	start, ok := msg.(startGuidedMsg)
+	if !ok {
+		t.Fatalf("expected startGuidedMsg, got %T", msg)
+	}
+	updated, _ := m.Update(start)
+	m = updated.(pickerModel)
+	if !m.guidedActive {
+		t.Fatal("expected guided form to be active")
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "win") {
+		t.Errorf("expected form in view, got:\n%s", view)
+	}
+
+	for _, r := range "fixed CI" {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(pickerModel)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(pickerModel)
+	if m.guidedActive || cmd == nil {
+		t.Fatal("expected form to complete with a command")
+	}
+	open, ok := cmd().(openEditorForCreateMsg)
+	if !ok {
+		t.Fatalf("expected openEditorForCreateMsg")
+	}
+	if open.content != "## Win\nfixed CI" {
+		t.Errorf("got content %q", open.content)
+	}
+	if len(open.refs) != 1 || open.refs[0].TemplateName != "retro" {
+		t.Errorf("expected template attribution, got %v", open.refs)
+	}
+}
+
+func TestTemplateCallbacksRenderFunctions(t *testing.T) {
+	store := &templateStorage{
+		mockStorage: &mockStorage{entries: map[string][]entry.Entry{}, byID: map[string]entry.Entry{}},
+		templates:   map[string]string{"weekly": "# Week {{.week}}"},
+	}
+	m := newTUIModel(store, TUIConfig{Editor: "vi", RenderOptions: func(vars map[string]string) template.RenderOptions {
+		return template.RenderOptions{Vars: map[string]string{"week": "11"}}
+	}})
+
+	if msg, ok := createWithTemplatesCallback(&m, []string{"weekly"})().(openEditorForCreateMsg); !ok || msg.content != "# Week 11" {
+		t.Errorf("expected rendered template on create, got %#v", msg)
+	}
+
+	m.templateTargetEntry = &entry.Entry{ID: "abc12345", Content: "notes"}
+	if msg, ok := appendTemplatesCallback(&m, []string{"weekly"})().(openEditorForEditMsg); !ok || msg.content != "notes\n\n# Week 11" {
+		t.Errorf("expected rendered template on append, got %#v", msg)
+	}
+
+	m.cfg.RenderOptions = func(map[string]string) template.RenderOptions { return template.RenderOptions{Strict: true} }
+	if msg, ok := createWithTemplatesCallback(&m, []string{"weekly"})().(editorFinishedMsg); !ok || msg.err == nil {
+		t.Errorf("expected strict render error, got %#v", msg)
+	}
+}
+
+func TestDefaultTemplateDoesNotCreateContexts(t *testing.T) {
+	dataDir := t.TempDir()
+	if err := dctx.SetManualContext(dataDir, "incident/42"); err != nil {
+		t.Fatal(err)
+	}
+	store := &mockStorage{entries: map[string][]entry.Entry{}, byID: map[string]entry.Entry{}}
+	m := newTUIModel(store, TUIConfig{
+		Editor:          "vi",
+		DataDir:         dataDir,
+		DefaultTemplate: "daily",
+		TemplateRules:   []template.Rule{{Template: "incident-log", Contexts: []string{"incident/*"}}},
+	})
+
+	if got := m.defaultTemplate(); got != "incident-log" {
+		t.Errorf("expected the context rule to match, got %q", got)
+	}
+	if len(store.contexts) != 0 {
+		t.Errorf("expected no contexts to be stored, got %+v", store.contexts)
+	}
+}
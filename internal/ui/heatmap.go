@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/chris-regnier/diaryctl/internal/day"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// heatmapGlyphs are the cells for activity levels 0 (no entries) to 4.
+var heatmapGlyphs = []string{"·", "░", "▒", "▓", "█"}
+
+// DayLister is the subset of storage needed to draw a heatmap.
+type DayLister interface {
+	ListDays(opts storage.ListDaysOptions) ([]storage.DaySummary, error)
+}
+
+// HeatmapCounts returns the number of entries per day ("2006-01-02") in year.
+func HeatmapCounts(store DayLister, year int) (map[string]int, error) {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	end := time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)
+	days, err := store.ListDays(storage.ListDaysOptions{StartDate: &start, EndDate: &end})
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int, len(days))
+	for _, d := range days {
+		counts[d.Date.Format("2006-01-02")] = d.Count
+	}
+	return counts, nil
+}
+
+// heatmapLevel scales count to a level from 0 to 4 relative to peak.
+func heatmapLevel(count, peak int) int {
+	if count <= 0 || peak <= 0 {
+		return 0
+	}
+	return min((count*4+peak-1)/peak, 4)
+}
+
+// RenderHeatmap draws year as a GitHub-style grid: one column per week
+// (Sunday first) and one row per weekday, shaded by the entries per day in
+// counts. A non-nil cursor highlights that day.
+func RenderHeatmap(theme Theme, year int, counts map[string]int, cursor *time.Time) string {
+	first := time.Date(year, time.January, 1, 0, 0, 0, 0, time.Local)
+	last := time.Date(year, time.December, 31, 0, 0, 0, 0, time.Local)
+	start := first.AddDate(0, 0, -int(first.Weekday()))
+	weeks := int(last.Sub(start).Hours()/24)/7 + 1
+
+	peak, total, active := 0, 0, 0
+	for _, n := range counts {
+		peak = max(peak, n)
+		total += n
+		active++
+	}
+
+	label := lipgloss.NewStyle().Foreground(theme.Muted).Background(theme.Background)
+	empty := lipgloss.NewStyle().Foreground(theme.Muted).Background(theme.Background)
+	filled := lipgloss.NewStyle().Foreground(theme.Accent).Background(theme.Background)
+	selected := lipgloss.NewStyle().Foreground(theme.Background).Background(theme.Accent)
+	blank := lipgloss.NewStyle().Background(theme.Background)
+
+	// Month labels above the first week of each month
+	months := []rune(strings.Repeat(" ", weeks+3))
+	for m := time.January; m <= time.December; m++ {
+		col := int(time.Date(year, m, 1, 0, 0, 0, 0, time.Local).Sub(start).Hours()/24) / 7
+		name := []rune(time.Month(m).String()[:3])
+		if col+len(name) <= len(months) && (col == 0 || months[col-1] == ' ') && months[col] == ' ' {
+			copy(months[col:], name)
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString(label.Render("    " + strings.TrimRight(string(months), " ")))
+	b.WriteString("\n")
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := "   "
+		if wd == time.Monday || wd == time.Wednesday || wd == time.Friday {
+			name = wd.String()[:3]
+		}
+		b.WriteString(label.Render(name + " "))
+		for w := 0; w < weeks; w++ {
+			d := start.AddDate(0, 0, w*7+int(wd))
+			if d.Year() != year {
+				b.WriteString(blank.Render(" "))
+				continue
+			}
+			level := heatmapLevel(counts[d.Format("2006-01-02")], peak)
+			style := filled
+			if level == 0 {
+				style = empty
+			}
+			if cursor != nil && sameDate(*cursor, d) {
+				style = selected
+			}
+			b.WriteString(style.Render(heatmapGlyphs[level]))
+		}
+		b.WriteString("\n")
+	}
+
+	legend := label.Render("    Less ")
+	for i, g := range heatmapGlyphs {
+		style := filled
+		if i == 0 {
+			style = empty
+		}
+		legend += style.Render(g) + blank.Render(" ")
+	}
+	b.WriteString(legend + label.Render("More"))
+	b.WriteString("\n")
+	dayLabel := "days"
+	if active == 1 {
+		dayLabel = "day"
+	}
+	b.WriteString(label.Render(fmt.Sprintf("    %d entries on %d %s in %d", total, active, dayLabel, year)))
+	return b.String()
+}
+
+func sameDate(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
+
+type heatmapLoadedMsg struct {
+	year   int
+	counts map[string]int
+	err    error
+}
+
+// loadHeatmapCmd loads the day counts for year.
+func (m pickerModel) loadHeatmapCmd(year int) tea.Cmd {
+	store := m.store
+	return func() tea.Msg {
+		counts, err := HeatmapCounts(store, year)
+		return heatmapLoadedMsg{year: year, counts: counts, err: err}
+	}
+}
+
+// openHeatmap shows the heatmap with the cursor on today.
+func (m pickerModel) openHeatmap() (tea.Model, tea.Cmd) {
+	if m.screen != screenHeatmap {
+		m.heatmapPrev = m.screen
+	}
+	m.heatmapCursor = day.Today()
+	m.heatmapCounts = nil
+	m.screen = screenHeatmap
+	return m, m.loadHeatmapCmd(m.heatmapCursor.Year())
+}
+
+func (m pickerModel) updateHeatmap(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var next time.Time
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "esc", "backspace":
+		m.screen = m.heatmapPrev
+		switch m.heatmapPrev {
+		case screenToday:
+			return m, m.loadTodayCmd
+		case screenDateList:
+			return m.loadDateList()
+		case screenEntryDetail:
+			return m.loadEntryDetail(m.entry.ID)
+		}
+		return m, nil
+	case "enter":
+		return m.openHeatmapDay()
+	case "left":
+		next = m.heatmapCursor.AddDate(0, 0, -7)
+	case "right":
+		next = m.heatmapCursor.AddDate(0, 0, 7)
+	case "up":
+		next = m.heatmapCursor.AddDate(0, 0, -1)
+	case "down":
+		next = m.heatmapCursor.AddDate(0, 0, 1)
+	case "p":
+		next = m.heatmapCursor.AddDate(-1, 0, 0)
+	case "n":
+		next = m.heatmapCursor.AddDate(1, 0, 0)
+	default:
+		return m, nil
+	}
+	return m.moveHeatmapCursor(next)
+}
+
+// moveHeatmapCursor moves the cursor to day, loading its year when it changes.
+func (m pickerModel) moveHeatmapCursor(day time.Time) (tea.Model, tea.Cmd) {
+	year := m.heatmapCursor.Year()
+	m.heatmapCursor = day
+	if day.Year() != year {
+		m.heatmapCounts = nil
+		return m, m.loadHeatmapCmd(day.Year())
+	}
+	return m, nil
+}
+
+// openHeatmapDay opens the day detail for the day under the cursor, if it
+// has entries. Going back from the day returns to the heatmap.
+func (m pickerModel) openHeatmapDay() (tea.Model, tea.Cmd) {
+	key := m.heatmapCursor.Format("2006-01-02")
+	if m.heatmapCounts[key] == 0 {
+		return m, nil
+	}
+	days, err := m.store.ListDays(storage.ListDaysOptions{})
+	if err != nil {
+		m.err = err
+		return m, tea.Quit
+	}
+	for i, d := range days {
+		if d.Date.Format("2006-01-02") == key {
+			m.days = days
+			m.dayIdx = i
+			m.heatmapDay = true
+			return m.loadDayDetail()
+		}
+	}
+	return m, nil
+}
+
+// closeHeatmapDay returns from a day opened on the heatmap, moving the
+// cursor to the day last shown.
+func (m pickerModel) closeHeatmapDay() (tea.Model, tea.Cmd) {
+	m.heatmapDay = false
+	m.screen = screenHeatmap
+	return m.moveHeatmapCursor(m.days[m.dayIdx].Date)
+}
+
+// viewHeatmap renders the heatmap screen.
+func (m pickerModel) viewHeatmap() string {
+	cw := m.contentWidth()
+	header := m.cfg.Theme.HeaderStyle().Width(cw).Render(fmt.Sprintf("Heatmap %d", m.heatmapCursor.Year()))
+	body := "Loading..."
+	if m.heatmapCounts != nil {
+		body = RenderHeatmap(m.cfg.Theme, m.heatmapCursor.Year(), m.heatmapCounts, &m.heatmapCursor)
+	}
+	n := m.heatmapCounts[m.heatmapCursor.Format("2006-01-02")]
+	label := "entries"
+	if n == 1 {
+		label = "entry"
+	}
+	selected := m.cfg.Theme.AccentStyle().Width(cw).Render(fmt.Sprintf("%s  %d %s",
+		m.heatmapCursor.Format("Mon 2006-01-02"), n, label))
+	footer := m.cfg.Theme.HelpStyle().Width(cw).Render("←/→ week • ↑/↓ day • p/n year • enter open day • esc back • q quit")
+	return header + "\n\n" + body + "\n\n" + selected + "\n" + footer
+}
@@ -0,0 +1,96 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func TestRenderHeatmap(t *testing.T) {
+	counts := map[string]int{"2026-01-01": 1, "2026-01-02": 4, "2026-12-31": 2}
+	cursor := time.Date(2026, 1, 2, 0, 0, 0, 0, time.Local)
+	out := stripANSI(RenderHeatmap(presets["default-dark"], 2026, counts, &cursor))
+	lines := strings.Split(out, "\n")
+	if len(lines) != 10 {
+		t.Fatalf("expected 10 lines, got %d:\n%s", len(lines), out)
+	}
+	if !strings.HasPrefix(lines[0], "    Jan") || !strings.Contains(lines[0], "Dec") {
+		t.Errorf("month labels = %q", lines[0])
+	}
+	// 2026-01-01 is a Thursday: the first week starts on Sunday 2025-12-28
+	if got := []rune(lines[5]); string(got[:5]) != "    ░" {
+		t.Errorf("Thursday row = %q", lines[5])
+	}
+	if got := []rune(lines[6]); string(got[:5]) != "Fri █" {
+		t.Errorf("Friday row = %q", lines[6])
+	}
+	if got := []rune(lines[1]); string(got[:5]) != "     " {
+		t.Errorf("Sunday row should start outside the year: %q", lines[1])
+	}
+	if !strings.HasSuffix(lines[9], "7 entries on 3 days in 2026") {
+		t.Errorf("summary = %q", lines[9])
+	}
+	for _, l := range lines[1:8] {
+		if n := len([]rune(l)); n != 4+53 {
+			t.Errorf("row width = %d, want 57: %q", n, l)
+		}
+	}
+}
+
+func TestHeatmapScreen(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	yesterday := today.AddDate(0, 0, -1)
+	e := entry.Entry{ID: "heat0001", Content: "yesterday's entry", CreatedAt: yesterday.Add(9 * time.Hour)}
+	mock := &mockStorage{
+		days:    []storage.DaySummary{{Date: yesterday, Count: 1, Preview: e.Content}},
+		entries: map[string][]entry.Entry{yesterday.Format("2006-01-02"): {e}},
+		byID:    map[string]entry.Entry{e.ID: e},
+	}
+	m := newTUIModel(mock, TUIConfig{Editor: "vi", Theme: presets["default-dark"]})
+	sized, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = sized.(pickerModel)
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'H'}})
+	m = runCmd(t, updated.(pickerModel), cmd)
+	if m.screen != screenHeatmap || !m.heatmapCursor.Equal(today) {
+		t.Fatalf("expected heatmap on today, got screen %d cursor %v", m.screen, m.heatmapCursor)
+	}
+
+	// Today has no entries: enter stays on the heatmap
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(pickerModel)
+	if m.screen != screenHeatmap {
+		t.Fatalf("expected to stay on heatmap, got screen %d", m.screen)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m = runCmd(t, updated.(pickerModel), cmd)
+	if !m.heatmapCursor.Equal(yesterday) {
+		t.Fatalf("cursor = %v, want %v", m.heatmapCursor, yesterday)
+	}
+	if !strings.Contains(stripANSI(m.View()), yesterday.Format("Mon 2006-01-02")+"  1 entry") {
+		t.Errorf("expected selected day in view:\n%s", stripANSI(m.View()))
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(pickerModel)
+	if m.screen != screenDayDetail || len(m.dayList.Items()) != 1 {
+		t.Fatalf("expected day detail with 1 entry, got screen %d", m.screen)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(pickerModel)
+	if m.screen != screenHeatmap {
+		t.Errorf("expected esc to return to heatmap, got screen %d", m.screen)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(pickerModel)
+	if m.screen != screenToday {
+		t.Errorf("expected esc to return to today, got screen %d", m.screen)
+	}
+}
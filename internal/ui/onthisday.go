@@ -0,0 +1,71 @@
+package ui
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/chris-regnier/diaryctl/internal/daily"
+)
+
+// maxMemoryLines is the number of memories shown in the today screen panel.
+const maxMemoryLines = 3
+
+// FormatMemories formats "on this day" memories as plain text, grouped by day.
+func FormatMemories(w io.Writer, memories []daily.Memory) {
+	if len(memories) == 0 {
+		fmt.Fprintln(w, "No memories for this day yet.")
+		return
+	}
+	for i, m := range memories {
+		label := "entries"
+		if len(m.Entries) == 1 {
+			label = "entry"
+		}
+		fmt.Fprintf(w, "── %s · %s (%d %s) ──────────\n",
+			m.Label, m.Date.Format("Mon 2006-01-02"), len(m.Entries), label)
+		for _, e := range m.Entries {
+			fmt.Fprintf(w, "  %s  %s  %s\n",
+				e.ID,
+				e.CreatedAt.Local().Format("15:04"),
+				e.Preview(80),
+			)
+		}
+		if i < len(memories)-1 {
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// memoriesPanelHeight is the number of lines viewMemories takes.
+func (m pickerModel) memoriesPanelHeight() int {
+	if len(m.memories) == 0 {
+		return 0
+	}
+	return min(len(m.memories), maxMemoryLines) + 1
+}
+
+// viewMemories renders the "On this day" panel of the today screen: one line
+// per memory with the preview of its latest entry.
+func (m pickerModel) viewMemories(width int) string {
+	if len(m.memories) == 0 {
+		return ""
+	}
+	title := "On this day"
+	if extra := len(m.memories) - maxMemoryLines; extra > 0 {
+		title += fmt.Sprintf(" (+%d more: diaryctl onthisday)", extra)
+	}
+	lines := []string{m.cfg.Theme.AccentStyle().Width(width).Render(title)}
+	for _, mem := range m.memories[:min(len(m.memories), maxMemoryLines)] {
+		line := fmt.Sprintf("  %-12s %s  %s", mem.Label, mem.Date.Format("2006-01-02"),
+			mem.Entries[0].Preview(width))
+		if n := len(mem.Entries); n > 1 {
+			line += fmt.Sprintf(" (+%d)", n-1)
+		}
+		if len([]rune(line)) > width && width > 3 {
+			line = string([]rune(line)[:width-3]) + "..."
+		}
+		lines = append(lines, m.cfg.Theme.HelpStyle().Width(width).Render(line))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,68 @@
+package ui
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+func TestFormatMemories(t *testing.T) {
+	var buf bytes.Buffer
+	FormatMemories(&buf, nil)
+	if buf.String() != "No memories for this day yet.\n" {
+		t.Errorf("empty = %q", buf.String())
+	}
+
+	day := time.Date(2025, 3, 5, 0, 0, 0, 0, time.Local)
+	buf.Reset()
+	FormatMemories(&buf, []daily.Memory{{Label: "1 year ago", Date: day, Entries: []entry.Entry{
+		{ID: "mem00001", Content: "first spring walk", CreatedAt: day.Add(9 * time.Hour)},
+	}}})
+	want := "── 1 year ago · Wed 2025-03-05 (1 entry) ──────────\n  mem00001  09:00  first spring walk\n"
+	if buf.String() != want {
+		t.Errorf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestTodayScreenMemories(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	lastYear := today.AddDate(-1, 0, 0)
+	weekAgo := today.AddDate(0, 0, -7)
+	old := entry.Entry{ID: "mem00001", Content: "a year ago today", CreatedAt: lastYear.Add(9 * time.Hour)}
+	recent := entry.Entry{ID: "mem00002", Content: "last week's note", CreatedAt: weekAgo.Add(9 * time.Hour)}
+	mock := &mockStorage{
+		days: []storage.DaySummary{{Date: weekAgo, Count: 1}, {Date: lastYear, Count: 1}},
+		entries: map[string][]entry.Entry{
+			lastYear.Format("2006-01-02"): {old},
+			weekAgo.Format("2006-01-02"):  {recent},
+		},
+		byID: map[string]entry.Entry{old.ID: old, recent.ID: recent},
+	}
+	m := newTUIModel(mock, TUIConfig{Editor: "vi", Theme: presets["default-dark"]})
+	sized, _ := m.Update(tea.WindowSizeMsg{Width: testWidth, Height: testHeight})
+	m = sized.(pickerModel)
+	updated, _ := m.Update(m.loadTodayCmd())
+	m = updated.(pickerModel)
+
+	if len(m.memories) != 2 {
+		t.Fatalf("memories = %+v", m.memories)
+	}
+	output := m.View()
+	plain := stripANSI(output)
+	for _, want := range []string{"On this day", "1 week ago", "last week's note", "1 year ago", "a year ago today"} {
+		if !strings.Contains(plain, want) {
+			t.Errorf("today screen missing %q:\n%s", want, plain)
+		}
+	}
+	if strings.Index(plain, "1 week ago") > strings.Index(plain, "1 year ago") {
+		t.Errorf("memories should be most recent first:\n%s", plain)
+	}
+	assertViewFillsScreen(t, output, testWidth, testHeight)
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	dctx "github.com/chris-regnier/diaryctl/internal/context"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 )
@@ -89,6 +90,9 @@ func FormatTemplateList(w io.Writer, templates []storage.Template) {
 func FormatTemplateFull(w io.Writer, t storage.Template) {
 	fmt.Fprintf(w, "Template: %s\n", t.Name)
 	fmt.Fprintf(w, "ID: %s\n", t.ID)
+	if t.Version > 0 {
+		fmt.Fprintf(w, "Version: %d\n", t.Version)
+	}
 	fmt.Fprintf(w, "Created: %s\n", t.CreatedAt.Local().Format("2006-01-02 15:04"))
 	fmt.Fprintf(w, "Modified: %s\n", t.UpdatedAt.Local().Format("2006-01-02 15:04"))
 	fmt.Fprintln(w)
@@ -186,19 +190,46 @@ func FormatContextFull(w io.Writer, c storage.Context) {
 }
 
 // FormatActiveContexts formats the currently active contexts.
-func FormatActiveContexts(w io.Writer, manual []string, auto []string) {
+// Time-boxed manual contexts show the time remaining before they expire.
+func FormatActiveContexts(w io.Writer, manual []dctx.ManualContext, auto []string) {
 	if len(manual) == 0 && len(auto) == 0 {
 		fmt.Fprintln(w, "No active contexts.")
 		return
 	}
 	if len(manual) > 0 {
-		fmt.Fprintf(w, "manual:  %s\n", strings.Join(manual, ", "))
+		now := time.Now()
+		names := make([]string, len(manual))
+		for i, c := range manual {
+			names[i] = c.Name
+			if c.ExpiresAt != nil {
+				names[i] += fmt.Sprintf(" (%s left)", FormatRemaining(c.Remaining(now)))
+			}
+		}
+		fmt.Fprintf(w, "manual:  %s\n", strings.Join(names, ", "))
 	}
 	if len(auto) > 0 {
 		fmt.Fprintf(w, "auto:    %s\n", strings.Join(auto, ", "))
 	}
 }
 
+// FormatRemaining renders a duration compactly, e.g. "2d4h", "3h12m", "<1m".
+func FormatRemaining(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	mins := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd%dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh%dm", hours, mins)
+	default:
+		return fmt.Sprintf("%dm", mins)
+	}
+}
+
 // FormatDailySummary formats grouped-by-day entries as plain text.
 func FormatDailySummary(w io.Writer, days []DayEntries) {
 	if len(days) == 0 {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"slices"
 	"strings"
 	"time"
 
@@ -14,6 +15,8 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	dctx "github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/daily"
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/editor"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
@@ -29,6 +32,8 @@ const (
 	screenDayDetail
 	screenEntryDetail
 	screenContextPanel
+	screenRecall
+	screenHeatmap
 )
 
 // Focus states for today screen
@@ -127,7 +132,7 @@ func (t templateItem) Title() string {
 }
 
 func (t templateItem) Description() string {
-	lines := strings.SplitN(t.tmpl.Content, "\n", 2)
+	lines := strings.SplitN(template.Body(t.tmpl.Content), "\n", 2)
 	preview := lines[0]
 	if len(preview) > 60 {
 		preview = preview[:57] + "..."
@@ -152,6 +157,7 @@ type pickerModel struct {
 	todayList     list.Model     // list for other today entries
 	dailyViewport viewport.Model // viewport for daily entry content
 	todayFocus    int            // 0=daily viewport, 1=entry list
+	memories      []daily.Memory // "on this day" entries from past days
 	// Browse screens (existing)
 	days     []storage.DaySummary
 	dayIdx   int
@@ -183,6 +189,31 @@ type pickerModel struct {
 	templateItems        []storage.Template // cached templates
 	templateCallback     templateCallbackFunc
 	templateTargetEntry  *entry.Entry // entry being edited with template append
+	templatePreselect    []string     // names selected when the picker opens
+	// Guided capture
+	guidedActive  bool
+	guided        guidedForm
+	guidedContent string // resolved template content awaiting answers
+	guidedRefs    []entry.TemplateRef
+	// Recall
+	recallActive bool
+	recallInput  textinput.Model
+	recallQuery  string
+	recallList   list.Model
+	recallPrev   pickerScreen          // screen to return to from results
+	enrichment   *dctx.EnrichedContent // suggestions for the entry in detail view
+	// Pending enrichment suggestions for the entry in detail view
+	pending *dctx.PendingSuggestion
+	// Heatmap
+	heatmapCursor time.Time
+	heatmapCounts map[string]int // entries per day in the cursor's year
+	heatmapPrev   pickerScreen   // screen to return to from the heatmap
+	heatmapDay    bool           // day detail was opened from the heatmap
+	// Go to date
+	gotoActive bool
+	gotoInput  textinput.Model
+	gotoErr    string // why the last input didn't open anything
+	enrichErr  string // why enriching the last write failed
 	// Common
 	width  int
 	height int
@@ -224,6 +255,7 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, tea.Quit
 		}
+		m.enrichErr = msg.enrichErr
 		// Refresh current screen
 		switch m.screen {
 		case screenToday:
@@ -245,6 +277,7 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, tea.Quit
 		}
+		m.enrichErr = msg.enrichErr
 		// Refresh current screen
 		switch m.screen {
 		case screenToday:
@@ -325,10 +358,14 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.templateItems = msg.templates
 		m.templateSelected = make(map[string]bool)
+		for _, name := range m.templatePreselect {
+			m.templateSelected[name] = true
+		}
+		m.templatePreselect = nil
 
 		items := make([]list.Item, len(msg.templates))
 		for i, t := range msg.templates {
-			items[i] = templateItem{tmpl: t, selected: false}
+			items[i] = templateItem{tmpl: t, selected: m.templateSelected[t.Name]}
 		}
 
 		m.templateList = m.cfg.Theme.NewList(items, m.contentWidth()-4, m.height/2)
@@ -340,6 +377,13 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case openEditorForCreateMsg:
 		return m.doCreateWithEditor(msg.content, msg.refs)
 
+	case startGuidedMsg:
+		m.guided = newGuidedForm(msg.title, msg.vars, nil, m.contentWidth()-8, m.cfg.Theme)
+		m.guidedContent = msg.content
+		m.guidedRefs = msg.refs
+		m.guidedActive = true
+		return m, textinput.Blink
+
 	case openEditorForEditMsg:
 		return m.doEditWithEditor(msg.entryID, msg.content, msg.refs)
 
@@ -350,6 +394,7 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.dailyEntry = msg.daily
 		m.todayEntries = msg.entries
+		m.memories = msg.memories
 		// Build today list
 		items := make([]list.Item, len(msg.entries))
 		for i, e := range msg.entries {
@@ -389,6 +434,24 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.viewport.Width = m.contentWidth()
 			m.viewport.Height = msg.Height - headerHeight - footerHeight
 			m.viewport.SetContent(m.formatEntry())
+		case screenRecall:
+			m.recallList.SetSize(m.contentWidth(), msg.Height-footerHeight)
+		}
+		return m, nil
+
+	case recallLoadedMsg:
+		return m.showRecallResults(msg)
+
+	case entryEnrichedMsg:
+		return m.applyEnrichment(msg)
+
+	case heatmapLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		if msg.year == m.heatmapCursor.Year() {
+			m.heatmapCounts = msg.counts
 		}
 		return m, nil
 
@@ -409,6 +472,11 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateTemplatePicker(msg)
 		}
 
+		// Guided capture form — intercept all keys
+		if m.guidedActive {
+			return m.updateGuided(msg)
+		}
+
 		// Jot input mode — intercept all keys
 		if m.jotActive {
 			return m.updateJotInput(msg)
@@ -419,6 +487,16 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateDeleteConfirm(msg)
 		}
 
+		// Recall query input — intercept all keys
+		if m.recallActive {
+			return m.updateRecallInput(msg)
+		}
+
+		// Go-to-date input — intercept all keys
+		if m.gotoActive {
+			return m.updateGotoInput(msg)
+		}
+
 		// Global keys (work from any screen when not in input mode)
 		switch msg.String() {
 		case "j":
@@ -430,6 +508,14 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "x":
 			return m.openContextPanel()
+		case "r":
+			if m.cfg.Recall != nil {
+				return m.startRecall()
+			}
+		case "H":
+			return m.openHeatmap()
+		case "g":
+			return m.startGoto()
 		}
 
 		// Screen-specific handling
@@ -444,6 +530,10 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.updateEntryDetail(msg)
 		case screenContextPanel:
 			return m.updateContextPanel(msg)
+		case screenRecall:
+			return m.updateRecall(msg)
+		case screenHeatmap:
+			return m.updateHeatmap(msg)
 		}
 	}
 
@@ -456,6 +546,8 @@ func (m pickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.dayList, cmd = m.dayList.Update(msg)
 	case screenEntryDetail:
 		m.viewport, cmd = m.viewport.Update(msg)
+	case screenRecall:
+		m.recallList, cmd = m.recallList.Update(msg)
 	}
 	return m, cmd
 }
@@ -542,6 +634,9 @@ func (m pickerModel) updateDayDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "q", "ctrl+c":
 		return m, tea.Quit
 	case "esc", "backspace":
+		if m.heatmapDay {
+			return m.closeHeatmapDay()
+		}
 		m.screen = screenDateList
 		if m.ready {
 			m.dateList.SetSize(m.contentWidth(), m.height-2)
@@ -614,6 +709,14 @@ func (m pickerModel) updateEntryDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "t", "T":
 		m.templateTargetEntry = &m.entry
 		return m.openTemplatePicker(appendTemplatesCallback)
+	case "a":
+		if m.pending != nil {
+			return m.acceptPending()
+		}
+	case "s":
+		if m.pending != nil {
+			return m.dismissPending()
+		}
 	}
 
 	var cmd tea.Cmd
@@ -657,6 +760,8 @@ func (m pickerModel) loadEntryDetail(id string) (tea.Model, tea.Cmd) {
 	}
 
 	m.entry = e
+	m.enrichment = nil
+	m.pending = m.loadPending(e.ID)
 	m.prevScreen = m.screen
 	headerHeight := 4
 	footerHeight := 2
@@ -664,7 +769,7 @@ func (m pickerModel) loadEntryDetail(id string) (tea.Model, tea.Cmd) {
 	m.viewport = viewport.New(m.contentWidth(), vpHeight)
 	m.viewport.SetContent(m.formatEntry())
 	m.screen = screenEntryDetail
-	return m, nil
+	return m, m.enrichCmd(e)
 }
 
 func (m pickerModel) formatEntry() string {
@@ -684,6 +789,8 @@ func (m pickerModel) formatEntry() string {
 	// Render markdown content as rich text
 	rendered := RenderMarkdownWithStyle(m.entry.Content, m.viewport.Width, m.cfg.Theme.MarkdownStyle)
 	fmt.Fprintln(&b, rendered)
+	b.WriteString(m.formatPending())
+	b.WriteString(m.formatEnrichment())
 	return b.String()
 }
 
@@ -699,7 +806,7 @@ func (m *pickerModel) layoutToday() {
 	}
 
 	headerHeight := 2 // header + blank line
-	footerHeight := 2 // help + blank line
+	footerHeight := 2 + m.memoriesPanelHeight() // help + blank line + on this day
 
 	if m.dailyEntry != nil {
 		vpHeight := m.dailyViewportHeight()
@@ -726,17 +833,20 @@ func (m *pickerModel) contentWidth() int {
 
 
 type todayLoadedMsg struct {
-	daily   *entry.Entry
-	entries []entry.Entry
-	err     error
+	daily    *entry.Entry
+	entries  []entry.Entry
+	memories []daily.Memory
+	err      error
 }
 
 type jotCompleteMsg struct {
-	err error
+	err       error
+	enrichErr string
 }
 
 type editorFinishedMsg struct {
-	err error
+	err       error
+	enrichErr string
 }
 
 type deleteCompleteMsg struct {
@@ -763,6 +873,15 @@ type openEditorForCreateMsg struct {
 	refs    []entry.TemplateRef
 }
 
+// startGuidedMsg opens the guided capture form for templates that declare
+// variables.
+type startGuidedMsg struct {
+	title   string
+	vars    []template.Variable
+	content string
+	refs    []entry.TemplateRef
+}
+
 type openEditorForEditMsg struct {
 	entryID string
 	content string
@@ -770,22 +889,25 @@ type openEditorForEditMsg struct {
 }
 
 func (m pickerModel) loadTodayCmd() tea.Msg {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	today := day.Today()
 	entries, err := m.store.List(storage.ListOptions{Date: &today})
 	if err != nil {
 		return todayLoadedMsg{err: err}
 	}
+	memories, err := daily.OnThisDay(m.store, today)
+	if err != nil {
+		return todayLoadedMsg{err: err}
+	}
 	if len(entries) == 0 {
-		return todayLoadedMsg{}
+		return todayLoadedMsg{memories: memories}
 	}
 	// First entry is the daily entry (oldest, shown inline)
-	daily := entries[len(entries)-1] // oldest (list is newest-first)
+	dailyEntry := entries[len(entries)-1] // oldest (list is newest-first)
 	var others []entry.Entry
 	if len(entries) > 1 {
 		others = entries[:len(entries)-1]
 	}
-	return todayLoadedMsg{daily: &daily, entries: others}
+	return todayLoadedMsg{daily: &dailyEntry, entries: others, memories: memories}
 }
 
 func (m pickerModel) loadDateList() (tea.Model, tea.Cmd) {
@@ -794,19 +916,7 @@ func (m pickerModel) loadDateList() (tea.Model, tea.Cmd) {
 		m.err = err
 		return m, tea.Quit
 	}
-	m.days = days
-	items := make([]list.Item, len(days))
-	for i, d := range days {
-		items[i] = dateItem{summary: d}
-	}
-	m.dateList = m.cfg.Theme.NewList(items, 0, 0)
-	m.dateList.Title = "Daily View"
-	m.dateList.SetShowHelp(false)
-	if m.ready {
-		m.dateList.SetSize(m.contentWidth(), m.height-2)
-	}
-	m.screen = screenDateList
-	return m, nil
+	return m.showDateList(days, "Daily View")
 }
 
 func (m pickerModel) View() string {
@@ -821,6 +931,12 @@ func (m pickerModel) View() string {
 	if m.helpActive {
 		return m.cfg.Theme.ClearLineEnds(m.helpOverlay())
 	}
+	if m.guidedActive {
+		form := m.cfg.Theme.BorderStyle().Padding(1, 2).Render(m.guided.View())
+		placed := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, form,
+			lipgloss.WithWhitespaceBackground(m.cfg.Theme.Background))
+		return m.cfg.Theme.ClearLineEnds(placed)
+	}
 	if m.templatePickerActive {
 		picker := m.templatePickerView()
 		placed := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, picker,
@@ -836,11 +952,14 @@ func (m pickerModel) View() string {
 		if m.dailyEntry == nil && len(m.todayEntries) == 0 {
 			// Empty state
 			header := m.cfg.Theme.HeaderStyle().Width(cw).Render(
-				fmt.Sprintf("Today — %s", time.Now().Format("2006-01-02")))
+				fmt.Sprintf("Today — %s", day.Today().Format("2006-01-02")))
 			empty := m.cfg.Theme.ViewPaneStyle().Width(cw).Render(
 				"Nothing yet today.\n\n  j  jot a quick note\n  c  create a new entry")
 			footer := m.cfg.Theme.HelpStyle().Width(cw).Render("j jot  c create  b browse  x ctx  ? help")
 			result = header + "\n" + empty + "\n" + footer
+			if memories := m.viewMemories(cw); memories != "" {
+				result = header + "\n" + empty + "\n" + memories + "\n" + footer
+			}
 		} else {
 			var sections []string
 
@@ -854,7 +973,7 @@ func (m pickerModel) View() string {
 				label = "entry"
 			}
 			header := m.cfg.Theme.HeaderStyle().Width(cw).Render(
-				fmt.Sprintf("Today — %s    %d %s", time.Now().Format("2006-01-02"), count, label))
+				fmt.Sprintf("Today — %s    %d %s", day.Today().Format("2006-01-02"), count, label))
 			sections = append(sections, header)
 
 			// Daily entry viewport
@@ -868,6 +987,11 @@ func (m pickerModel) View() string {
 				sections = append(sections, m.todayList.View())
 			}
 
+			// On this day
+			if memories := m.viewMemories(cw); memories != "" {
+				sections = append(sections, memories)
+			}
+
 			// Footer
 			footer := m.cfg.Theme.HelpStyle().Width(cw).Render("j jot  c create  e edit  b browse  x ctx  ? help")
 			sections = append(sections, footer)
@@ -885,7 +1009,11 @@ func (m pickerModel) View() string {
 		meta := m.cfg.Theme.HelpStyle().Width(cw).Render(fmt.Sprintf("Created: %s  Modified: %s",
 			m.entry.CreatedAt.Local().Format("2006-01-02 15:04"),
 			m.entry.UpdatedAt.Local().Format("2006-01-02 15:04")))
-		footer := m.cfg.Theme.HelpStyle().Width(cw).Render("↑/↓ scroll • esc back • q quit")
+		hint := "↑/↓ scroll • esc back • q quit"
+		if m.pending != nil {
+			hint = "a accept suggestions • s dismiss • " + hint
+		}
+		footer := m.cfg.Theme.HelpStyle().Width(cw).Render(hint)
 		paneStyle := m.cfg.Theme.ViewPaneStyle().Width(cw)
 		result = header + "\n" + meta + "\n\n" + paneStyle.Render(m.viewport.View()) + "\n" + footer
 	case screenContextPanel:
@@ -901,6 +1029,11 @@ func (m pickerModel) View() string {
 			b.WriteString("\n" + m.cfg.Theme.HelpStyle().Width(cw).Render(hint))
 		}
 		result = b.String()
+	case screenRecall:
+		footer := m.cfg.Theme.HelpStyle().Width(cw).Render("↑/↓ navigate • enter open • r new search • esc back • q quit")
+		result = m.recallList.View() + "\n" + footer
+	case screenHeatmap:
+		result = m.viewHeatmap()
 	}
 
 	if m.deleteActive {
@@ -909,6 +1042,15 @@ func (m pickerModel) View() string {
 	} else if m.jotActive {
 		label := m.cfg.Theme.HelpStyle().Width(cw).Render(m.jotTargetLabel())
 		result = result + "\n" + label + "\n" + m.jotInput.View()
+	} else if m.recallActive {
+		result = result + "\n" + m.recallInput.View()
+	} else if m.gotoActive {
+		result = result + "\n" + m.gotoInput.View()
+		if m.gotoErr != "" {
+			result = result + "\n" + m.cfg.Theme.DangerStyle().Width(cw).Render(m.gotoErr)
+		}
+	} else if m.enrichErr != "" {
+		result = result + "\n" + m.cfg.Theme.DangerStyle().Width(cw).Render(m.enrichErr)
 	}
 
 	return m.cfg.Theme.PaintScreen(result, m.width, m.height, cw)
@@ -922,7 +1064,7 @@ func (m pickerModel) helpOverlay() string {
   ↑/↓        navigate / scroll
   enter      select / edit daily entry
   esc        go back
-  b          browse date list
+  b / H / g  dates / heatmap / go to date
   ←/→ p/n    prev / next day
   tab        switch focus (today)
 
@@ -933,7 +1075,7 @@ Actions
   t          append template to entry
   d          delete selected entry
   /          search / filter
-  x          context panel
+  x / r      context panel / recall entries
 
   q          quit     ? close help`)
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, help,
@@ -1029,7 +1171,7 @@ func (m pickerModel) updateDeleteConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if err := m.store.Delete(id); err != nil {
 				return deleteCompleteMsg{err: err}
 			}
-			return deleteCompleteMsg{}
+			return deleteCompleteMsg{err: m.dropPending(id)}
 		}
 	case "n", "esc":
 		m.deleteActive = false
@@ -1282,12 +1424,54 @@ func createWithTemplatesCallback(m *pickerModel, names []string) tea.Cmd {
 			}
 			content = c
 			refs = r
+
+			vars, err := template.Variables(m.store, names)
+			if err != nil {
+				return editorFinishedMsg{err: err}
+			}
+			if len(vars) > 0 {
+				return startGuidedMsg{title: strings.Join(names, " + "), vars: vars, content: content, refs: refs}
+			}
+			if content, err = template.RenderWith(content, m.renderOptions(nil)); err != nil {
+				return editorFinishedMsg{err: err}
+			}
 		}
 
 		return openEditorForCreateMsg{content: content, refs: refs}
 	}
 }
 
+// updateGuided routes keys to the guided form. When the form completes, the
+// template is rendered with the answers and opened in the editor.
+func (m pickerModel) updateGuided(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	m.guided, cmd = m.guided.Update(msg)
+	if !m.guided.done {
+		return m, cmd
+	}
+	m.guidedActive = false
+	if m.guided.cancelled {
+		return m, nil
+	}
+	content, refs, answers := m.guidedContent, m.guidedRefs, m.guided.answers
+	opts := m.renderOptions(answers)
+	return m, func() tea.Msg {
+		rendered, err := template.RenderWith(content, opts)
+		if err != nil {
+			return editorFinishedMsg{err: err}
+		}
+		return openEditorForCreateMsg{content: rendered, refs: refs}
+	}
+}
+
+// renderOptions returns the options for rendering templates with vars.
+func (m pickerModel) renderOptions(vars map[string]string) template.RenderOptions {
+	if m.cfg.RenderOptions == nil {
+		return template.RenderOptions{Vars: vars}
+	}
+	return m.cfg.RenderOptions(vars)
+}
+
 // appendTemplatesCallback is called after template selection for append action.
 func appendTemplatesCallback(m *pickerModel, names []string) tea.Cmd {
 	if len(names) == 0 || m.templateTargetEntry == nil {
@@ -1299,6 +1483,9 @@ func appendTemplatesCallback(m *pickerModel, names []string) tea.Cmd {
 		if err != nil {
 			return editorFinishedMsg{err: err}
 		}
+		if c, err = template.RenderWith(c, m.renderOptions(nil)); err != nil {
+			return editorFinishedMsg{err: err}
+		}
 
 		// Append to existing content
 		newContent := m.templateTargetEntry.Content
@@ -1329,7 +1516,7 @@ func appendTemplatesCallback(m *pickerModel, names []string) tea.Cmd {
 
 // resolveContexts resolves active contexts for the TUI using config.
 func (m pickerModel) resolveContexts() []entry.ContextRef {
-	resolvers := buildTUIContextResolvers(m.cfg.ContextResolvers)
+	resolvers := buildTUIContextResolvers(m.cfg.ContextResolvers, m.cfg.ProviderConfig)
 	manual, err := dctx.LoadManualContexts(m.cfg.DataDir)
 	if err != nil {
 		manual = nil
@@ -1338,11 +1525,29 @@ func (m pickerModel) resolveContexts() []entry.ContextRef {
 	return refs
 }
 
+// activeContextNames returns the names of the active manual and resolved
+// contexts without creating them in storage.
+func (m pickerModel) activeContextNames() []string {
+	names, _ := dctx.LoadManualContexts(m.cfg.DataDir)
+	for _, r := range buildTUIContextResolvers(m.cfg.ContextResolvers, m.cfg.ProviderConfig) {
+		resolved, err := r.Resolve()
+		if err != nil {
+			continue
+		}
+		for _, n := range resolved {
+			if n != "" && !slices.Contains(names, n) {
+				names = append(names, n)
+			}
+		}
+	}
+	return names
+}
+
 // buildTUIContentProviders creates ContentProviders from config names.
-func buildTUIContentProviders(names []string) []dctx.ContentProvider {
+func buildTUIContentProviders(names []string, cfg dctx.ProviderConfig) []dctx.ContentProvider {
 	var providers []dctx.ContentProvider
 	for _, name := range names {
-		p := dctx.LookupContentProvider(name)
+		p := dctx.LookupContentProviderWithConfig(name, cfg)
 		if p != nil {
 			providers = append(providers, p)
 		}
@@ -1351,10 +1556,10 @@ func buildTUIContentProviders(names []string) []dctx.ContentProvider {
 }
 
 // buildTUIContextResolvers creates ContextResolvers from config names.
-func buildTUIContextResolvers(names []string) []dctx.ContextResolver {
+func buildTUIContextResolvers(names []string, cfg dctx.ProviderConfig) []dctx.ContextResolver {
 	var resolvers []dctx.ContextResolver
 	for _, name := range names {
-		r := dctx.LookupContextResolver(name)
+		r := dctx.LookupContextResolverWithConfig(name, cfg)
 		if r != nil {
 			resolvers = append(resolvers, r)
 		}
@@ -1376,7 +1581,7 @@ func (m pickerModel) doCreateWithEditor(initialContent string, refs []entry.Temp
 	}
 	tmpName := tmpFile.Name()
 
-	providers := buildTUIContentProviders(m.cfg.ContextProviders)
+	providers := buildTUIContentProviders(m.cfg.ContextProviders, m.cfg.ProviderConfig)
 	composedContent := dctx.ComposeContent(providers, initialContent)
 
 	if composedContent != "" {
@@ -1431,7 +1636,7 @@ func (m pickerModel) doCreateWithEditor(initialContent string, refs []entry.Temp
 		for _, ref := range contextRefs {
 			_ = m.store.AttachContext(e.ID, ref.ContextID)
 		}
-		return editorFinishedMsg{}
+		return editorFinishedMsg{enrichErr: m.enrich(e.ID, e.Content)}
 	})
 }
 
@@ -1513,6 +1718,7 @@ func (m pickerModel) doJot(content string) tea.Msg {
 		for _, ref := range contextRefs {
 			_ = m.store.AttachContext(m.jotTarget.ID, ref.ContextID)
 		}
+		return jotCompleteMsg{enrichErr: m.enrich(target.ID, content)}
 	} else {
 		// No target — create new daily entry (screenToday with no entries)
 		id, err := entry.NewID()
@@ -1523,8 +1729,8 @@ func (m pickerModel) doJot(content string) tea.Msg {
 
 		// Use default template if configured
 		var templateRefs []entry.TemplateRef
-		if m.cfg.DefaultTemplate != "" {
-			names := template.ParseNames(m.cfg.DefaultTemplate)
+		if defaultTemplate := m.defaultTemplate(); defaultTemplate != "" {
+			names := template.ParseNames(defaultTemplate)
 			_, refs, err := template.Compose(m.store, names)
 			if err != nil {
 				// Continue without template refs (match CLI behavior)
@@ -1547,16 +1753,26 @@ func (m pickerModel) doJot(content string) tea.Msg {
 		for _, ref := range contextRefs {
 			_ = m.store.AttachContext(e.ID, ref.ContextID)
 		}
+		return jotCompleteMsg{enrichErr: m.enrich(e.ID, content)}
 	}
-
-	return jotCompleteMsg{}
 }
 
 func (m pickerModel) startCreate() (tea.Model, tea.Cmd) {
-	// Open template picker for create flow
+	// Open template picker for create flow, with the default template selected
+	m.templatePreselect = template.ParseNames(m.defaultTemplate())
 	return m.openTemplatePicker(createWithTemplatesCallback)
 }
 
+// defaultTemplate returns the default template for new entries, chosen by the
+// template rules from the current time and active contexts.
+func (m pickerModel) defaultTemplate() string {
+	if len(m.cfg.TemplateRules) == 0 {
+		return m.cfg.DefaultTemplate
+	}
+	sel, _ := template.Select(m.cfg.TemplateRules, m.cfg.DefaultTemplate, time.Now(), m.activeContextNames())
+	return sel.Template
+}
+
 func (m pickerModel) startEdit(e entry.Entry) (tea.Model, tea.Cmd) {
 	editorCmd := editor.ResolveEditor(m.cfg.Editor)
 	parts := strings.Fields(editorCmd)
@@ -1613,11 +1829,22 @@ func (m pickerModel) startEdit(e entry.Entry) (tea.Model, tea.Cmd) {
 type TUIConfig struct {
 	Editor           string   // resolved editor command
 	DefaultTemplate  string   // default template name
+	TemplateRules    []template.Rule // rules choosing the default template (first match wins)
 	MaxWidth         int      // maximum viewport width (0 = no limit)
 	Theme            Theme    // resolved theme
 	ContextProviders []string // content provider names from config
 	ContextResolvers []string // context resolver names from config
 	DataDir          string   // data directory for manual contexts state
+	ProviderConfig   dctx.ProviderConfig // settings for configurable content providers
+	// RenderOptions builds template render options for guided capture answers
+	// (nil = answers only).
+	RenderOptions func(vars map[string]string) template.RenderOptions
+	// Recall searches past entries and enriches the entry in detail view
+	// (nil = recall disabled).
+	Recall dctx.Provider
+	// Enrich saves enrichment suggestions for a newly created entry or jot
+	// (nil = enrichment disabled). Failures are shown but never undo the write.
+	Enrich func(entryID string, content string) error
 }
 
 // newTUIModel creates a new TUI model starting at the today screen.
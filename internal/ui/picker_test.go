@@ -9,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/day"
 	"github.com/chris-regnier/diaryctl/internal/entry"
 	"github.com/chris-regnier/diaryctl/internal/storage"
 )
@@ -2127,3 +2128,33 @@ func TestFormatEntryNoContextsOmitsLine(t *testing.T) {
 		t.Error("should not show 'Contexts:' when no contexts are attached")
 	}
 }
+
+func TestTodayScreen_DayBoundary(t *testing.T) {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if now.Sub(midnight) > 23*time.Hour {
+		t.Skip("too close to midnight")
+	}
+	// Move the boundary past the current time so that today is still yesterday
+	day.SetStartsAt(now.Sub(midnight).Truncate(time.Minute) + time.Hour)
+	t.Cleanup(func() { day.SetStartsAt(0) })
+
+	yesterday := midnight.AddDate(0, 0, -1)
+	e := entry.Entry{ID: "late0001", Content: "# late night", CreatedAt: midnight.Add(-2 * time.Hour)}
+	mock := &mockStorage{
+		entries: map[string][]entry.Entry{yesterday.Format("2006-01-02"): {e}},
+		byID:    map[string]entry.Entry{e.ID: e},
+	}
+	m := newTUIModel(mock, TUIConfig{Editor: "vi", Theme: presets["default-dark"]})
+	sized, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = sized.(pickerModel)
+	updated, _ := m.Update(m.loadTodayCmd())
+	m = updated.(pickerModel)
+
+	if m.dailyEntry == nil || m.dailyEntry.ID != e.ID {
+		t.Fatalf("expected yesterday's entry before the day boundary, got %v", m.dailyEntry)
+	}
+	if view := stripANSI(m.View()); !strings.Contains(view, "Today — "+yesterday.Format("2006-01-02")) {
+		t.Errorf("expected header dated %s:\n%s", yesterday.Format("2006-01-02"), view)
+	}
+}
@@ -0,0 +1,183 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	dctx "github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+// recallLimit caps the results shown for a recall query.
+const recallLimit = 50
+
+// recallItem implements list.Item for a recalled entry.
+type recallItem struct {
+	result dctx.EntryResult
+}
+
+func (r recallItem) Title() string       { return fmt.Sprintf("%s  %s", r.result.Date, r.result.ID) }
+func (r recallItem) Description() string { return r.result.Preview }
+func (r recallItem) FilterValue() string { return r.result.Preview }
+
+type recallLoadedMsg struct {
+	query   string
+	results []dctx.EntryResult
+	err     error
+}
+
+type entryEnrichedMsg struct {
+	entryID  string
+	enriched dctx.EnrichedContent
+}
+
+// startRecall opens the recall query prompt.
+func (m pickerModel) startRecall() (tea.Model, tea.Cmd) {
+	ti := textinput.New()
+	ti.Placeholder = "recall entries about..."
+	ti.Prompt = "recall: "
+	ti.SetValue(m.recallQuery)
+	ti.Focus()
+	ti.CharLimit = maxJotInputLength
+	ti.Width = m.contentWidth() - 12
+	m.recallInput = ti
+	m.recallActive = true
+	return m, textinput.Blink
+}
+
+func (m pickerModel) updateRecallInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		m.recallActive = false
+		query := strings.TrimSpace(m.recallInput.Value())
+		if query == "" {
+			return m, nil
+		}
+		provider := m.cfg.Recall
+		return m, func() tea.Msg {
+			results, err := provider.Search(context.Background(), query, recallLimit)
+			return recallLoadedMsg{query: query, results: results, err: err}
+		}
+	case "esc":
+		m.recallActive = false
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.recallInput, cmd = m.recallInput.Update(msg)
+	return m, cmd
+}
+
+// showRecallResults lists the results of a recall query.
+func (m pickerModel) showRecallResults(msg recallLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.err = msg.err
+		return m, tea.Quit
+	}
+	items := make([]list.Item, len(msg.results))
+	for i, r := range msg.results {
+		items[i] = recallItem{result: r}
+	}
+	m.recallQuery = msg.query
+	m.recallList = m.cfg.Theme.NewList(items, m.contentWidth(), m.height-2)
+	m.recallList.Title = fmt.Sprintf("Recall: %q (%d)", msg.query, len(msg.results))
+	m.recallList.SetShowHelp(false)
+	if m.screen != screenRecall {
+		m.recallPrev = m.screen
+	}
+	m.screen = screenRecall
+	return m, nil
+}
+
+func (m pickerModel) updateRecall(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "esc", "backspace":
+		m.screen = m.recallPrev
+		switch m.recallPrev {
+		case screenToday:
+			return m, m.loadTodayCmd
+		case screenDateList:
+			return m.loadDateList()
+		case screenDayDetail:
+			return m.loadDayDetail()
+		case screenEntryDetail:
+			return m.loadEntryDetail(m.entry.ID)
+		}
+		return m, nil
+	case "enter":
+		if item, ok := m.recallList.SelectedItem().(recallItem); ok {
+			return m.loadEntryDetail(item.result.ID)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.recallList, cmd = m.recallList.Update(msg)
+	return m, cmd
+}
+
+// enrichCmd asks the recall provider for suggestions about e, when it
+// supports enrichment.
+func (m pickerModel) enrichCmd(e entry.Entry) tea.Cmd {
+	enricher, ok := m.cfg.Recall.(dctx.Enricher)
+	if !ok {
+		return nil
+	}
+	return func() tea.Msg {
+		enriched, err := enricher.Enrich(context.Background(), e.Content)
+		if err != nil {
+			// Suggestions are optional; show none
+			return nil
+		}
+		return entryEnrichedMsg{entryID: e.ID, enriched: enriched}
+	}
+}
+
+// applyEnrichment shows suggestions for the entry still in detail view.
+func (m pickerModel) applyEnrichment(msg entryEnrichedMsg) (tea.Model, tea.Cmd) {
+	if m.screen != screenEntryDetail || m.entry.ID != msg.entryID {
+		return m, nil
+	}
+	m.enrichment = &msg.enriched
+	m.viewport.SetContent(m.formatEntry())
+	return m, nil
+}
+
+// formatEnrichment renders suggestions for the entry in detail view, leaving
+// out tags already attached as contexts.
+func (m pickerModel) formatEnrichment() string {
+	if m.enrichment == nil {
+		return ""
+	}
+	attached := map[string]bool{}
+	for _, ref := range m.entry.Contexts {
+		attached[ref.ContextName] = true
+	}
+	var tags []string
+	for _, t := range m.enrichment.SuggestedTags {
+		if !attached[t] {
+			tags = append(tags, t)
+		}
+	}
+
+	var lines []string
+	if len(tags) > 0 {
+		lines = append(lines, "Suggested tags: "+strings.Join(tags, ", "))
+	}
+	if m.enrichment.Mood != "" {
+		lines = append(lines, "Mood: "+m.enrichment.Mood)
+	}
+	for _, f := range m.enrichment.FollowUpPrompts {
+		lines = append(lines, "→ "+f)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n" + m.cfg.Theme.HelpStyle().Render(strings.Join(lines, "\n")) + "\n"
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	gocontext "context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+	"github.com/chris-regnier/diaryctl/internal/storage"
+)
+
+// mockRecall is a recall provider that returns fixed results and enrichment.
+type mockRecall struct {
+	queries []string
+}
+
+func (p *mockRecall) Search(ctx gocontext.Context, query string, limit int) ([]context.EntryResult, error) {
+	p.queries = append(p.queries, query)
+	return []context.EntryResult{{ID: "entry001", Date: "2026-02-03", Preview: "Planned the migration"}}, nil
+}
+
+func (p *mockRecall) Filter(ctx gocontext.Context, opts storage.ListOptions) ([]context.EntryResult, error) {
+	return nil, nil
+}
+
+func (p *mockRecall) Enrich(ctx gocontext.Context, content string) (context.EnrichedContent, error) {
+	return context.EnrichedContent{
+		Content:         content,
+		SuggestedTags:   []string{"feature/auth", "db"},
+		FollowUpPrompts: []string{"Follow up on: rollback plan"},
+	}, nil
+}
+
+// runCmd executes cmd and feeds its message back into the model.
+func runCmd(t *testing.T, m pickerModel, cmd tea.Cmd) pickerModel {
+	t.Helper()
+	if cmd == nil {
+		return m
+	}
+	msg := cmd()
+	if msg == nil {
+		return m
+	}
+	updated, _ := m.Update(msg)
+	return updated.(pickerModel)
+}
+
+func TestRecallSearchAndEnrich(t *testing.T) {
+	now := time.Now()
+	e := entry.Entry{
+		ID: "entry001", Content: "Planned the migration", CreatedAt: now, UpdatedAt: now,
+		Contexts: []entry.ContextRef{{ContextID: "ctx001", ContextName: "feature/auth"}},
+	}
+	mock := &mockStorage{
+		entries: map[string][]entry.Entry{},
+		byID:    map[string]entry.Entry{"entry001": e},
+	}
+	recall := &mockRecall{}
+	m := newTUIModel(mock, TUIConfig{Editor: "vi", Theme: presets["default-dark"], Recall: recall})
+	sized, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = sized.(pickerModel)
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	m = updated.(pickerModel)
+	if !m.recallActive {
+		t.Fatal("expected recall prompt after pressing 'r'")
+	}
+	for _, r := range "migration" {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = updated.(pickerModel)
+	}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, updated.(pickerModel), cmd)
+
+	if m.screen != screenRecall || len(m.recallList.Items()) != 1 {
+		t.Fatalf("expected recall results, got screen %d with %d items", m.screen, len(m.recallList.Items()))
+	}
+	if len(recall.queries) != 1 || recall.queries[0] != "migration" {
+		t.Errorf("queries = %v", recall.queries)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, updated.(pickerModel), cmd)
+	if m.screen != screenEntryDetail || m.entry.ID != "entry001" {
+		t.Fatalf("expected entry detail, got screen %d", m.screen)
+	}
+	out := stripANSI(m.formatEntry())
+	// Already attached contexts are not suggested again
+	if !strings.Contains(out, "Suggested tags: db") {
+		t.Errorf("expected suggested tags in entry detail:\n%s", out)
+	}
+	if !strings.Contains(out, "→ Follow up on: rollback plan") {
+		t.Errorf("expected follow-up prompt in entry detail:\n%s", out)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(pickerModel)
+	if m.screen != screenRecall {
+		t.Errorf("expected esc to return to recall results, got screen %d", m.screen)
+	}
+}
+
+func TestRecallDisabledWithoutProvider(t *testing.T) {
+	mock := &mockStorage{entries: map[string][]entry.Entry{}, byID: map[string]entry.Entry{}}
+	m := newTUIModel(mock, TUIConfig{Editor: "vi", Theme: presets["default-dark"]})
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	if updated.(pickerModel).recallActive {
+		t.Error("recall should be disabled without a provider")
+	}
+}
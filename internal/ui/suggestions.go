@@ -0,0 +1,91 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	dctx "github.com/chris-regnier/diaryctl/internal/context"
+)
+
+// loadPending returns the enrichment suggestions pending for an entry, or nil
+// when there are none or no data directory is configured.
+func (m pickerModel) loadPending(entryID string) *dctx.PendingSuggestion {
+	if m.cfg.DataDir == "" {
+		return nil
+	}
+	p, ok, err := dctx.PendingFor(m.cfg.DataDir, entryID)
+	if err != nil || !ok {
+		return nil
+	}
+	return &p
+}
+
+// acceptPending attaches the pending tags and contexts to the entry in
+// detail view and refreshes it.
+func (m pickerModel) acceptPending() (tea.Model, tea.Cmd) {
+	if _, err := dctx.AcceptPendingSuggestion(m.cfg.DataDir, m.store, m.entry.ID); err != nil {
+		m.err = err
+		return m, tea.Quit
+	}
+	e, err := m.store.Get(m.entry.ID)
+	if err != nil {
+		m.err = err
+		return m, tea.Quit
+	}
+	m.entry = e
+	m.pending = nil
+	m.viewport.SetContent(m.formatEntry())
+	return m, nil
+}
+
+// dismissPending drops the suggestions pending for the entry in detail view.
+func (m pickerModel) dismissPending() (tea.Model, tea.Cmd) {
+	if err := dctx.DismissPendingSuggestion(m.cfg.DataDir, m.entry.ID); err != nil {
+		m.err = err
+		return m, tea.Quit
+	}
+	m.pending = nil
+	m.viewport.SetContent(m.formatEntry())
+	return m, nil
+}
+
+// enrich runs the configured enrichment for a newly written entry and
+// returns why it failed, or "" when it succeeded or is disabled.
+func (m pickerModel) enrich(entryID string, content string) string {
+	if m.cfg.Enrich == nil {
+		return ""
+	}
+	if err := m.cfg.Enrich(entryID, content); err != nil {
+		return "enrichment failed: " + err.Error()
+	}
+	return ""
+}
+
+// dropPending drops the suggestions pending for a deleted entry.
+func (m pickerModel) dropPending(entryID string) error {
+	if m.cfg.DataDir == "" {
+		return nil
+	}
+	return dctx.DismissPendingSuggestion(m.cfg.DataDir, entryID)
+}
+
+// formatPending renders the suggestions pending for the entry in detail view.
+func (m pickerModel) formatPending() string {
+	if m.pending == nil {
+		return ""
+	}
+	var lines []string
+	if names := m.pending.Names(); len(names) > 0 {
+		lines = append(lines, "Pending contexts: "+strings.Join(names, ", ")+"  (a accept · s dismiss)")
+	}
+	if m.pending.Mood != "" {
+		lines = append(lines, "Suggested mood: "+m.pending.Mood)
+	}
+	for _, f := range m.pending.FollowUps {
+		lines = append(lines, "→ "+f)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return "\n" + m.cfg.Theme.AccentStyle().Render(strings.Join(lines, "\n")) + "\n"
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/chris-regnier/diaryctl/internal/context"
+	"github.com/chris-regnier/diaryctl/internal/entry"
+)
+
+func TestPendingSuggestionsAcceptAndDismiss(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	mock := &mockStorage{
+		entries: map[string][]entry.Entry{},
+		byID: map[string]entry.Entry{
+			"entry001": {ID: "entry001", Content: "Planned the migration", CreatedAt: now, UpdatedAt: now},
+			"entry002": {ID: "entry002", Content: "Lunch", CreatedAt: now, UpdatedAt: now},
+		},
+	}
+	for _, p := range []context.PendingSuggestion{
+		{EntryID: "entry001", Tags: []string{"db"}, Mood: "focused"},
+		{EntryID: "entry002", Tags: []string{"food"}},
+	} {
+		if err := context.SavePendingSuggestion(dir, p); err != nil {
+			t.Fatalf("SavePendingSuggestion: %v", err)
+		}
+	}
+	m := newTUIModel(mock, TUIConfig{Editor: "vi", Theme: presets["default-dark"], DataDir: dir})
+	sized, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	m = sized.(pickerModel)
+
+	updated, _ := m.loadEntryDetail("entry001")
+	m = updated.(pickerModel)
+	out := stripANSI(m.formatEntry())
+	if !strings.Contains(out, "Pending contexts: db") || !strings.Contains(out, "Suggested mood: focused") {
+		t.Fatalf("expected pending suggestions in entry detail:\n%s", out)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
+	m = updated.(pickerModel)
+	if m.pending != nil {
+		t.Error("expected no pending suggestions after accepting")
+	}
+	if len(mock.contexts) != 1 || mock.contexts[0].Name != "db" || len(mock.entryContexts["entry001"]) != 1 {
+		t.Errorf("expected db context created and attached, got %+v / %+v", mock.contexts, mock.entryContexts)
+	}
+
+	updated, _ = m.loadEntryDetail("entry002")
+	m = updated.(pickerModel)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	m = updated.(pickerModel)
+	if m.pending != nil || len(mock.entryContexts["entry002"]) != 0 {
+		t.Error("expected suggestions dismissed without attaching")
+	}
+	if all, _ := context.LoadPendingSuggestions(dir); len(all) != 0 {
+		t.Errorf("expected no pending suggestions left, got %+v", all)
+	}
+}
+
+func TestJotRunsEnrichment(t *testing.T) {
+	now := time.Now()
+	existing := entry.Entry{ID: "entry001", Content: "# Today", CreatedAt: now, UpdatedAt: now}
+	mock := &mockStorage{
+		entries: map[string][]entry.Entry{},
+		byID:    map[string]entry.Entry{"entry001": existing},
+	}
+	var gotID, gotContent string
+	failure := errors.New("endpoint unreachable")
+	var enrichErr error
+	m := newTUIModel(mock, TUIConfig{
+		Editor:           "vi",
+		Theme:            presets["default-dark"],
+		ContextResolvers: []string{},
+		Enrich: func(entryID string, content string) error {
+			gotID, gotContent = entryID, content
+			return enrichErr
+		},
+	})
+	m.jotTarget = &existing
+
+	msg := m.doJot("bought groceries").(jotCompleteMsg)
+	if msg.err != nil || msg.enrichErr != "" {
+		t.Fatalf("unexpected jot result %+v", msg)
+	}
+	if gotID != "entry001" || gotContent != "bought groceries" {
+		t.Errorf("enriched %q with %q, want entry001 with the jot text", gotID, gotContent)
+	}
+
+	// A failing enrichment keeps the jot and is shown instead of quitting
+	enrichErr = failure
+	msg = m.doJot("ran errands").(jotCompleteMsg)
+	if msg.err != nil {
+		t.Fatalf("jot error: %v", msg.err)
+	}
+	sized, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	updated, _ := sized.(pickerModel).Update(msg)
+	m = updated.(pickerModel)
+	if m.err != nil {
+		t.Fatalf("expected the TUI to keep running, got err %v", m.err)
+	}
+	if !strings.Contains(stripANSI(m.View()), "enrichment failed: endpoint unreachable") {
+		t.Error("expected the enrichment failure in the view")
+	}
+	if !strings.Contains(mock.byID["entry001"].Content, "ran errands") {
+		t.Error("expected the jot to be saved despite the enrichment failure")
+	}
+}
+
+func TestDeleteDropsPendingSuggestions(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	e := entry.Entry{ID: "entry001", Content: "Planned the migration", CreatedAt: now, UpdatedAt: now}
+	mock := &mockStorage{
+		entries: map[string][]entry.Entry{},
+		byID:    map[string]entry.Entry{"entry001": e},
+	}
+	if err := context.SavePendingSuggestion(dir, context.PendingSuggestion{EntryID: "entry001", Tags: []string{"db"}}); err != nil {
+		t.Fatalf("SavePendingSuggestion: %v", err)
+	}
+	m := newTUIModel(mock, TUIConfig{Editor: "vi", Theme: presets["default-dark"], DataDir: dir})
+	m.deleteActive = true
+	m.deleteEntry = e
+
+	_, cmd := m.updateDeleteConfirm(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if msg := cmd().(deleteCompleteMsg); msg.err != nil {
+		t.Fatalf("delete error: %v", msg.err)
+	}
+	if all, _ := context.LoadPendingSuggestions(dir); len(all) != 0 {
+		t.Errorf("expected pending suggestions dropped with the entry, got %+v", all)
+	}
+}